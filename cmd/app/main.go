@@ -1,9 +1,14 @@
 package main
 
 import (
+	"context"
+	"log"
 	"raion-assessment/config"
+	domain "raion-assessment/domain/entity"
 	"raion-assessment/internal/di"
 	"raion-assessment/internal/routes"
+	"raion-assessment/internal/storage"
+	"raion-assessment/internal/transcode"
 
 	_ "raion-assessment/docs"
 )
@@ -26,13 +31,32 @@ func main() {
 	serverPort := config.GetServerPort()
 	jwtSecret := config.GetJWTSecret()
 	refreshSecret := config.GetRefreshSecret()
+	counterReconcileInterval := config.GetCounterReconcileInterval()
+	graphQLConfig := config.GetGraphQLConfig()
+	storageConfig := config.GetStorageConfig()
+	mediaWorkerConfig := config.GetMediaWorkerConfig()
+	uploadConfig := config.GetUploadConfig()
+	mediaCleanupConfig := config.GetMediaCleanupConfig()
 
 	db := config.InitDatabase()
 	defer db.Close()
 
-	container := di.NewContainer(db, jwtSecret, refreshSecret)
+	mediaStore, err := storage.NewMediaStore(storageConfig)
+	if err != nil {
+		log.Fatalf("Failed to set up media storage: %v", err)
+	}
+
+	transcoder := transcode.NewFFmpeg(mediaWorkerConfig.FFmpegPath, domain.MaxVideoSide, domain.MaxVideoDuration)
+
+	container := di.NewContainer(db, mediaStore, transcoder, jwtSecret, refreshSecret, counterReconcileInterval, mediaWorkerConfig.Interval, uploadConfig.Expiry, uploadConfig.SweepInterval, uploadConfig.Quota, mediaCleanupConfig.Interval, mediaCleanupConfig.GracePeriod)
+	go container.CounterReconciler.Start(context.Background())
+	go container.MediaProcessor.Start(context.Background())
+	go container.UploadExpirer.Start(context.Background())
+	go container.MediaSweeper.Start(context.Background())
+	go container.EventProjector.Start(context.Background())
+
 	app := config.SetupFiber()
-	routes.SetupRoutes(app, *container, jwtSecret)
+	routes.SetupRoutes(app, *container, jwtSecret, graphQLConfig)
 
 	config.StartServer(app, serverPort)
 }
\ No newline at end of file
@@ -4,6 +4,8 @@ import (
 	"context"
 	"log"
 	"os"
+	"raion-assessment/pkg/response"
+	"strconv"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -33,6 +35,155 @@ func GetRefreshSecret() string {
 	return "my-very-secure-refresh-secret"
 }
 
+func GetCounterReconcileInterval() time.Duration {
+	interval, err := time.ParseDuration(os.Getenv("COUNTER_RECONCILE_INTERVAL"))
+	if err != nil || interval <= 0 {
+		return time.Hour
+	}
+	return interval
+}
+
+// GraphQLConfig bounds what the GraphQL endpoint is willing to execute.
+type GraphQLConfig struct {
+	MaxDepth             int
+	MaxComplexity        int
+	PersistedQueriesFile string
+	AllowListOnly        bool
+}
+
+func GetGraphQLConfig() GraphQLConfig {
+	return GraphQLConfig{
+		MaxDepth:             getIntEnv("GRAPHQL_MAX_DEPTH", 10),
+		MaxComplexity:        getIntEnv("GRAPHQL_MAX_COMPLEXITY", 5000),
+		PersistedQueriesFile: os.Getenv("GRAPHQL_PERSISTED_QUERIES"),
+		AllowListOnly:        os.Getenv("GRAPHQL_ALLOWLIST_ONLY") == "true",
+	}
+}
+
+// MediaWorkerConfig configures the background worker that transcodes videos
+// and animated GIFs with ffmpeg.
+type MediaWorkerConfig struct {
+	FFmpegPath string
+	Interval   time.Duration
+}
+
+func GetMediaWorkerConfig() MediaWorkerConfig {
+	interval, err := time.ParseDuration(os.Getenv("MEDIA_WORKER_INTERVAL"))
+	if err != nil || interval <= 0 {
+		interval = 10 * time.Second
+	}
+	return MediaWorkerConfig{
+		FFmpegPath: getEnv("FFMPEG_PATH", "ffmpeg"),
+		Interval:   interval,
+	}
+}
+
+// UploadConfig configures resumable uploads: how long one may sit idle
+// before it expires and how often expired uploads are cleaned up. Quota is
+// how many bytes of media and uploads in progress each user may keep.
+type UploadConfig struct {
+	Expiry        time.Duration
+	SweepInterval time.Duration
+	Quota         int64
+}
+
+func GetUploadConfig() UploadConfig {
+	return UploadConfig{
+		Expiry:        getDurationEnv("UPLOAD_EXPIRY", 24*time.Hour),
+		SweepInterval: getDurationEnv("UPLOAD_SWEEP_INTERVAL", time.Hour),
+		Quota:         getInt64Env("UPLOAD_QUOTA_BYTES", 1<<30),
+	}
+}
+
+// MediaCleanupConfig configures the sweeper that deletes media no post or
+// profile uses any more: how often it runs and how long media must have gone
+// unused first.
+type MediaCleanupConfig struct {
+	Interval    time.Duration
+	GracePeriod time.Duration
+}
+
+func GetMediaCleanupConfig() MediaCleanupConfig {
+	return MediaCleanupConfig{
+		Interval:    getDurationEnv("MEDIA_CLEANUP_INTERVAL", time.Hour),
+		GracePeriod: getDurationEnv("MEDIA_CLEANUP_GRACE_PERIOD", 24*time.Hour),
+	}
+}
+
+// StorageConfig selects and configures the media storage driver. Driver is
+// "local" (the default) or "s3"; the S3 fields also fit S3-compatible servers
+// such as MinIO, which need ForcePathStyle. Media is only handed out through
+// signed URLs that expire after URLExpiry. SigningKey signs the local driver's
+// URLs and has no default: the local driver refuses to start without one.
+// S3PresignEndpoint is the address clients reach the S3 server at, when
+// it differs from the one the app uses.
+type StorageConfig struct {
+	Driver        string
+	LocalDir      string
+	PublicBaseURL string
+	SigningKey    string
+	URLExpiry     time.Duration
+
+	S3Endpoint        string
+	S3Region          string
+	S3Bucket          string
+	S3AccessKey       string
+	S3SecretKey       string
+	S3PublicURL       string
+	S3PresignEndpoint string
+	S3ForcePathStyle  bool
+}
+
+func GetStorageConfig() StorageConfig {
+	return StorageConfig{
+		Driver:        getEnv("STORAGE_DRIVER", "local"),
+		LocalDir:      getEnv("STORAGE_LOCAL_DIR", "./public/uploads"),
+		PublicBaseURL: getEnv("STORAGE_PUBLIC_URL", "https://raion-assessment.elginbrian.com/uploads"),
+		SigningKey:    os.Getenv("STORAGE_SIGNING_KEY"),
+		URLExpiry:     getDurationEnv("STORAGE_URL_EXPIRY", time.Hour),
+
+		S3Endpoint:        getEnv("S3_ENDPOINT", "https://s3.amazonaws.com"),
+		S3Region:          getEnv("S3_REGION", "us-east-1"),
+		S3Bucket:          os.Getenv("S3_BUCKET"),
+		S3AccessKey:       os.Getenv("S3_ACCESS_KEY"),
+		S3SecretKey:       os.Getenv("S3_SECRET_KEY"),
+		S3PublicURL:       os.Getenv("S3_PUBLIC_URL"),
+		S3PresignEndpoint: getEnv("S3_PRESIGN_ENDPOINT", getEnv("S3_ENDPOINT", "https://s3.amazonaws.com")),
+		S3ForcePathStyle:  os.Getenv("S3_FORCE_PATH_STYLE") == "true",
+	}
+}
+
+func getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+func getIntEnv(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+	return value
+}
+
+func getInt64Env(key string, fallback int64) int64 {
+	value, err := strconv.ParseInt(os.Getenv(key), 10, 64)
+	if err != nil || value <= 0 {
+		return fallback
+	}
+	return value
+}
+
+func getDurationEnv(key string, fallback time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+	return value
+}
+
 func InitDatabase() *pgxpool.Pool {
 	databaseURL := GetDatabaseURL()
 	db, err := pgxpool.Connect(context.Background(), databaseURL)
@@ -55,10 +206,33 @@ func InitDatabase() *pgxpool.Pool {
 }
 
 
+// MaxRequestBodySize bounds every request body but tus chunks, which are
+// streamed and bounded by the upload handler. It leaves room for several
+// full-size images of a multi-image post and the form fields sent with them;
+// each image is still held to its own per-type limit.
+const MaxRequestBodySize = 40 << 20
+
+// GetCORSAllowOrigins returns the comma-separated origins browsers may call
+// the API from, set with CORS_ALLOW_ORIGINS. It defaults to "*", any origin.
+func GetCORSAllowOrigins() string {
+	return getEnv("CORS_ALLOW_ORIGINS", "*")
+}
+
 func SetupFiber() *fiber.App {
-	app := fiber.New()
+	app := fiber.New(fiber.Config{
+		ErrorHandler: response.ErrorHandler,
+		BodyLimit:    MaxRequestBodySize,
+		// Bodies are read by middleware.BodyLimit instead, so that tus chunks
+		// can be passed to storage as they arrive.
+		StreamRequestBody: true,
+	})
 	app.Use(logger.New())
-	app.Use(cors.New())
+	app.Use(cors.New(cors.Config{
+		AllowOrigins: GetCORSAllowOrigins(),
+		// Browsers hide response headers from scripts unless they are listed;
+		// tus clients read these to follow and resume their uploads.
+		ExposeHeaders: "Location,Upload-Offset,Upload-Length,Upload-Expires,Tus-Resumable,Tus-Version,Tus-Extension,Tus-Max-Size,Tus-Max-Chunk-Size",
+	}))
 	return app
 }
 
@@ -17,6 +17,20 @@ func RunSQLMigrations(db *pgxpool.Pool) error {
 		migrations.InsertPostsData,
 		migrations.CreateCommentsTable,
 		migrations.CreateLikesTable,
+		migrations.CreateCommentLikesTable,
+		migrations.AddEngagementCounters,
+		migrations.CreateEngagementCounterTriggers,
+		migrations.CreateBookmarksTable,
+		migrations.CreatePaginationIndexes,
+		migrations.CreateMediaTable,
+		migrations.AddMediaPlaceholders,
+		migrations.CreatePostMediaTable,
+		migrations.AddMediaProcessing,
+		migrations.CreateUploadsTable,
+		migrations.AddMediaPurpose,
+		migrations.CreateDirectUploadsTable,
+		migrations.AddMediaReferences,
+		migrations.CreateMediaReferenceTriggers,
 	}
 
 	for i, migration := range Migrations {
@@ -0,0 +1,163 @@
+package migrations
+
+const CreateMediaTable = `
+CREATE EXTENSION IF NOT EXISTS pgcrypto;
+
+CREATE TABLE IF NOT EXISTS media (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    content_type TEXT NOT NULL,
+    width INTEGER NOT NULL,
+    height INTEGER NOT NULL,
+    renditions JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS media_owner_id_idx ON media (owner_id);
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS avatar_media_id UUID REFERENCES media(id) ON DELETE SET NULL;
+`
+
+const AddMediaPlaceholders = `
+ALTER TABLE media ADD COLUMN IF NOT EXISTS blurhash TEXT NOT NULL DEFAULT '';
+ALTER TABLE media ADD COLUMN IF NOT EXISTS dominant_color TEXT NOT NULL DEFAULT '';
+`
+
+// AddMediaProcessing lets media wait for the background worker: videos are
+// recorded as pending with their upload under source_key, and the worker
+// claims them one at a time through claimed_at and attempts.
+const AddMediaProcessing = `
+ALTER TABLE media ADD COLUMN IF NOT EXISTS kind TEXT NOT NULL DEFAULT 'image'
+    CHECK (kind IN ('image', 'video'));
+ALTER TABLE media ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'ready'
+    CHECK (status IN ('pending', 'ready', 'failed'));
+ALTER TABLE media ADD COLUMN IF NOT EXISTS duration_ms INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE media ADD COLUMN IF NOT EXISTS source_key TEXT;
+ALTER TABLE media ADD COLUMN IF NOT EXISTS attempts INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE media ADD COLUMN IF NOT EXISTS claimed_at TIMESTAMP;
+ALTER TABLE media ADD COLUMN IF NOT EXISTS error TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS media_pending_idx ON media (created_at) WHERE status = 'pending';
+`
+
+// AddMediaPurpose records which renditions media was ingested with, so media
+// uploaded ahead of time can only be attached where it fits. Existing avatars
+// are found through users.avatar_media_id the first time it runs.
+const AddMediaPurpose = `
+DO $$
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1 FROM information_schema.columns
+        WHERE table_name = 'media' AND column_name = 'purpose'
+    ) THEN
+        ALTER TABLE media ADD COLUMN purpose TEXT NOT NULL DEFAULT 'posts'
+            CHECK (purpose IN ('posts', 'profile'));
+
+        UPDATE media SET purpose = 'profile'
+        WHERE id IN (SELECT avatar_media_id FROM users WHERE avatar_media_id IS NOT NULL);
+    END IF;
+END $$;
+`
+
+// AddMediaReferences counts the posts and profiles using each media item and
+// records since when an unused one has been unused, so the sweeper can delete
+// it once a grace period has passed. size_bytes is what an item keeps in the
+// media store and users.storage_used the total of everything a user owns.
+// References to existing media are counted the first time it runs; media
+// stored before sizes were recorded counts as empty.
+const AddMediaReferences = `
+DO $$
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1 FROM information_schema.columns
+        WHERE table_name = 'media' AND column_name = 'ref_count'
+    ) THEN
+        ALTER TABLE media ADD COLUMN ref_count INTEGER NOT NULL DEFAULT 0;
+        ALTER TABLE media ADD COLUMN unreferenced_at TIMESTAMP DEFAULT NOW();
+
+        UPDATE media m
+        SET ref_count = c.total, unreferenced_at = CASE WHEN c.total = 0 THEN NOW() END
+        FROM (
+            SELECT m2.id,
+                (SELECT COUNT(*) FROM post_media pm WHERE pm.media_id = m2.id) +
+                (SELECT COUNT(*) FROM users u WHERE u.avatar_media_id = m2.id) AS total
+            FROM media m2
+        ) c
+        WHERE m.id = c.id;
+    END IF;
+END $$;
+
+ALTER TABLE media ADD COLUMN IF NOT EXISTS size_bytes BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS storage_used BIGINT NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS media_unreferenced_idx ON media (unreferenced_at) WHERE ref_count = 0;
+CREATE INDEX IF NOT EXISTS users_avatar_media_id_idx ON users (avatar_media_id) WHERE avatar_media_id IS NOT NULL;
+`
+
+// CreateMediaReferenceTriggers keeps media.ref_count in step with post_media
+// and users.avatar_media_id, and users.storage_used with the sizes of the
+// media each user owns.
+const CreateMediaReferenceTriggers = `
+CREATE OR REPLACE FUNCTION adjust_media_ref_count(target_id UUID, delta INTEGER) RETURNS VOID AS $$
+BEGIN
+    UPDATE media
+    SET ref_count = GREATEST(ref_count + delta, 0),
+        unreferenced_at = CASE WHEN ref_count + delta > 0 THEN NULL ELSE COALESCE(unreferenced_at, NOW()) END
+    WHERE id = target_id;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION sync_post_media_ref_count() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        PERFORM adjust_media_ref_count(NEW.media_id, 1);
+    ELSIF TG_OP = 'DELETE' THEN
+        PERFORM adjust_media_ref_count(OLD.media_id, -1);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS post_media_sync_media_ref_count ON post_media;
+CREATE TRIGGER post_media_sync_media_ref_count
+    AFTER INSERT OR DELETE ON post_media
+    FOR EACH ROW EXECUTE FUNCTION sync_post_media_ref_count();
+
+CREATE OR REPLACE FUNCTION sync_avatar_ref_count() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'UPDATE' AND NEW.avatar_media_id IS NOT DISTINCT FROM OLD.avatar_media_id THEN
+        RETURN NULL;
+    END IF;
+    IF TG_OP IN ('UPDATE', 'DELETE') AND OLD.avatar_media_id IS NOT NULL THEN
+        PERFORM adjust_media_ref_count(OLD.avatar_media_id, -1);
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') AND NEW.avatar_media_id IS NOT NULL THEN
+        PERFORM adjust_media_ref_count(NEW.avatar_media_id, 1);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS users_sync_avatar_ref_count ON users;
+CREATE TRIGGER users_sync_avatar_ref_count
+    AFTER INSERT OR DELETE OR UPDATE OF avatar_media_id ON users
+    FOR EACH ROW EXECUTE FUNCTION sync_avatar_ref_count();
+
+CREATE OR REPLACE FUNCTION sync_user_storage_used() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        UPDATE users SET storage_used = storage_used + NEW.size_bytes WHERE id = NEW.owner_id;
+    ELSIF TG_OP = 'DELETE' THEN
+        UPDATE users SET storage_used = GREATEST(storage_used - OLD.size_bytes, 0) WHERE id = OLD.owner_id;
+    ELSIF NEW.size_bytes <> OLD.size_bytes THEN
+        UPDATE users SET storage_used = GREATEST(storage_used + NEW.size_bytes - OLD.size_bytes, 0) WHERE id = NEW.owner_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS media_sync_user_storage_used ON media;
+CREATE TRIGGER media_sync_user_storage_used
+    AFTER INSERT OR DELETE OR UPDATE OF size_bytes ON media
+    FOR EACH ROW EXECUTE FUNCTION sync_user_storage_used();
+`
@@ -0,0 +1,12 @@
+package migrations
+
+const CreatePaginationIndexes = `
+CREATE INDEX IF NOT EXISTS idx_posts_created_at_id ON posts (created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_posts_user_created_at_id ON posts (user_id, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_users_created_at_id ON users (created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_comments_post_created_at_id ON comments (post_id, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_comments_post_like_count ON comments (post_id, like_count DESC, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_likes_post_created_at_id ON likes (post_id, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_likes_user_created_at_id ON likes (user_id, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_comment_likes_comment_created_at_id ON comment_likes (comment_id, created_at DESC, id DESC);
+`
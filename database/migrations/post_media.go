@@ -0,0 +1,31 @@
+package migrations
+
+// CreatePostMediaTable moves post images into an ordered post_media table,
+// carrying over the single image recorded in posts.media_id before dropping
+// that column.
+const CreatePostMediaTable = `
+CREATE TABLE IF NOT EXISTS post_media (
+    post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    media_id UUID NOT NULL REFERENCES media(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL CHECK (position >= 0),
+    alt_text TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (post_id, media_id),
+    UNIQUE (post_id, position)
+);
+
+CREATE INDEX IF NOT EXISTS post_media_media_id_idx ON post_media (media_id);
+
+DO $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM information_schema.columns
+        WHERE table_name = 'posts' AND column_name = 'media_id'
+    ) THEN
+        INSERT INTO post_media (post_id, media_id, position)
+        SELECT id, media_id, 0 FROM posts WHERE media_id IS NOT NULL
+        ON CONFLICT DO NOTHING;
+
+        ALTER TABLE posts DROP COLUMN media_id;
+    END IF;
+END $$;
+`
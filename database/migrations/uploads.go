@@ -0,0 +1,36 @@
+package migrations
+
+// CreateUploadsTable tracks resumable uploads in progress. The received bytes
+// live in the media store as one object per chunk, listed in order in
+// chunk_keys; upload_offset is the total received so far.
+const CreateUploadsTable = `
+CREATE TABLE IF NOT EXISTS uploads (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    upload_length BIGINT NOT NULL CHECK (upload_length > 0),
+    upload_offset BIGINT NOT NULL DEFAULT 0 CHECK (upload_offset BETWEEN 0 AND upload_length),
+    metadata TEXT NOT NULL DEFAULT '',
+    chunk_keys TEXT[] NOT NULL DEFAULT '{}',
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS uploads_expires_at_idx ON uploads (expires_at);
+`
+
+// CreateDirectUploadsTable tracks files clients send straight to the media
+// store through presigned URLs, until they are completed or expire.
+const CreateDirectUploadsTable = `
+CREATE TABLE IF NOT EXISTS direct_uploads (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    purpose TEXT NOT NULL CHECK (purpose IN ('posts', 'profile')),
+    object_key TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    size BIGINT NOT NULL CHECK (size > 0),
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS direct_uploads_expires_at_idx ON direct_uploads (expires_at);
+`
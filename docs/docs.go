@@ -234,6 +234,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Email already registered",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -277,6 +283,176 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Not the comment's author",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/media/uploads": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a short-lived URL to send a file straight to storage instead of through the API. Declare what the file is for (\"posts\" accepts images and videos, \"profile\" only images), its content type and its exact size in bytes; the same limits apply as to files sent to the API. Send the file within 15 minutes as a single request with the returned method, URL and headers, then complete the upload to turn it into media. Requires JWT authentication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "media"
+                ],
+                "summary": "Start a direct upload",
+                "parameters": [
+                    {
+                        "description": "The file about to be uploaded",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateDirectUploadRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Where and how to send the file",
+                        "schema": {
+                            "$ref": "#/definitions/response.CreateDirectUploadResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "507": {
+                        "description": "Storage quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/media/uploads/{id}/complete": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Check the file sent to a direct upload's URL and turn it into media. The file must have the declared content type and size. Attach the returned media to a new post with media_id, or use it as the profile image with media_id when updating the user. Videos are transcoded in the background and start out \"pending\". Requires JWT authentication.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "media"
+                ],
+                "summary": "Complete a direct upload",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Direct upload ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "The new media",
+                        "schema": {
+                            "$ref": "#/definitions/response.CompleteDirectUploadResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request, or the file is missing or not what was declared",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Direct upload not found or expired",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "507": {
+                        "description": "Storage quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/media/usage": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get how much of their storage quota the current user has taken up, in bytes: the media they own and the declared size of resumable and direct uploads they have started. Uploads that would not fit in what remains fail with 507 and the code QUOTA_EXCEEDED. Media no post or profile uses any more counts until it is cleaned up. Requires JWT authentication.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "media"
+                ],
+                "summary": "Get storage usage",
+                "responses": {
+                    "200": {
+                        "description": "Storage usage and quota",
+                        "schema": {
+                            "$ref": "#/definitions/response.GetStorageUsageResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -288,7 +464,7 @@ const docTemplate = `{
         },
         "/posts": {
             "get": {
-                "description": "Get a list of all posts, along with details like the user who created them, the caption, image URL, and timestamps.",
+                "description": "Get a list of all posts, along with details like the user who created them, the caption, image URL, and timestamps. When called with a bearer token, each post reports whether the caller liked it.",
                 "produces": [
                     "application/json"
                 ],
@@ -296,6 +472,21 @@ const docTemplate = `{
                     "posts"
                 ],
                 "summary": "Get all posts",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size (max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor returned as next_cursor by the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "Successful fetch posts response",
@@ -323,7 +514,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new post with a caption. Optionally, you can upload an image. If an image is uploaded, its URL will be returned in the response. Requires JWT authentication.",
+                "description": "Create a new post with a caption and up to 10 images or videos, sent as repeated \"media\" files in display order; repeated \"images\" files and a single \"image\" file are still accepted. Each image must be a JPEG, PNG or WebP of at most 8 MB or a GIF of at most 4 MB, and at most 10000 pixels on each side. Each video must be an MP4, MOV or WebM of at most 32 MB. Videos and animated GIFs may play for at most 60 seconds and are transcoded in the background: the post's status stays \"pending\" until they are ready, or becomes \"failed\". The whole request may be at most 40 MB; larger files can be sent beforehand as resumable uploads and attached with repeated \"upload_id\" fields, after any files, or sent straight to storage as direct uploads and attached with repeated \"media_id\" fields, after any uploads. Repeated \"alt_text\" fields describe the files, uploads and media in the same order. image_url in the response is the first image, or the poster frame of a video once it is ready. Requires JWT authentication.",
                 "consumes": [
                     "multipart/form-data"
                 ],
@@ -344,9 +535,51 @@ const docTemplate = `{
                     },
                     {
                         "type": "file",
-                        "description": "Post image (optional)",
+                        "description": "Post images and videos, in display order",
+                        "name": "media",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Post images, in display order, for older clients",
+                        "name": "images",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Alt text for each image, in the same order",
+                        "name": "alt_text",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Single post image, for older clients",
                         "name": "image",
                         "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "IDs of finished resumable uploads, in display order after any files",
+                        "name": "upload_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "IDs of media from completed direct uploads, in display order after any uploads",
+                        "name": "media_id",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
@@ -367,6 +600,12 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
+                    },
+                    "507": {
+                        "description": "Storage quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
                     }
                 }
             }
@@ -388,6 +627,19 @@ const docTemplate = `{
                         "name": "user_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size (max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor returned as next_cursor by the previous page",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -403,6 +655,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -444,6 +702,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Post not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -485,6 +749,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Not the post's author",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -499,7 +769,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update only the caption of an existing post. Only the post creator is allowed to make this change. Requires JWT authentication.",
+                "description": "Update the caption of an existing post, rearrange its images, or both, in a single change. An empty caption clears it; a caption left out is kept. \"media\" lists the images to keep by media_id in their new order, each with its alt text; images left out are removed, at least one must remain, and new images cannot be added. Only the post creator is allowed to make this change. Requires JWT authentication.",
                 "consumes": [
                     "application/json"
                 ],
@@ -509,7 +779,7 @@ const docTemplate = `{
                 "tags": [
                     "posts"
                 ],
-                "summary": "Update an existing post's caption",
+                "summary": "Update an existing post's caption or images",
                 "parameters": [
                     {
                         "type": "string",
@@ -519,7 +789,7 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Request body with updated caption",
+                        "description": "Request body with updated caption and/or images",
                         "name": "request",
                         "in": "body",
                         "required": true,
@@ -541,6 +811,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Not the post's author",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -552,7 +828,7 @@ const docTemplate = `{
         },
         "/posts/{post_id}/comments": {
             "get": {
-                "description": "Retrieve all comments related to a specific post.",
+                "description": "Retrieve all comments related to a specific post, ordered by newest or by like count. When called with a bearer token, each comment reports whether the caller liked it.",
                 "produces": [
                     "application/json"
                 ],
@@ -567,6 +843,30 @@ const docTemplate = `{
                         "name": "post_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "enum": [
+                            "newest",
+                            "top"
+                        ],
+                        "type": "string",
+                        "default": "newest",
+                        "description": "Sort order",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size (max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor returned as next_cursor by the previous page",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -585,6 +885,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Post not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -650,18 +956,18 @@ const docTemplate = `{
                 }
             }
         },
-        "/posts/{post_id}/like": {
+        "/posts/{post_id}/comments/{comment_id}/like": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Allows a user to like a post. Requires JWT authentication.",
+                "description": "Allows a user to like a comment. Requires JWT authentication.",
                 "tags": [
                     "likes"
                 ],
-                "summary": "Like a post",
+                "summary": "Like a comment",
                 "parameters": [
                     {
                         "type": "string",
@@ -669,11 +975,18 @@ const docTemplate = `{
                         "name": "post_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comment ID",
+                        "name": "comment_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully liked post",
+                        "description": "Successfully liked comment",
                         "schema": {
                             "$ref": "#/definitions/response.LikeResponse"
                         }
@@ -684,6 +997,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Comment not found on this post",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Comment already liked",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -693,16 +1018,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/posts/{post_id}/likes": {
+        "/posts/{post_id}/comments/{comment_id}/likes": {
             "get": {
-                "description": "Fetch all users who liked a specific post",
+                "description": "Fetch all users who liked a specific comment",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "likes"
                 ],
-                "summary": "Get all likes for a post",
+                "summary": "Get all likes for a comment",
                 "parameters": [
                     {
                         "type": "string",
@@ -710,15 +1035,35 @@ const docTemplate = `{
                         "name": "post_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comment ID",
+                        "name": "comment_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size (max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor returned as next_cursor by the previous page",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of users who liked the post",
+                        "description": "List of users who liked the comment",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/response.GetAllLikesResponse"
+                                "$ref": "#/definitions/response.GetAllCommentLikesResponse"
                             }
                         }
                     },
@@ -728,6 +1073,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Comment not found on this post",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -737,18 +1088,18 @@ const docTemplate = `{
                 }
             }
         },
-        "/posts/{post_id}/unlike": {
+        "/posts/{post_id}/comments/{comment_id}/unlike": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Allows a user to remove their like from a post. Requires JWT authentication.",
+                "description": "Allows a user to remove their like from a comment. Requires JWT authentication.",
                 "tags": [
                     "likes"
                 ],
-                "summary": "Unlike a post",
+                "summary": "Unlike a comment",
                 "parameters": [
                     {
                         "type": "string",
@@ -756,11 +1107,18 @@ const docTemplate = `{
                         "name": "post_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comment ID",
+                        "name": "comment_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully unliked post",
+                        "description": "Successfully unliked comment",
                         "schema": {
                             "$ref": "#/definitions/response.LikeResponse"
                         }
@@ -771,12 +1129,173 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "404": {
+                        "description": "Comment not found on this post",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
-                    }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/{post_id}/like": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Allows a user to like a post. Requires JWT authentication.",
+                "tags": [
+                    "likes"
+                ],
+                "summary": "Like a post",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Post ID",
+                        "name": "post_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully liked post",
+                        "schema": {
+                            "$ref": "#/definitions/response.LikeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Post already liked",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/{post_id}/likes": {
+            "get": {
+                "description": "Fetch all users who liked a specific post",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "likes"
+                ],
+                "summary": "Get all likes for a post",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Post ID",
+                        "name": "post_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size (max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor returned as next_cursor by the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of users who liked the post",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.GetAllLikesResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Post not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/{post_id}/unlike": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Allows a user to remove their like from a post. Requires JWT authentication.",
+                "tags": [
+                    "likes"
+                ],
+                "summary": "Unlike a post",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Post ID",
+                        "name": "post_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully unliked post",
+                        "schema": {
+                            "$ref": "#/definitions/response.LikeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
                 }
             }
         },
@@ -797,6 +1316,19 @@ const docTemplate = `{
                         "name": "query",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size (max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor returned as next_cursor by the previous page",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -835,6 +1367,19 @@ const docTemplate = `{
                         "name": "query",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size (max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor returned as next_cursor by the previous page",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -862,6 +1407,267 @@ const docTemplate = `{
                 }
             }
         },
+        "/uploads": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Start a tus 1.0 resumable upload of an image or video of Upload-Length bytes. The upload's address is returned in the Location header; send the file to it with PATCH requests, check how much arrived with HEAD after a dropped connection, and pass the upload ID as upload_id to create a post or update the profile once it is complete. Uploads expire when no chunk arrives for a day, as announced in Upload-Expires. Requires JWT authentication.",
+                "tags": [
+                    "uploads"
+                ],
+                "summary": "Start a resumable upload",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "1.0.0",
+                        "description": "tus protocol version",
+                        "name": "Tus-Resumable",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Size of the whole file in bytes",
+                        "name": "Upload-Length",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated key and base64 value pairs, such as filename",
+                        "name": "Upload-Metadata",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Upload created at the URL in the Location header"
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Unsupported tus version",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Upload too large",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "507": {
+                        "description": "Storage quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "options": {
+                "description": "Report the tus protocol version, extensions and largest upload size the server supports, and the largest chunk a single PATCH may carry.",
+                "tags": [
+                    "uploads"
+                ],
+                "summary": "Describe resumable uploads",
+                "responses": {
+                    "204": {
+                        "description": "Supported tus features, in the Tus-Version, Tus-Extension, Tus-Max-Size and Tus-Max-Chunk-Size headers"
+                    }
+                }
+            }
+        },
+        "/uploads/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a resumable upload and every chunk received for it. Requires JWT authentication.",
+                "tags": [
+                    "uploads"
+                ],
+                "summary": "Cancel a resumable upload",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Upload ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "1.0.0",
+                        "description": "tus protocol version",
+                        "name": "Tus-Resumable",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Upload deleted"
+                    },
+                    "404": {
+                        "description": "Upload not found or expired",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "head": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Report how many bytes of a resumable upload have arrived, in the Upload-Offset header, so an interrupted upload can carry on from there. Requires JWT authentication.",
+                "tags": [
+                    "uploads"
+                ],
+                "summary": "Get the offset of a resumable upload",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Upload ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "1.0.0",
+                        "description": "tus protocol version",
+                        "name": "Tus-Resumable",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Upload-Offset and Upload-Length headers"
+                    },
+                    "404": {
+                        "description": "Upload not found or expired"
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Append the request body to a resumable upload. Upload-Offset must equal the upload's current offset; the new offset is returned in the same header. The body is streamed to storage and may be at most Tus-Max-Chunk-Size bytes, as reported by OPTIONS /uploads, with its Content-Length set. Requires JWT authentication.",
+                "consumes": [
+                    "application/offset+octet-stream"
+                ],
+                "tags": [
+                    "uploads"
+                ],
+                "summary": "Send a chunk of a resumable upload",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Upload ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "1.0.0",
+                        "description": "tus protocol version",
+                        "name": "Tus-Resumable",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset the chunk starts at",
+                        "name": "Upload-Offset",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Chunk stored; the new offset is in the Upload-Offset header"
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Upload not found or expired",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Upload-Offset does not match the upload",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "411": {
+                        "description": "Content-Length missing",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Chunk larger than Tus-Max-Chunk-Size",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Wrong content type",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/users": {
             "get": {
                 "description": "Retrieve a list of all users from the database.",
@@ -872,6 +1678,21 @@ const docTemplate = `{
                     "users"
                 ],
                 "summary": "Get all users",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size (max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor returned as next_cursor by the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "Successful fetch users response",
@@ -919,9 +1740,21 @@ const docTemplate = `{
                     },
                     {
                         "type": "file",
-                        "description": "Updated image (optional)",
+                        "description": "Updated image (optional): JPEG, PNG or WebP up to 8 MB, or GIF up to 4 MB",
                         "name": "image",
                         "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of a finished resumable upload to use as the image instead of a file (optional)",
+                        "name": "upload_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the media of a completed direct upload to use as the image instead of a file or upload (optional)",
+                        "name": "media_id",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
@@ -948,6 +1781,12 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
+                    },
+                    "507": {
+                        "description": "Storage quota exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
                     }
                 }
             }
@@ -1004,6 +1843,19 @@ const docTemplate = `{
                         "name": "user_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size (max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor returned as next_cursor by the previous page",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1022,6 +1874,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -1033,6 +1891,23 @@ const docTemplate = `{
         }
     },
     "definitions": {
+        "domain.FieldError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "min"
+                },
+                "field": {
+                    "type": "string",
+                    "example": "username"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "username must be at least 3 characters long"
+                }
+            }
+        },
         "request.ChangePasswordRequest": {
             "type": "object",
             "required": [
@@ -1064,6 +1939,50 @@ const docTemplate = `{
                 }
             }
         },
+        "request.CreateDirectUploadRequest": {
+            "type": "object",
+            "required": [
+                "content_type",
+                "purpose",
+                "size"
+            ],
+            "properties": {
+                "content_type": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "image/jpeg"
+                },
+                "purpose": {
+                    "type": "string",
+                    "enum": [
+                        "posts",
+                        "profile"
+                    ],
+                    "example": "posts"
+                },
+                "size": {
+                    "type": "integer",
+                    "example": 482113
+                }
+            }
+        },
+        "request.PostMediaItem": {
+            "type": "object",
+            "required": [
+                "media_id"
+            ],
+            "properties": {
+                "alt_text": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": "Sunset over the bay"
+                },
+                "media_id": {
+                    "type": "string",
+                    "example": "6b1f2c9e-8a47-4d3b-9e21-5c0a7f3d2b18"
+                }
+            }
+        },
         "request.RefreshTokenRequest": {
             "type": "object",
             "required": [
@@ -1078,14 +1997,18 @@ const docTemplate = `{
         },
         "request.UpdatePostRequest": {
             "type": "object",
-            "required": [
-                "caption"
-            ],
             "properties": {
                 "caption": {
                     "type": "string",
-                    "minLength": 1,
                     "example": "Had an amazing trip to the mountains!"
+                },
+                "media": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/request.PostMediaItem"
+                    }
                 }
             }
         },
@@ -1153,6 +2076,14 @@ const docTemplate = `{
                     "type": "string",
                     "example": "550e8400-e29b-41d4-a716-446655440000"
                 },
+                "like_count": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "liked_by_me": {
+                    "type": "boolean",
+                    "example": false
+                },
                 "post_id": {
                     "type": "string",
                     "example": "c6f7c988-233f-4f3c-a74d-17f72e4a1b56"
@@ -1167,6 +2098,43 @@ const docTemplate = `{
                 }
             }
         },
+        "response.CommentLike": {
+            "type": "object",
+            "properties": {
+                "comment_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-31T12:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "a1f5e4b3-8d2a-4c39-91a2-47b36295d8a3"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "b3d1a42b-6871-4a47-bec3-6df0980a9c75"
+                }
+            }
+        },
+        "response.CompleteDirectUploadResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 201
+                },
+                "data": {
+                    "$ref": "#/definitions/response.Media"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
         "response.CreateCommentResponse": {
             "type": "object",
             "properties": {
@@ -1182,6 +2150,22 @@ const docTemplate = `{
                 }
             }
         },
+        "response.CreateDirectUploadResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 201
+                },
+                "data": {
+                    "$ref": "#/definitions/response.DirectUpload"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
         "response.CreatePostResponse": {
             "type": "object",
             "properties": {
@@ -1237,17 +2221,96 @@ const docTemplate = `{
                 }
             }
         },
+        "response.DirectUpload": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string",
+                    "example": "2025-02-01T12:00:00Z"
+                },
+                "headers": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string",
+                    "example": "0f8c4a52-5d2e-4b7a-9a3e-1c6d2b9e7f10"
+                },
+                "method": {
+                    "type": "string",
+                    "example": "PUT"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://storage.example.com/media/incoming/2e0850c7/0f8c4a52?X-Amz-Signature=..."
+                },
+                "url_expires_at": {
+                    "type": "string",
+                    "example": "2025-01-31T12:15:00Z"
+                }
+            }
+        },
         "response.ErrorResponse": {
             "type": "object",
             "properties": {
                 "code": {
-                    "type": "integer"
+                    "type": "string",
+                    "example": "NOT_FOUND"
                 },
-                "message": {
-                    "type": "string"
+                "detail": {
+                    "type": "string",
+                    "example": "post not found"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.FieldError"
+                    }
+                },
+                "instance": {
+                    "type": "string",
+                    "example": "/api/v1/posts/1"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 404
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Not Found"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "about:blank"
+                }
+            }
+        },
+        "response.GetAllCommentLikesResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.CommentLike"
+                    }
+                },
+                "has_more": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "next_cursor": {
+                    "type": "string",
+                    "example": "eyJ0IjoiMjAyNS0wMS0wMVQwMDowMDowMFoiLCJpZCI6IjEifQ"
                 },
                 "status": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "success"
                 }
             }
         },
@@ -1263,6 +2326,14 @@ const docTemplate = `{
                         "$ref": "#/definitions/response.Like"
                     }
                 },
+                "has_more": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "next_cursor": {
+                    "type": "string",
+                    "example": "eyJ0IjoiMjAyNS0wMS0wMVQwMDowMDowMFoiLCJpZCI6IjEifQ"
+                },
                 "status": {
                     "type": "string",
                     "example": "success"
@@ -1281,6 +2352,14 @@ const docTemplate = `{
                         "$ref": "#/definitions/response.Post"
                     }
                 },
+                "has_more": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "next_cursor": {
+                    "type": "string",
+                    "example": "eyJ0IjoiMjAyNS0wMS0wMVQwMDowMDowMFoiLCJpZCI6IjEifQ"
+                },
                 "status": {
                     "type": "string",
                     "example": "success"
@@ -1299,6 +2378,14 @@ const docTemplate = `{
                         "$ref": "#/definitions/response.User"
                     }
                 },
+                "has_more": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "next_cursor": {
+                    "type": "string",
+                    "example": "eyJ0IjoiMjAyNS0wMS0wMVQwMDowMDowMFoiLCJpZCI6IjEifQ"
+                },
                 "status": {
                     "type": "string",
                     "example": "success"
@@ -1317,6 +2404,14 @@ const docTemplate = `{
                         "$ref": "#/definitions/response.Comment"
                     }
                 },
+                "has_more": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "next_cursor": {
+                    "type": "string",
+                    "example": "eyJ0IjoiMjAyNS0wMS0wMVQwMDowMDowMFoiLCJpZCI6IjEifQ"
+                },
                 "status": {
                     "type": "string",
                     "example": "success"
@@ -1353,6 +2448,22 @@ const docTemplate = `{
                 }
             }
         },
+        "response.GetStorageUsageResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "data": {
+                    "$ref": "#/definitions/response.StorageUsage"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
         "response.GetUserByIDResponse": {
             "type": "object",
             "properties": {
@@ -1433,13 +2544,85 @@ const docTemplate = `{
                 }
             }
         },
+        "response.Media": {
+            "type": "object",
+            "properties": {
+                "blurhash": {
+                    "type": "string",
+                    "example": "LEHV6nWB2yk8pyo0adR*.7kCMdnj"
+                },
+                "dominant_color": {
+                    "type": "string",
+                    "example": "#6f8fa8"
+                },
+                "duration_ms": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "height": {
+                    "type": "integer",
+                    "example": 4032
+                },
+                "id": {
+                    "type": "string",
+                    "example": "6b1f2c9e-8a47-4d3b-9e21-5c0a7f3d2b18"
+                },
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "image",
+                        "video"
+                    ],
+                    "example": "image"
+                },
+                "purpose": {
+                    "type": "string",
+                    "enum": [
+                        "posts",
+                        "profile"
+                    ],
+                    "example": "posts"
+                },
+                "renditions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.Rendition"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "ready",
+                        "failed"
+                    ],
+                    "example": "ready"
+                },
+                "width": {
+                    "type": "integer",
+                    "example": 3024
+                }
+            }
+        },
         "response.Post": {
             "type": "object",
             "properties": {
+                "author": {
+                    "$ref": "#/definitions/response.PostAuthor"
+                },
+                "bookmarked_by_me": {
+                    "description": "Reserved for bookmarks, which are not supported yet: always false.",
+                    "type": "boolean",
+                    "example": false
+                },
                 "caption": {
                     "type": "string",
                     "example": "Had an amazing day at the beach!"
                 },
+                "comment_count": {
+                    "type": "integer",
+                    "example": 4
+                },
                 "created_at": {
                     "type": "string",
                     "example": "2025-01-31T12:00:00Z"
@@ -1452,6 +2635,29 @@ const docTemplate = `{
                     "type": "string",
                     "example": "https://example.com/images/beach.jpg"
                 },
+                "like_count": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "liked_by_me": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "media": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.PostMedia"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "ready",
+                        "failed"
+                    ],
+                    "example": "ready"
+                },
                 "updated_at": {
                     "type": "string",
                     "example": "2025-01-31T12:30:00Z"
@@ -1462,6 +2668,39 @@ const docTemplate = `{
                 }
             }
         },
+        "response.PostAuthor": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "2e0850c7-d213-4a91-9b78-bb86e3a6f0d3"
+                },
+                "image_url": {
+                    "type": "string",
+                    "example": "https://example.com/profile.jpg"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "john_doe"
+                }
+            }
+        },
+        "response.PostMedia": {
+            "type": "object",
+            "properties": {
+                "alt_text": {
+                    "type": "string",
+                    "example": "Sunset over the bay"
+                },
+                "media": {
+                    "$ref": "#/definitions/response.Media"
+                },
+                "position": {
+                    "type": "integer",
+                    "example": 0
+                }
+            }
+        },
         "response.RefreshTokenData": {
             "type": "object",
             "properties": {
@@ -1510,6 +2749,37 @@ const docTemplate = `{
                 }
             }
         },
+        "response.Rendition": {
+            "type": "object",
+            "properties": {
+                "content_type": {
+                    "type": "string",
+                    "example": "image/jpeg"
+                },
+                "height": {
+                    "type": "integer",
+                    "example": 1080
+                },
+                "name": {
+                    "type": "string",
+                    "enum": [
+                        "video",
+                        "thumbnail",
+                        "feed",
+                        "full"
+                    ],
+                    "example": "feed"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://example.com/uploads/posts/2e0850c7/6b1f2c9e/feed.jpg?expires=1738328400\u0026signature=9f2c..."
+                },
+                "width": {
+                    "type": "integer",
+                    "example": 810
+                }
+            }
+        },
         "response.SearchPostsResponse": {
             "type": "object",
             "properties": {
@@ -1522,6 +2792,14 @@ const docTemplate = `{
                         "$ref": "#/definitions/response.Post"
                     }
                 },
+                "has_more": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "next_cursor": {
+                    "type": "string",
+                    "example": "eyJ0IjoiMjAyNS0wMS0wMVQwMDowMDowMFoiLCJpZCI6IjEifQ"
+                },
                 "status": {
                     "type": "string",
                     "example": "success"
@@ -1540,12 +2818,41 @@ const docTemplate = `{
                         "$ref": "#/definitions/response.User"
                     }
                 },
+                "has_more": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "next_cursor": {
+                    "type": "string",
+                    "example": "eyJ0IjoiMjAyNS0wMS0wMVQwMDowMDowMFoiLCJpZCI6IjEifQ"
+                },
                 "status": {
                     "type": "string",
                     "example": "success"
                 }
             }
         },
+        "response.StorageUsage": {
+            "type": "object",
+            "properties": {
+                "quota": {
+                    "type": "integer",
+                    "example": 1073741824
+                },
+                "remaining": {
+                    "type": "integer",
+                    "example": 1010827264
+                },
+                "reserved": {
+                    "type": "integer",
+                    "example": 10485760
+                },
+                "used": {
+                    "type": "integer",
+                    "example": 52428800
+                }
+            }
+        },
         "response.UpdatePostResponse": {
             "type": "object",
             "properties": {
@@ -1579,6 +2886,9 @@ const docTemplate = `{
         "response.User": {
             "type": "object",
             "properties": {
+                "avatar": {
+                    "$ref": "#/definitions/response.Media"
+                },
                 "bio": {
                     "type": "string",
                     "example": "Hi there!"
@@ -1599,6 +2909,10 @@ const docTemplate = `{
                     "type": "string",
                     "example": "https://example.com/profile.jpg"
                 },
+                "post_count": {
+                    "type": "integer",
+                    "example": 8
+                },
                 "updated_at": {
                     "type": "string",
                     "example": "2025-01-31T12:30:00Z"
@@ -6,16 +6,20 @@ import (
 )
 
 type ICommentRepository interface {
-	GetCommentsByPostID(ctx context.Context, postID string) ([]domain.Comment, error)
+	GetCommentsByPostID(ctx context.Context, postID, viewerID, sort string, page domain.PageRequest) ([]domain.Comment, domain.PageInfo, error)
 	GetCommentByID(ctx context.Context, commentID string) (*domain.Comment, error)
 	CreateComment(ctx context.Context, comment domain.Comment) (*domain.Comment, error)
 	DeleteComment(ctx context.Context, commentID string) error
+	GetCommentsByIDs(ctx context.Context, commentIDs []string, viewerID string) ([]domain.Comment, error)
+	GetCommentsByPostIDs(ctx context.Context, postIDs []string, viewerID, sort string, page domain.PageRequest) (map[string]domain.Page[domain.Comment], error)
 }
 
 
 type ICommentService interface {
-	GetCommentsByPostID(postID string) ([]domain.Comment, error)
+	GetCommentsByPostID(postID, viewerID, sort string, page domain.PageRequest) ([]domain.Comment, domain.PageInfo, error)
 	GetCommentByID(commentID string) (domain.Comment, error)
 	CreateComment(comment domain.Comment) (domain.Comment, error)
 	DeleteComment(commentID string) error
-}
\ No newline at end of file
+	GetCommentsByIDs(ids []string, viewerID string) ([]domain.Comment, error)
+	GetCommentsByPostIDs(postIDs []string, viewerID, sort string, page domain.PageRequest) (map[string]domain.Page[domain.Comment], error)
+}
@@ -0,0 +1,7 @@
+package domain
+
+import "context"
+
+type ICounterRepository interface {
+	ReconcileCounters(ctx context.Context) (int64, error)
+}
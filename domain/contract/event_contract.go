@@ -0,0 +1,10 @@
+package domain
+
+import "context"
+
+// IEventBus is the in-process publish/subscribe channel services use to
+// announce changes. Topics and payload types are listed in domain/entity.
+type IEventBus interface {
+	Publish(topic string, payload interface{})
+	Subscribe(ctx context.Context, topic string) <-chan interface{}
+}
@@ -6,15 +6,27 @@ import (
 )
 
 type ILikeRepository interface {
-	GetLikesByPostID(ctx context.Context, postID string) ([]domain.Like, error)
-	GetLikesByUserID(ctx context.Context, userID string) ([]domain.Like, error)
+	GetLikesByPostID(ctx context.Context, postID string, page domain.PageRequest) ([]domain.Like, domain.PageInfo, error)
+	GetLikesByUserID(ctx context.Context, userID string, page domain.PageRequest) ([]domain.Like, domain.PageInfo, error)
 	AddLike(ctx context.Context, like domain.Like) (*domain.Like, error)
 	RemoveLike(ctx context.Context, userID, postID string) error
+	GetLikesByCommentID(ctx context.Context, commentID string, page domain.PageRequest) ([]domain.CommentLike, domain.PageInfo, error)
+	AddCommentLike(ctx context.Context, like domain.CommentLike) (*domain.CommentLike, error)
+	RemoveCommentLike(ctx context.Context, userID, commentID string) error
+	GetLikesByPostIDs(ctx context.Context, postIDs []string, page domain.PageRequest) (map[string]domain.Page[domain.Like], error)
 }
 
+// ILikeService likes posts and comments. The comment methods take the post
+// the comment was reached through; a comment on another post is not found.
+// An empty postID, for callers that address comments by ID alone, skips the
+// check.
 type ILikeService interface {
-	GetLikesByPostID(postID string) ([]domain.Like, error)
-	GetLikesByUserID(userID string) ([]domain.Like, error)
+	GetLikesByPostID(postID string, page domain.PageRequest) ([]domain.Like, domain.PageInfo, error)
+	GetLikesByUserID(userID string, page domain.PageRequest) ([]domain.Like, domain.PageInfo, error)
 	AddLike(userID, postID string) (domain.Like, error)
 	RemoveLike(userID, postID string) error
-}
\ No newline at end of file
+	GetLikesByCommentID(postID, commentID string, page domain.PageRequest) ([]domain.CommentLike, domain.PageInfo, error)
+	AddCommentLike(userID, postID, commentID string) (domain.CommentLike, error)
+	RemoveCommentLike(userID, postID, commentID string) error
+	GetLikesByPostIDs(postIDs []string, page domain.PageRequest) (map[string]domain.Page[domain.Like], error)
+}
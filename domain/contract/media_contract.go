@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	domain "raion-assessment/domain/entity"
+	"time"
+)
+
+// IMediaStore is the pluggable backend uploaded media is kept in. Keys are
+// slash-separated paths such as "posts/photo.jpg". Stored media is private:
+// SignedURL returns an address a key can be fetched from for a limited time,
+// and KeyOf recovers the key from such an address, expired or not, or from
+// the store's older unsigned ones. PresignPut returns a URL a client can PUT
+// exactly size bytes of contentType to under key, without credentials, until
+// expiry has passed.
+type IMediaStore interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+	SignedURL(key string) string
+	KeyOf(rawURL string) (string, bool)
+	PresignPut(key, contentType string, size int64, expiry time.Duration) (string, error)
+}
+
+// IServedMediaStore is implemented by media stores whose signed and presigned
+// URLs point back at the API, such as the local disk, rather than at a
+// storage service that checks them itself. OpenSigned opens the file behind a
+// signed URL once its expiry and signature check out.
+type IServedMediaStore interface {
+	OpenSigned(ctx context.Context, key, expires, signature string) (io.ReadSeekCloser, fs.FileInfo, error)
+	ReceivePresignedPut(ctx context.Context, key, contentType string, size int64, expires, signature string, body io.Reader) error
+}
+
+// IVideoTranscoder converts an uploaded video or animated GIF into a
+// web-friendly MP4 and grabs a poster frame from the result. Both work on
+// local files.
+type IVideoTranscoder interface {
+	Transcode(ctx context.Context, srcPath, dstPath string) error
+	Poster(ctx context.Context, videoPath string) ([]byte, error)
+}
+
+type IMediaRepository interface {
+	CreateMedia(ctx context.Context, media domain.Media, quota int64) (*domain.Media, error)
+	ClaimPendingMedia(ctx context.Context) (*domain.Media, error)
+	CompleteMedia(ctx context.Context, media domain.Media, quota int64) error
+	ReleaseMedia(ctx context.Context, mediaID, message string, failed bool) error
+	FetchPostMedia(ctx context.Context, postIDs []string) (map[string][]domain.PostMedia, error)
+	FetchMedia(ctx context.Context, ids []string) (map[string]domain.Media, error)
+	FetchUserAvatars(ctx context.Context, userIDs []string) (map[string]domain.Media, error)
+	DeleteUnreferencedMedia(ctx context.Context, unusedFor time.Duration, limit int) ([]domain.Media, error)
+	DeleteOwnerMedia(ctx context.Context, ownerID string) ([]domain.Media, error)
+	FetchStorageUsage(ctx context.Context, ownerID string) (domain.StorageUsage, error)
+}
+
+// IMediaService checks uploads and ingests them into stored renditions, and
+// looks up the media attached to posts and users, with signed rendition URLs
+// filled in. Uploads are files, finished resumable uploads or completed
+// direct uploads; media uploaded ahead of time is attached by ID. ctx carries
+// the caller's locale, which field errors are reported in. Videos are
+// ingested as pending and finished later by ProcessPendingMedia. SignURL
+// re-signs a media URL recorded elsewhere, such as a user's image_url, and
+// leaves URLs outside the media store as they are. Ingesting fails once it
+// would take the owner past their storage quota, and media no post or profile
+// uses any more is deleted by SweepUnreferencedMedia.
+type IMediaService interface {
+	UploadPostMedia(ctx context.Context, ownerID string, files []io.ReadSeeker, uploadIDs, mediaIDs, altTexts []string) ([]domain.PostMedia, error)
+	UploadProfileImage(ctx context.Context, ownerID string, file io.Reader, uploadID, mediaID string) (*domain.Media, error)
+	CheckDirectUpload(ctx context.Context, purpose, contentType string, size int64) (string, error)
+	CompleteDirectUpload(ctx context.Context, ownerID, id string) (domain.Media, error)
+	ProcessPendingMedia() (bool, error)
+	FetchPostMedia(postIDs []string) (map[string][]domain.PostMedia, error)
+	FetchMedia(ids []string) (map[string]domain.Media, error)
+	FetchUserAvatars(userIDs []string) (map[string]domain.Media, error)
+	FetchStorageUsage(ownerID string) (domain.StorageUsage, error)
+	SweepUnreferencedMedia(unusedFor time.Duration) (int, error)
+	DeleteOwnerMedia(ownerID string) error
+	SignURL(rawURL string) string
+}
@@ -6,21 +6,26 @@ import (
 )
 
 type IPostRepository interface {
-	FetchAllPosts(ctx context.Context) ([]domain.Post, error)
+	FetchAllPosts(ctx context.Context, page domain.PageRequest) ([]domain.Post, domain.PageInfo, error)
 	FetchPostByID(ctx context.Context, postID string) (*domain.Post, error)
-	FetchPostsByUserID(ctx context.Context, userID string) ([]domain.Post, error)
+	FetchPostsByUserID(ctx context.Context, userID string, page domain.PageRequest) ([]domain.Post, domain.PageInfo, error)
 	CreatePost(ctx context.Context, post domain.Post) (*domain.Post, error)
-	UpdatePost(ctx context.Context, postID string, post domain.Post) (*domain.Post, error)
+	EditPost(ctx context.Context, postID string, caption *string, media []domain.PostMedia, imageURL string) error
 	DeletePost(ctx context.Context, postID string) error
-	SearchPosts(ctx context.Context, query string) ([]domain.Post, error)
+	SearchPosts(ctx context.Context, query string, page domain.PageRequest) ([]domain.Post, domain.PageInfo, error)
+	FetchViewerStates(ctx context.Context, viewerID string, postIDs []string) (map[string]domain.PostViewerState, error)
+	FetchPostsByIDs(ctx context.Context, postIDs []string) ([]domain.Post, error)
+	FetchPostsByUserIDs(ctx context.Context, userIDs []string, page domain.PageRequest) (map[string]domain.Page[domain.Post], error)
 }
 
 type IPostService interface {
-	FetchAllPosts() ([]domain.Post, error)
-	FetchPostByID(id string) (domain.Post, error)
-	FetchPostsByUserID(userID string) ([]domain.Post, error)
+	FetchAllPosts(viewerID string, page domain.PageRequest) ([]domain.Post, domain.PageInfo, error)
+	FetchPostByID(id, viewerID string) (domain.Post, error)
+	FetchPostsByUserID(userID, viewerID string, page domain.PageRequest) ([]domain.Post, domain.PageInfo, error)
 	CreatePost(post domain.Post) (domain.Post, error)
-	UpdatePost(id string, post domain.Post) (domain.Post, error)
+	EditPost(id string, caption *string, media []domain.PostMedia) (domain.Post, error)
 	DeletePost(id string) error
-	SearchPosts(query string) ([]domain.Post, error)
+	SearchPosts(query, viewerID string, page domain.PageRequest) ([]domain.Post, domain.PageInfo, error)
+	FetchPostsByIDs(ids []string, viewerID string) ([]domain.Post, error)
+	FetchPostsByUserIDs(userIDs []string, viewerID string, page domain.PageRequest) (map[string]domain.Page[domain.Post], error)
 }
\ No newline at end of file
@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"context"
+	"io"
+	domain "raion-assessment/domain/entity"
+	"time"
+)
+
+type IUploadRepository interface {
+	CreateUpload(ctx context.Context, upload domain.Upload, ttl time.Duration, quota int64) (*domain.Upload, error)
+	FetchUpload(ctx context.Context, id, ownerID string) (*domain.Upload, error)
+	AppendChunk(ctx context.Context, id, ownerID string, offset, size int64, chunkKey string, ttl time.Duration) (*domain.Upload, error)
+	DeleteUpload(ctx context.Context, id, ownerID string) (*domain.Upload, error)
+	DeleteExpiredUploads(ctx context.Context, limit int) ([]domain.Upload, error)
+	CreateDirectUpload(ctx context.Context, upload domain.DirectUpload, ttl time.Duration, quota int64) (*domain.DirectUpload, error)
+	FetchDirectUpload(ctx context.Context, id, ownerID string) (*domain.DirectUpload, error)
+	DeleteDirectUpload(ctx context.Context, id, ownerID string) (*domain.DirectUpload, error)
+	DeleteExpiredDirectUploads(ctx context.Context, limit int) ([]domain.DirectUpload, error)
+}
+
+// IUploadService runs resumable and direct uploads. Resumable chunks must
+// arrive in order at the current offset; a finished upload is read back with
+// OpenUpload and deleted once it has been ingested. Direct uploads are sent
+// to a presigned URL and read back with OpenDirectUpload the same way.
+// Uploads count against the owner's storage quota from the moment they are
+// started, and those left unfinished or unused expire.
+type IUploadService interface {
+	CreateUpload(ownerID string, length int64, metadata string) (domain.Upload, error)
+	FetchUpload(ownerID, id string) (domain.Upload, error)
+	AppendChunk(ownerID, id string, offset, size int64, chunk io.Reader) (domain.Upload, error)
+	OpenUpload(ownerID, id string) (io.ReadCloser, error)
+	DeleteUpload(ownerID, id string) error
+	PresignUpload(ownerID, purpose, contentType string, size int64) (domain.DirectUpload, error)
+	OpenDirectUpload(ownerID, id string) (domain.DirectUpload, io.ReadCloser, error)
+	DeleteDirectUpload(ownerID, id string) error
+	ExpireUploads() (int, error)
+}
@@ -6,19 +6,21 @@ import (
 )
 
 type IUserRepository interface {
-	GetAllUsers(ctx context.Context) ([]domain.User, error)
+	GetAllUsers(ctx context.Context, page domain.PageRequest) ([]domain.User, domain.PageInfo, error)
 	GetUserByID(ctx context.Context, id string) (domain.User, error)
+	GetUsersByIDs(ctx context.Context, ids []string) ([]domain.User, error)
 	CreateUser(ctx context.Context, user domain.User) (domain.User, error)
 	UpdateUser(ctx context.Context, id string, user domain.User) (domain.User, error)
 	DeleteUser(ctx context.Context, id string) error
-	SearchUsers(ctx context.Context, query string) ([]domain.User, error)
+	SearchUsers(ctx context.Context, query string, page domain.PageRequest) ([]domain.User, domain.PageInfo, error)
 }
 
 type IUserService interface {
-	FetchAllUsers() ([]domain.User, error)
+	FetchAllUsers(page domain.PageRequest) ([]domain.User, domain.PageInfo, error)
 	FetchUserByID(id string) (domain.User, error)
+	FetchUsersByIDs(ids []string) ([]domain.User, error)
 	CreateUser(user domain.User) (domain.User, error)
 	UpdateUser(id string, user domain.User) (domain.User, error)
 	DeleteUser(id string) error
-	SearchUsers(query string) ([]domain.User, error)
+	SearchUsers(query string, page domain.PageRequest) ([]domain.User, domain.PageInfo, error)
 }
\ No newline at end of file
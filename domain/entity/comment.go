@@ -2,11 +2,18 @@ package domain
 
 import "time"
 
+const (
+    CommentSortNewest = "newest"
+    CommentSortTop    = "top"
+)
+
 type Comment struct {
     ID        string    `json:"id"`
     UserID    string    `json:"user_id"`
     PostID    string    `json:"post_id"`
     Content   string    `json:"content"`
+    LikeCount int       `json:"like_count"`
+    LikedByMe bool      `json:"liked_by_me"`
     CreatedAt time.Time `json:"created_at"`
     UpdatedAt time.Time `json:"updated_at"`
-}
\ No newline at end of file
+}
@@ -0,0 +1,10 @@
+package domain
+
+import "time"
+
+type CommentLike struct {
+    ID        string    `json:"id"`
+    UserID    string    `json:"user_id"`
+    CommentID string    `json:"comment_id"`
+    CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,90 @@
+package domain
+
+import (
+    "errors"
+    "time"
+)
+
+// Kinds of failure understood by every layer. Services and repositories
+// return an *Error carrying one of them, and the REST and GraphQL layers map
+// the kind to a status code. Test for a kind with errors.Is.
+var (
+    ErrNotFound        = errors.New("not found")
+    ErrConflict        = errors.New("conflict")
+    ErrForbidden       = errors.New("forbidden")
+    ErrUnauthenticated = errors.New("unauthenticated")
+    ErrValidation      = errors.New("validation failed")
+    ErrRateLimited     = errors.New("rate limited")
+    ErrQuotaExceeded   = errors.New("quota exceeded")
+)
+
+// Error is a failure of a known kind. Message is safe to show to clients;
+// Err, when set, is the underlying cause and is only meant for logs.
+type Error struct {
+    Kind       error
+    Message    string
+    Fields     []FieldError
+    RetryAfter time.Duration
+    Err        error
+}
+
+// FieldError explains why one input field was rejected. Code names the rule
+// it broke, such as "required" or "uuid", and Message is meant for people.
+type FieldError struct {
+    Field   string `json:"field" example:"username"`
+    Code    string `json:"code" example:"min"`
+    Message string `json:"message" example:"username must be at least 3 characters long"`
+}
+
+func (e *Error) Error() string {
+    if e.Err != nil {
+        return e.Message + ": " + e.Err.Error()
+    }
+    return e.Message
+}
+
+func (e *Error) Unwrap() []error {
+    if e.Err != nil {
+        return []error{e.Kind, e.Err}
+    }
+    return []error{e.Kind}
+}
+
+func NotFound(message string) error {
+    return &Error{Kind: ErrNotFound, Message: message}
+}
+
+func Conflict(message string) error {
+    return &Error{Kind: ErrConflict, Message: message}
+}
+
+func Forbidden(message string) error {
+    return &Error{Kind: ErrForbidden, Message: message}
+}
+
+func Unauthenticated(message string) error {
+    return &Error{Kind: ErrUnauthenticated, Message: message}
+}
+
+// Validation reports invalid input, listing each offending field.
+func Validation(message string, fields ...FieldError) error {
+    return &Error{Kind: ErrValidation, Message: message, Fields: fields}
+}
+
+// RateLimited reports that the caller should retry after the given delay.
+func RateLimited(message string, retryAfter time.Duration) error {
+    return &Error{Kind: ErrRateLimited, Message: message, RetryAfter: retryAfter}
+}
+
+// QuotaExceeded reports that storing something would take the caller past
+// their storage quota.
+func QuotaExceeded(message string) error {
+    return &Error{Kind: ErrQuotaExceeded, Message: message}
+}
+
+// AsError returns the *Error in err's chain, if any.
+func AsError(err error) (*Error, bool) {
+    var domainErr *Error
+    ok := errors.As(err, &domainErr)
+    return domainErr, ok
+}
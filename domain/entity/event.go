@@ -0,0 +1,20 @@
+package domain
+
+// Topics published on the internal event bus. Payloads are the entity named in
+// each comment, passed by value.
+const (
+    EventPostCreated      = "post.created"     // Post
+    EventCommentAdded     = "comment.added"    // Comment
+    EventPostLiked        = "post.liked"       // Like
+    EventPostUnliked      = "post.unliked"     // Like
+    EventCommentLiked     = "comment.liked"    // CommentLike
+    EventCommentUnliked   = "comment.unliked"  // CommentLike
+    EventLikeCountChanged = "post.like_count"  // LikeCountChange
+    EventNotification     = "notification"     // Notification
+)
+
+// LikeCountChange carries a post's like counter after a like or unlike.
+type LikeCountChange struct {
+    PostID    string `json:"post_id"`
+    LikeCount int    `json:"like_count"`
+}
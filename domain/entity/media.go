@@ -0,0 +1,150 @@
+package domain
+
+import "time"
+
+// Media purposes. Each has its own set of renditions and is also the key
+// prefix its files are stored under.
+const (
+    MediaPurposePost   = "posts"
+    MediaPurposeAvatar = "profile"
+)
+
+// Media kinds. Videos include animated GIFs, which are transcoded to video.
+const (
+    MediaKindImage = "image"
+    MediaKindVideo = "video"
+)
+
+// Upload limits shared by the upload endpoints and the media worker.
+const (
+    // MaxVideoUploadSize is the largest file accepted for upload, which is
+    // the limit for videos; images are held to smaller per-type limits.
+    MaxVideoUploadSize = 32 << 20
+
+    // MaxVideoDuration bounds how long a video or animated GIF may play.
+    // Uploads that declare a longer duration are rejected; the transcoder
+    // also cuts its output at this length for files that declare none.
+    MaxVideoDuration = 60 * time.Second
+
+    // MaxVideoSide is the longest side transcoded videos are scaled down to.
+    MaxVideoSide = 1280
+)
+
+// Media statuses. Images are ready as soon as they are ingested; videos stay
+// pending until the media worker has transcoded them.
+const (
+    MediaStatusPending = "pending"
+    MediaStatusReady   = "ready"
+    MediaStatusFailed  = "failed"
+)
+
+// Rendition names. The still renditions run from smallest to largest and are
+// the poster frame of a video; RenditionVideo is its transcoded MP4.
+const (
+    RenditionThumbnail = "thumbnail"
+    RenditionFeed      = "feed"
+    RenditionFull      = "full"
+    RenditionVideo     = "video"
+)
+
+// Media is an ingested upload. The original is never kept: only its
+// renditions, re-encoded without metadata, are stored. Width, Height,
+// BlurHash and DominantColor describe the upright original and let clients
+// reserve space and paint a placeholder before any rendition loads.
+//
+// Videos keep their upload under SourceKey until they are processed, and
+// only gain dimensions, placeholders and renditions once they are ready.
+//
+// SizeBytes is everything the item keeps in the media store, which counts
+// against its owner's storage quota.
+type Media struct {
+    ID            string      `json:"id"`
+    OwnerID       string      `json:"owner_id"`
+    Purpose       string      `json:"purpose"`
+    Kind          string      `json:"kind"`
+    Status        string      `json:"status"`
+    ContentType   string      `json:"content_type"`
+    Width         int         `json:"width"`
+    Height        int         `json:"height"`
+    DurationMS    int         `json:"duration_ms"`
+    BlurHash      string      `json:"blurhash"`
+    DominantColor string      `json:"dominant_color"`
+    Renditions    []Rendition `json:"renditions"`
+    SizeBytes     int64       `json:"size_bytes"`
+    SourceKey     string      `json:"-"`
+    Attempts      int         `json:"-"`
+    CreatedAt     time.Time   `json:"created_at"`
+}
+
+// Rendition is one stored, resized copy of a media item. URL is filled in from
+// the media store when the item is read.
+type Rendition struct {
+    Name        string `json:"name"`
+    Key         string `json:"key"`
+    URL         string `json:"url,omitempty"`
+    ContentType string `json:"content_type"`
+    Width       int    `json:"width"`
+    Height      int    `json:"height"`
+    Size        int64  `json:"size"`
+}
+
+// ImageUpload is an uploaded image that passed validation, held in memory.
+type ImageUpload struct {
+    Data        []byte
+    ContentType string
+}
+
+// VideoUpload is an uploaded video or animated GIF that passed validation.
+// Duration is what the file declares, or zero if it declares nothing.
+type VideoUpload struct {
+    Data        []byte
+    ContentType string
+    Duration    time.Duration
+}
+
+// Rendition returns the rendition with the given name.
+func (m Media) Rendition(name string) (Rendition, bool) {
+    for _, rendition := range m.Renditions {
+        if rendition.Name == name {
+            return rendition, true
+        }
+    }
+    return Rendition{}, false
+}
+
+// Keys lists every object the item keeps in the media store.
+func (m Media) Keys() []string {
+    keys := make([]string, 0, len(m.Renditions)+1)
+    for _, rendition := range m.Renditions {
+        keys = append(keys, rendition.Key)
+    }
+    if m.SourceKey != "" {
+        keys = append(keys, m.SourceKey)
+    }
+    return keys
+}
+
+// URL returns the URL of the named rendition, or "" if there is none.
+func (m Media) URL(name string) string {
+    rendition, _ := m.Rendition(name)
+    return rendition.URL
+}
+
+// StorageUsage is how much of their storage quota a user has taken up, in
+// bytes. Used is the media they own; Reserved is the declared size of uploads
+// they have started but not yet turned into media.
+type StorageUsage struct {
+    Used     int64 `json:"used"`
+    Reserved int64 `json:"reserved"`
+    Quota    int64 `json:"quota"`
+}
+
+// Remaining is how many bytes are still free, never less than zero.
+func (u StorageUsage) Remaining() int64 {
+    return max(u.Quota-u.Used-u.Reserved, 0)
+}
+
+// Fits says whether size more bytes fit in what is left of the quota.
+func (u StorageUsage) Fits(size int64) bool {
+    return u.Used+u.Reserved+size <= u.Quota
+}
@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+const (
+    NotificationPostLiked     = "post_liked"
+    NotificationPostCommented = "post_commented"
+    NotificationCommentLiked  = "comment_liked"
+)
+
+// Notification tells a user that someone interacted with their content. It is
+// delivered live over the event bus and is not persisted.
+type Notification struct {
+    Type        string    `json:"type"`
+    RecipientID string    `json:"recipient_id"`
+    ActorID     string    `json:"actor_id"`
+    PostID      string    `json:"post_id"`
+    CommentID   string    `json:"comment_id,omitempty"`
+    CreatedAt   time.Time `json:"created_at"`
+}
@@ -0,0 +1,45 @@
+package domain
+
+import "time"
+
+const (
+    DefaultPageSize = 20
+    MaxPageSize     = 100
+)
+
+// Cursor identifies the last row of a page in a (score, created_at, id)
+// ordering. Score is only used by orderings that rank by a counter.
+type Cursor struct {
+    Score     int       `json:"s,omitempty"`
+    CreatedAt time.Time `json:"t"`
+    ID        string    `json:"id"`
+}
+
+type PageRequest struct {
+    Limit int
+    After *Cursor
+}
+
+type PageInfo struct {
+    HasMore bool
+    Next    *Cursor
+}
+
+// Normalized clamps the limit into the range [1, MaxPageSize], falling back
+// to DefaultPageSize when no limit was given.
+func (p PageRequest) Normalized() PageRequest {
+    if p.Limit <= 0 {
+        p.Limit = DefaultPageSize
+    }
+    if p.Limit > MaxPageSize {
+        p.Limit = MaxPageSize
+    }
+    return p
+}
+
+// Page is one page of a list together with its pagination state, used when
+// first pages for several parents are loaded in a single batch.
+type Page[T any] struct {
+    Items    []T
+    PageInfo PageInfo
+}
@@ -2,11 +2,54 @@ package domain
 
 import "time"
 
+// MaxPostMedia is the most images a single post can carry.
+const MaxPostMedia = 10
+
+// Post is a post with its images in display order. ImageURL mirrors the
+// first image for clients that predate multi-image posts.
 type Post struct {
-    ID        string    `json:"id"`
-    UserID    string    `json:"user_id"`
-    Caption   string    `json:"caption,omitempty"`
-    ImageURL  string    `json:"image_url,omitempty"`
-    CreatedAt time.Time `json:"created_at"`
-    UpdatedAt time.Time `json:"updated_at"`
-}
\ No newline at end of file
+    ID           string      `json:"id"`
+    UserID       string      `json:"user_id"`
+    Caption      string      `json:"caption,omitempty"`
+    ImageURL     string      `json:"image_url,omitempty"`
+    Media        []PostMedia `json:"media"`
+    Status       string      `json:"status"`
+    LikeCount    int         `json:"like_count"`
+    CommentCount int         `json:"comment_count"`
+    LikedByMe    bool        `json:"liked_by_me"`
+    Author       *PostAuthor `json:"author,omitempty"`
+    CreatedAt    time.Time   `json:"created_at"`
+    UpdatedAt    time.Time   `json:"updated_at"`
+}
+
+type PostAuthor struct {
+    ID       string `json:"id"`
+    Name     string `json:"name"`
+    ImageURL string `json:"image_url"`
+}
+
+// PostMedia is one image of a post. Position orders the images from 0.
+type PostMedia struct {
+    Position int    `json:"position"`
+    AltText  string `json:"alt_text"`
+    Media    Media  `json:"media"`
+}
+
+// MediaStatus sums up the processing status of a post's media: failed if any
+// item failed, pending while any is still being processed, ready otherwise.
+func (p Post) MediaStatus() string {
+    status := MediaStatusReady
+    for _, item := range p.Media {
+        switch item.Media.Status {
+        case MediaStatusFailed:
+            return MediaStatusFailed
+        case MediaStatusPending:
+            status = MediaStatusPending
+        }
+    }
+    return status
+}
+
+type PostViewerState struct {
+    LikedByMe bool
+}
@@ -0,0 +1,42 @@
+package domain
+
+import "time"
+
+// Upload is a resumable upload sent in chunks over the tus protocol. Each
+// chunk is kept in the media store under ChunkKeys, in order, until the
+// finished upload is attached to a post or profile, or it expires.
+type Upload struct {
+    ID        string    `json:"id"`
+    OwnerID   string    `json:"owner_id"`
+    Length    int64     `json:"length"`
+    Offset    int64     `json:"offset"`
+    Metadata  string    `json:"metadata"`
+    ChunkKeys []string  `json:"-"`
+    ExpiresAt time.Time `json:"expires_at"`
+    CreatedAt time.Time `json:"created_at"`
+}
+
+// Complete reports whether every byte of the upload has been received.
+func (u Upload) Complete() bool {
+    return u.Offset == u.Length
+}
+
+// DirectUpload is a file a client sends straight to the media store through a
+// presigned URL instead of through the API. The client sends the file to URL
+// with Method and Headers before URLExpiresAt, then completes the upload
+// before ExpiresAt, which verifies and ingests the stored object. Key is where
+// the object is stored until then.
+type DirectUpload struct {
+    ID           string            `json:"id"`
+    OwnerID      string            `json:"owner_id"`
+    Purpose      string            `json:"purpose"`
+    Key          string            `json:"-"`
+    ContentType  string            `json:"content_type"`
+    Size         int64             `json:"size"`
+    Method       string            `json:"method"`
+    URL          string            `json:"url"`
+    Headers      map[string]string `json:"headers"`
+    URLExpiresAt time.Time         `json:"url_expires_at"`
+    ExpiresAt    time.Time         `json:"expires_at"`
+    CreatedAt    time.Time         `json:"created_at"`
+}
@@ -8,7 +8,10 @@ type User struct {
     Email        string    `json:"email"`
     PasswordHash string    `json:"-"`              
     ImageURL     string    `json:"image_url"` 
+    AvatarID     string    `json:"-"`
+    Avatar       *Media    `json:"avatar,omitempty"`
     Bio          string    `json:"bio"`   
+    PostCount    int       `json:"post_count"`
     CreatedAt    time.Time `json:"created"`
     UpdatedAt    time.Time `json:"updated"`
 }
\ No newline at end of file
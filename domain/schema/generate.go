@@ -0,0 +1,5 @@
+// Package schema holds the GraphQL schema (graph/*.graphqls) and the gqlgen
+// configuration the executable schema and resolver stubs are generated from.
+package schema
+
+//go:generate go run github.com/99designs/gqlgen generate
@@ -0,0 +1,444 @@
+// Code generated by github.com/99designs/gqlgen, DO NOT EDIT.
+
+package model
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// An object with a globally unique ID, resolvable through the node query.
+type Node interface {
+	IsNode()
+	GetID() string
+}
+
+type AuthPayload struct {
+	AccessToken  string  `json:"accessToken"`
+	RefreshToken *string `json:"refreshToken,omitempty"`
+}
+
+type Comment struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"userId"`
+	PostID    string    `json:"postId"`
+	Content   string    `json:"content"`
+	LikeCount int       `json:"likeCount"`
+	LikedByMe bool      `json:"likedByMe"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type CommentConnection struct {
+	Edges    []*CommentEdge `json:"edges"`
+	PageInfo *PageInfo      `json:"pageInfo"`
+	// Total number of comments on the post; null when the list is not scoped to a post.
+	Count *int `json:"count,omitempty"`
+}
+
+type CommentEdge struct {
+	Node   *Comment `json:"node"`
+	Cursor string   `json:"cursor"`
+}
+
+type CommentLike struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"userId"`
+	CommentID string    `json:"commentId"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type CommentLikeConnection struct {
+	Edges    []*CommentLikeEdge `json:"edges"`
+	PageInfo *PageInfo          `json:"pageInfo"`
+}
+
+type CommentLikeEdge struct {
+	Node   *CommentLike `json:"node"`
+	Cursor string       `json:"cursor"`
+}
+
+// Where and how to send a file straight to storage: one request with method to url, carrying every header in headers, before urlExpiresAt. Complete the upload with completeDirectUpload before expiresAt.
+type DirectUpload struct {
+	ID           string        `json:"id"`
+	Method       string        `json:"method"`
+	URL          string        `json:"url"`
+	Headers      []*HTTPHeader `json:"headers"`
+	URLExpiresAt time.Time     `json:"urlExpiresAt"`
+	ExpiresAt    time.Time     `json:"expiresAt"`
+}
+
+type HTTPHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type Like struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"userId"`
+	PostID    string    `json:"postId"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type LikeConnection struct {
+	Edges    []*LikeEdge `json:"edges"`
+	PageInfo *PageInfo   `json:"pageInfo"`
+	// Total number of likes on the post; null when the list is not scoped to a post.
+	Count *int `json:"count,omitempty"`
+}
+
+type LikeCountChange struct {
+	PostID    string `json:"postId"`
+	LikeCount int    `json:"likeCount"`
+}
+
+type LikeEdge struct {
+	Node   *Like  `json:"node"`
+	Cursor string `json:"cursor"`
+}
+
+// An uploaded image or video, stored as processed renditions without its original metadata. A video's first rendition is the playable MP4, followed by stills of its poster frame.
+type Media struct {
+	DatabaseID string       `json:"databaseId"`
+	Purpose    MediaPurpose `json:"purpose"`
+	Kind       MediaKind    `json:"kind"`
+	Status     MediaStatus  `json:"status"`
+	// Dimensions, placeholders and renditions are empty until a video is READY.
+	Width  int `json:"width"`
+	Height int `json:"height"`
+	// Length of a video in milliseconds, 0 for images and videos that do not declare one.
+	DurationMs int `json:"durationMs"`
+	// BlurHash placeholder, empty for media ingested before placeholders existed.
+	Blurhash string `json:"blurhash"`
+	// Most common colour as #rrggbb.
+	DominantColor string       `json:"dominantColor"`
+	Renditions    []*Rendition `json:"renditions"`
+}
+
+type Mutation struct {
+}
+
+type Notification struct {
+	Type      NotificationType `json:"type"`
+	ActorID   string           `json:"actorId"`
+	PostID    string           `json:"postId"`
+	CommentID *string          `json:"commentId,omitempty"`
+	CreatedAt time.Time        `json:"createdAt"`
+}
+
+type PageInfo struct {
+	HasNextPage     bool    `json:"hasNextPage"`
+	HasPreviousPage bool    `json:"hasPreviousPage"`
+	StartCursor     *string `json:"startCursor,omitempty"`
+	EndCursor       *string `json:"endCursor,omitempty"`
+}
+
+type Post struct {
+	ID         string `json:"id"`
+	DatabaseID string `json:"databaseId"`
+	UserID     string `json:"userId"`
+	Caption    string `json:"caption"`
+	// The first image, for clients that predate multi-image posts.
+	ImageURL string       `json:"imageURL"`
+	Media    []*PostMedia `json:"media"`
+	// PENDING while a video is still being processed, FAILED if one could not be.
+	Status       MediaStatus `json:"status"`
+	LikeCount    int         `json:"likeCount"`
+	CommentCount int         `json:"commentCount"`
+	LikedByMe    bool        `json:"likedByMe"`
+	// Reserved for bookmarks, which are not supported yet: always false.
+	BookmarkedByMe bool      `json:"bookmarkedByMe"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+func (Post) IsNode()            {}
+func (this Post) GetID() string { return this.ID }
+
+type PostConnection struct {
+	Edges    []*PostEdge `json:"edges"`
+	PageInfo *PageInfo   `json:"pageInfo"`
+}
+
+type PostEdge struct {
+	Node   *Post  `json:"node"`
+	Cursor string `json:"cursor"`
+}
+
+type PostMedia struct {
+	Position int    `json:"position"`
+	AltText  string `json:"altText"`
+	Media    *Media `json:"media"`
+}
+
+// Places one of a post's current images when they are rearranged.
+type PostMediaInput struct {
+	MediaID string  `json:"mediaId"`
+	AltText *string `json:"altText,omitempty"`
+}
+
+type Query struct {
+}
+
+type RegisterPayload struct {
+	Message string `json:"message"`
+}
+
+type Rendition struct {
+	Name string `json:"name"`
+	// Signed URL that stops working after a while; fetch the media again for a fresh one.
+	URL         string `json:"url"`
+	ContentType string `json:"contentType"`
+	Width       int    `json:"width"`
+	Height      int    `json:"height"`
+}
+
+// How much of their storage quota a user has taken up, in bytes. Uploads fail with QUOTA_EXCEEDED once they would not fit in what remains.
+type StorageUsage struct {
+	// Size of the media the user owns. Media no post or profile uses any more counts until it is cleaned up.
+	Used int `json:"used"`
+	// Declared size of uploads started but not yet turned into media.
+	Reserved  int `json:"reserved"`
+	Quota     int `json:"quota"`
+	Remaining int `json:"remaining"`
+}
+
+type Subscription struct {
+}
+
+type User struct {
+	ID         string    `json:"id"`
+	DatabaseID string    `json:"databaseId"`
+	Username   string    `json:"username"`
+	Email      string    `json:"email"`
+	Bio        string    `json:"bio"`
+	ImageURL   string    `json:"imageURL"`
+	Avatar     *Media    `json:"avatar,omitempty"`
+	PostCount  int       `json:"postCount"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+func (User) IsNode()            {}
+func (this User) GetID() string { return this.ID }
+
+type UserConnection struct {
+	Edges    []*UserEdge `json:"edges"`
+	PageInfo *PageInfo   `json:"pageInfo"`
+}
+
+type UserEdge struct {
+	Node   *User  `json:"node"`
+	Cursor string `json:"cursor"`
+}
+
+type CommentSort string
+
+const (
+	CommentSortNewest CommentSort = "NEWEST"
+	CommentSortTop    CommentSort = "TOP"
+)
+
+var AllCommentSort = []CommentSort{
+	CommentSortNewest,
+	CommentSortTop,
+}
+
+func (e CommentSort) IsValid() bool {
+	switch e {
+	case CommentSortNewest, CommentSortTop:
+		return true
+	}
+	return false
+}
+
+func (e CommentSort) String() string {
+	return string(e)
+}
+
+func (e *CommentSort) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = CommentSort(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid CommentSort", str)
+	}
+	return nil
+}
+
+func (e CommentSort) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type MediaKind string
+
+const (
+	MediaKindImage MediaKind = "IMAGE"
+	MediaKindVideo MediaKind = "VIDEO"
+)
+
+var AllMediaKind = []MediaKind{
+	MediaKindImage,
+	MediaKindVideo,
+}
+
+func (e MediaKind) IsValid() bool {
+	switch e {
+	case MediaKindImage, MediaKindVideo:
+		return true
+	}
+	return false
+}
+
+func (e MediaKind) String() string {
+	return string(e)
+}
+
+func (e *MediaKind) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = MediaKind(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid MediaKind", str)
+	}
+	return nil
+}
+
+func (e MediaKind) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type MediaPurpose string
+
+const (
+	MediaPurposePosts   MediaPurpose = "POSTS"
+	MediaPurposeProfile MediaPurpose = "PROFILE"
+)
+
+var AllMediaPurpose = []MediaPurpose{
+	MediaPurposePosts,
+	MediaPurposeProfile,
+}
+
+func (e MediaPurpose) IsValid() bool {
+	switch e {
+	case MediaPurposePosts, MediaPurposeProfile:
+		return true
+	}
+	return false
+}
+
+func (e MediaPurpose) String() string {
+	return string(e)
+}
+
+func (e *MediaPurpose) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = MediaPurpose(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid MediaPurpose", str)
+	}
+	return nil
+}
+
+func (e MediaPurpose) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type MediaStatus string
+
+const (
+	MediaStatusPending MediaStatus = "PENDING"
+	MediaStatusReady   MediaStatus = "READY"
+	MediaStatusFailed  MediaStatus = "FAILED"
+)
+
+var AllMediaStatus = []MediaStatus{
+	MediaStatusPending,
+	MediaStatusReady,
+	MediaStatusFailed,
+}
+
+func (e MediaStatus) IsValid() bool {
+	switch e {
+	case MediaStatusPending, MediaStatusReady, MediaStatusFailed:
+		return true
+	}
+	return false
+}
+
+func (e MediaStatus) String() string {
+	return string(e)
+}
+
+func (e *MediaStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = MediaStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid MediaStatus", str)
+	}
+	return nil
+}
+
+func (e MediaStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type NotificationType string
+
+const (
+	NotificationTypePostLiked     NotificationType = "POST_LIKED"
+	NotificationTypePostCommented NotificationType = "POST_COMMENTED"
+	NotificationTypeCommentLiked  NotificationType = "COMMENT_LIKED"
+)
+
+var AllNotificationType = []NotificationType{
+	NotificationTypePostLiked,
+	NotificationTypePostCommented,
+	NotificationTypeCommentLiked,
+}
+
+func (e NotificationType) IsValid() bool {
+	switch e {
+	case NotificationTypePostLiked, NotificationTypePostCommented, NotificationTypeCommentLiked:
+		return true
+	}
+	return false
+}
+
+func (e NotificationType) String() string {
+	return string(e)
+}
+
+func (e *NotificationType) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = NotificationType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid NotificationType", str)
+	}
+	return nil
+}
+
+func (e NotificationType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
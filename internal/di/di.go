@@ -1,49 +1,83 @@
 package di
 
 import (
+	contract "raion-assessment/domain/contract"
 	graph "raion-assessment/internal/handler/graphql"
+	"raion-assessment/internal/event"
 	rest "raion-assessment/internal/handler/rest"
+	"raion-assessment/internal/job"
 	"raion-assessment/internal/repository"
 	"raion-assessment/internal/service"
+	"time"
 
 	"github.com/jackc/pgx/v4/pgxpool"
 )
 
 type Container struct {
-	UserHandler    *rest.UserHandler
-	AuthHandler    *rest.AuthHandler
-	PostHandler    *rest.PostHandler
-	CommentHandler *rest.CommentHandler
-	LikeHandler    *rest.LikeHandler
-	UserResolver   *graph.UserResolver
-	PostResolver   *graph.PostResolver
+	UserHandler       *rest.UserHandler
+	AuthHandler       *rest.AuthHandler
+	PostHandler       *rest.PostHandler
+	CommentHandler    *rest.CommentHandler
+	LikeHandler       *rest.LikeHandler
+	UploadHandler     *rest.UploadHandler
+	MediaHandler      *rest.MediaHandler
+	StorageHandler    *rest.StorageHandler
+	GraphResolver     *graph.Resolver
+	CounterReconciler *job.CounterReconciler
+	MediaProcessor    *job.MediaProcessor
+	UploadExpirer     *job.UploadExpirer
+	MediaSweeper      *job.MediaSweeper
+	EventProjector    *event.Projector
 }
 
-func NewContainer(db *pgxpool.Pool, jwtSecret string, refreshSecret string) *Container {
+func NewContainer(db *pgxpool.Pool, mediaStore contract.IMediaStore, transcoder contract.IVideoTranscoder, jwtSecret string, refreshSecret string, counterReconcileInterval time.Duration, mediaWorkerInterval time.Duration, uploadExpiry time.Duration, uploadSweepInterval time.Duration, uploadQuota int64, mediaCleanupInterval time.Duration, mediaCleanupGracePeriod time.Duration) *Container {
 	// Repositories
 	userRepo 	:= repository.NewUserRepository(db)
 	authRepo 	:= repository.NewAuthRepository(db)
 	postRepo 	:= repository.NewPostRepository(db)
 	commentRepo := repository.NewCommentRepository(db) 
 	likeRepo 	:= repository.NewLikeRepository(db) 
+	counterRepo := repository.NewCounterRepository(db)
+	mediaRepo 	:= repository.NewMediaRepository(db)
+	uploadRepo 	:= repository.NewUploadRepository(db)
+
+	// Events
+	eventBus := event.NewBus()
 
 	// Services
-	userService 	:= service.NewUserService(userRepo)
+	uploadService 	:= service.NewUploadService(uploadRepo, mediaStore, uploadExpiry, uploadQuota)
+	mediaService 	:= service.NewMediaService(mediaRepo, uploadService, mediaStore, transcoder, uploadQuota)
+	userService 	:= service.NewUserService(userRepo, mediaService)
 	authService 	:= service.NewAuthService(userRepo, authRepo, jwtSecret, refreshSecret)
-	postService 	:= service.NewPostService(postRepo) 
-	commentService 	:= service.NewCommentService(commentRepo)
-	likeService 	:= service.NewLikeService(likeRepo)
+	postService 	:= service.NewPostService(postRepo, userRepo, mediaService, eventBus)
+	commentService 	:= service.NewCommentService(commentRepo, eventBus)
+	likeService 	:= service.NewLikeService(likeRepo, commentRepo, eventBus)
 
 	// Handlers
-	userHandler 	:= rest.NewUserHandler(userService, authService)
+	userHandler 	:= rest.NewUserHandler(userService, authService, mediaService)
 	authHandler 	:= rest.NewAuthHandler(authService)
-	postHandler 	:= rest.NewPostHandler(postService, authService) 
+	postHandler 	:= rest.NewPostHandler(postService, authService, mediaService) 
 	commentHandler 	:= rest.NewCommentHandler(commentService, authService)
 	likeHandler 	:= rest.NewLikeHandler(likeService, authService)
+	uploadHandler 	:= rest.NewUploadHandler(uploadService, authService)
+	mediaHandler 	:= rest.NewMediaHandler(uploadService, mediaService, authService)
+
+	// Stores whose URLs point back at the API need a handler to serve media
+	// and receive uploads.
+	var storageHandler *rest.StorageHandler
+	if servedStore, ok := mediaStore.(contract.IServedMediaStore); ok {
+		storageHandler = rest.NewStorageHandler(servedStore)
+	}
 
 	// Resolvers
-	userResolver 	:= graph.NewUserResolver(userService, authService)
-	postResolver 	:= graph.NewPostResolver(postService, authService)
+	graphResolver 	:= graph.NewResolver(userService, postService, commentService, likeService, authService, eventBus, mediaService, uploadService)
+
+	// Jobs
+	counterReconciler := job.NewCounterReconciler(counterRepo, counterReconcileInterval)
+	mediaProcessor := job.NewMediaProcessor(mediaService, mediaWorkerInterval)
+	uploadExpirer := job.NewUploadExpirer(uploadService, uploadSweepInterval)
+	mediaSweeper := job.NewMediaSweeper(mediaService, mediaCleanupInterval, mediaCleanupGracePeriod)
+	eventProjector := event.NewProjector(eventBus, postRepo, commentRepo)
 
 	return &Container{
 		UserHandler: userHandler,
@@ -51,7 +85,14 @@ func NewContainer(db *pgxpool.Pool, jwtSecret string, refreshSecret string) *Con
 		PostHandler: postHandler, 
 		CommentHandler: commentHandler,
 		LikeHandler: likeHandler,
-		UserResolver: userResolver,
-		PostResolver: postResolver,
+		UploadHandler: uploadHandler,
+		MediaHandler: mediaHandler,
+		StorageHandler: storageHandler,
+		GraphResolver: graphResolver,
+		CounterReconciler: counterReconciler,
+		MediaProcessor: mediaProcessor,
+		UploadExpirer: uploadExpirer,
+		MediaSweeper: mediaSweeper,
+		EventProjector: eventProjector,
 	}
 }
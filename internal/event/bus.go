@@ -0,0 +1,61 @@
+package event
+
+import (
+	"context"
+	"log"
+	contract "raion-assessment/domain/contract"
+	"sync"
+)
+
+const subscriberBuffer = 32
+
+// Bus is an in-process publish/subscribe hub. Publishing never blocks: an
+// event is dropped for any subscriber whose buffer is full, so one slow
+// WebSocket client cannot stall the request that produced the event.
+type Bus struct {
+	mu          sync.RWMutex
+	subscribers map[string]map[chan interface{}]struct{}
+}
+
+func NewBus() contract.IEventBus {
+	return &Bus{subscribers: make(map[string]map[chan interface{}]struct{})}
+}
+
+func (b *Bus) Publish(topic string, payload interface{}) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for ch := range b.subscribers[topic] {
+		select {
+		case ch <- payload:
+		default:
+			log.Printf("Event bus dropped %s event for a slow subscriber", topic)
+		}
+	}
+}
+
+// Subscribe returns a channel receiving every event published on topic until
+// ctx is cancelled, at which point the channel is closed.
+func (b *Bus) Subscribe(ctx context.Context, topic string) <-chan interface{} {
+	ch := make(chan interface{}, subscriberBuffer)
+
+	b.mu.Lock()
+	if b.subscribers[topic] == nil {
+		b.subscribers[topic] = make(map[chan interface{}]struct{})
+	}
+	b.subscribers[topic][ch] = struct{}{}
+	b.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		b.mu.Lock()
+		delete(b.subscribers[topic], ch)
+		if len(b.subscribers[topic]) == 0 {
+			delete(b.subscribers, topic)
+		}
+		b.mu.Unlock()
+		close(ch)
+	}()
+
+	return ch
+}
@@ -0,0 +1,96 @@
+package event
+
+import (
+	"context"
+	"log"
+	contract "raion-assessment/domain/contract"
+	entity "raion-assessment/domain/entity"
+	"time"
+)
+
+// Projector derives the events clients subscribe to from the raw domain
+// events services publish: the new like count of a post after each like or
+// unlike, and a notification for the owner of any liked or commented content.
+type Projector struct {
+	bus         contract.IEventBus
+	postRepo    contract.IPostRepository
+	commentRepo contract.ICommentRepository
+}
+
+func NewProjector(bus contract.IEventBus, postRepo contract.IPostRepository, commentRepo contract.ICommentRepository) *Projector {
+	return &Projector{bus: bus, postRepo: postRepo, commentRepo: commentRepo}
+}
+
+// Start consumes domain events until the context is cancelled.
+func (p *Projector) Start(ctx context.Context) {
+	liked := p.bus.Subscribe(ctx, entity.EventPostLiked)
+	unliked := p.bus.Subscribe(ctx, entity.EventPostUnliked)
+	commented := p.bus.Subscribe(ctx, entity.EventCommentAdded)
+	commentLiked := p.bus.Subscribe(ctx, entity.EventCommentLiked)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case payload := <-liked:
+			if like, ok := payload.(entity.Like); ok {
+				p.publishLikeCount(ctx, like.PostID)
+				p.notifyPostOwner(ctx, entity.NotificationPostLiked, like.UserID, like.PostID, "")
+			}
+		case payload := <-unliked:
+			if like, ok := payload.(entity.Like); ok {
+				p.publishLikeCount(ctx, like.PostID)
+			}
+		case payload := <-commented:
+			if comment, ok := payload.(entity.Comment); ok {
+				p.notifyPostOwner(ctx, entity.NotificationPostCommented, comment.UserID, comment.PostID, comment.ID)
+			}
+		case payload := <-commentLiked:
+			if like, ok := payload.(entity.CommentLike); ok {
+				p.notifyCommentOwner(ctx, like)
+			}
+		}
+	}
+}
+
+func (p *Projector) publishLikeCount(ctx context.Context, postID string) {
+	post, err := p.postRepo.FetchPostByID(ctx, postID)
+	if err != nil || post == nil {
+		log.Printf("Projector could not load post %s: %v", postID, err)
+		return
+	}
+	p.bus.Publish(entity.EventLikeCountChanged, entity.LikeCountChange{PostID: post.ID, LikeCount: post.LikeCount})
+}
+
+func (p *Projector) notifyPostOwner(ctx context.Context, kind, actorID, postID, commentID string) {
+	post, err := p.postRepo.FetchPostByID(ctx, postID)
+	if err != nil || post == nil {
+		log.Printf("Projector could not load post %s: %v", postID, err)
+		return
+	}
+	p.notify(kind, post.UserID, actorID, postID, commentID)
+}
+
+func (p *Projector) notifyCommentOwner(ctx context.Context, like entity.CommentLike) {
+	comment, err := p.commentRepo.GetCommentByID(ctx, like.CommentID)
+	if err != nil || comment == nil {
+		log.Printf("Projector could not load comment %s: %v", like.CommentID, err)
+		return
+	}
+	p.notify(entity.NotificationCommentLiked, comment.UserID, like.UserID, comment.PostID, comment.ID)
+}
+
+// notify publishes a notification unless users are acting on their own content.
+func (p *Projector) notify(kind, recipientID, actorID, postID, commentID string) {
+	if recipientID == actorID {
+		return
+	}
+	p.bus.Publish(entity.EventNotification, entity.Notification{
+		Type:        kind,
+		RecipientID: recipientID,
+		ActorID:     actorID,
+		PostID:      postID,
+		CommentID:   commentID,
+		CreatedAt:   time.Now(),
+	})
+}
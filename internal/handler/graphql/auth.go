@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"context"
+
+	contract "raion-assessment/domain/contract"
+	entity "raion-assessment/domain/entity"
+	"raion-assessment/pkg/request"
+	"raion-assessment/pkg/util"
+)
+
+// currentUser resolves the bearer token carried in the request context to the
+// calling user, failing with UNAUTHENTICATED when it is missing or invalid.
+func currentUser(ctx context.Context, authService contract.IAuthService) (*entity.User, error) {
+	token, err := util.ExtractTokenFromContext(ctx)
+	if err != nil {
+		return nil, unauthenticated()
+	}
+	user, err := authService.GetCurrentUser(ctx, token)
+	if err != nil {
+		return nil, unauthenticated()
+	}
+	return user, nil
+}
+
+// validateInput runs the request struct validation the REST handlers apply,
+// so both APIs reject the same input with the same localized messages.
+func validateInput(ctx context.Context, input interface{}) error {
+	return request.Validate(request.LocaleFromContext(ctx), input)
+}
+
+// viewerID returns the ID of the calling user, or "" for anonymous requests.
+// The lookup is cached on the request's loaders.
+func (r *Resolver) viewerID(ctx context.Context) string {
+	return r.loadersFor(ctx).viewerID()
+}
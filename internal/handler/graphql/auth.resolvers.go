@@ -0,0 +1,97 @@
+package handler
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.64
+
+import (
+	"context"
+	"log"
+	"raion-assessment/domain/schema/graph"
+	"raion-assessment/domain/schema/graph/model"
+	"raion-assessment/pkg/request"
+)
+
+// Register is the resolver for the register field.
+func (r *mutationResolver) Register(ctx context.Context, username string, email string, password string) (*model.RegisterPayload, error) {
+	input := request.UserRegistrationRequest{Username: username, Email: email, Password: password}
+	if err := validateInput(ctx, input); err != nil {
+		return nil, err
+	}
+
+	if err := r.authService.Register(input.Username, input.Email, input.Password); err != nil {
+		log.Printf("Registration failed: %v", err)
+		return nil, err
+	}
+
+	return &model.RegisterPayload{Message: "User registered successfully"}, nil
+}
+
+// Login is the resolver for the login field.
+func (r *mutationResolver) Login(ctx context.Context, email string, password string) (*model.AuthPayload, error) {
+	input := request.UserLoginRequest{Email: email, Password: password}
+	if err := validateInput(ctx, input); err != nil {
+		return nil, err
+	}
+
+	accessToken, refreshToken, err := r.authService.Login(input.Email, input.Password)
+	if err != nil {
+		log.Printf("Login failed: %v", err)
+		return nil, err
+	}
+
+	return &model.AuthPayload{
+		AccessToken:  "Bearer " + accessToken,
+		RefreshToken: &refreshToken,
+	}, nil
+}
+
+// RefreshToken is the resolver for the refreshToken field.
+func (r *mutationResolver) RefreshToken(ctx context.Context, refreshToken string) (*model.AuthPayload, error) {
+	input := request.RefreshTokenRequest{RefreshToken: refreshToken}
+	if err := validateInput(ctx, input); err != nil {
+		return nil, err
+	}
+
+	accessToken, err := r.authService.RefreshToken(input.RefreshToken)
+	if err != nil {
+		log.Printf("Token refresh failed: %v", err)
+		return nil, err
+	}
+
+	return &model.AuthPayload{AccessToken: "Bearer " + accessToken}, nil
+}
+
+// ChangePassword is the resolver for the changePassword field.
+func (r *mutationResolver) ChangePassword(ctx context.Context, oldPassword string, newPassword string) (bool, error) {
+	user, err := currentUser(ctx, r.authService)
+	if err != nil {
+		return false, err
+	}
+
+	input := request.ChangePasswordRequest{OldPassword: oldPassword, NewPassword: newPassword}
+	if err := validateInput(ctx, input); err != nil {
+		return false, err
+	}
+
+	if err := r.authService.ChangePassword(user.ID, input.OldPassword, input.NewPassword); err != nil {
+		log.Printf("Password change failed: %v", err)
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Me is the resolver for the me field.
+func (r *queryResolver) Me(ctx context.Context) (*model.User, error) {
+	user, err := currentUser(ctx, r.authService)
+	if err != nil {
+		return nil, err
+	}
+	return mapToUser(*user), nil
+}
+
+// Mutation returns graph.MutationResolver implementation.
+func (r *Resolver) Mutation() graph.MutationResolver { return &mutationResolver{r} }
+
+type mutationResolver struct{ *Resolver }
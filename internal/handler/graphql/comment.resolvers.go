@@ -0,0 +1,108 @@
+package handler
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.64
+
+import (
+	"context"
+	"log"
+	domain "raion-assessment/domain/entity"
+	"raion-assessment/domain/schema/graph"
+	"raion-assessment/domain/schema/graph/model"
+	"raion-assessment/pkg/request"
+	"strings"
+)
+
+// Author is the resolver for the author field.
+func (r *commentResolver) Author(ctx context.Context, obj *model.Comment) (*model.User, error) {
+	user, err := r.loadersFor(ctx).users.Load(ctx, obj.UserID)
+	if err != nil {
+		return nil, fromLoader(err, "user not found")
+	}
+	return mapToUser(user), nil
+}
+
+// Post is the resolver for the post field.
+func (r *commentResolver) Post(ctx context.Context, obj *model.Comment) (*model.Post, error) {
+	post, err := r.loadersFor(ctx).posts.Load(ctx, obj.PostID)
+	if err != nil {
+		return nil, fromLoader(err, "post not found")
+	}
+	return mapToPost(post), nil
+}
+
+// CreateComment is the resolver for the createComment field.
+func (r *mutationResolver) CreateComment(ctx context.Context, postID string, content string) (*model.Comment, error) {
+	user, err := currentUser(ctx, r.authService)
+	if err != nil {
+		return nil, err
+	}
+
+	input := request.CreateCommentRequest{PostID: localID(postID, "Post"), Content: content}
+	if err := validateInput(ctx, input); err != nil {
+		return nil, err
+	}
+
+	comment, err := r.commentService.CreateComment(domain.Comment{
+		PostID:  input.PostID,
+		UserID:  user.ID,
+		Content: input.Content,
+	})
+	if err != nil {
+		log.Println("Error creating comment:", err)
+		return nil, err
+	}
+
+	return mapToComment(comment), nil
+}
+
+// DeleteComment is the resolver for the deleteComment field.
+func (r *mutationResolver) DeleteComment(ctx context.Context, id string) (bool, error) {
+	user, err := currentUser(ctx, r.authService)
+	if err != nil {
+		return false, err
+	}
+
+	comment, err := r.commentService.GetCommentByID(id)
+	if err != nil {
+		return false, err
+	}
+
+	if comment.UserID != user.ID {
+		return false, forbidden("not allowed to delete this comment")
+	}
+
+	if err := r.commentService.DeleteComment(id); err != nil {
+		log.Println("Error deleting comment:", err)
+		return false, err
+	}
+
+	return true, nil
+}
+
+// GetCommentsByPostID is the resolver for the getCommentsByPostID field.
+func (r *queryResolver) GetCommentsByPostID(ctx context.Context, postID string, sort *model.CommentSort, first *int, after *string) (*model.CommentConnection, error) {
+	order := domain.CommentSortNewest
+	if sort != nil {
+		order = strings.ToLower(sort.String())
+	}
+
+	page, err := pageRequestFromArgs(ctx, first, after)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, pageInfo, err := r.commentService.GetCommentsByPostID(localID(postID, "Post"), r.viewerID(ctx), order, page)
+	if err != nil {
+		log.Println("Error fetching comments:", err)
+		return nil, err
+	}
+
+	return newCommentConnection(comments, order, page, pageInfo), nil
+}
+
+// Comment returns graph.CommentResolver implementation.
+func (r *Resolver) Comment() graph.CommentResolver { return &commentResolver{r} }
+
+type commentResolver struct{ *Resolver }
@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"context"
+	entity "raion-assessment/domain/entity"
+	"raion-assessment/domain/schema/graph/model"
+	"raion-assessment/pkg/util"
+)
+
+// pageRequestFromArgs reads the Relay `first` and `after` arguments into the
+// same keyset page request used by the REST handlers.
+func pageRequestFromArgs(ctx context.Context, first *int, after *string) (entity.PageRequest, error) {
+	page := entity.PageRequest{Limit: entity.DefaultPageSize}
+
+	if first != nil {
+		if *first <= 0 {
+			return page, invalidArgument(ctx, "first", "number")
+		}
+		page.Limit = *first
+	}
+
+	if after != nil {
+		cursor, err := util.DecodeCursor(*after)
+		if err != nil {
+			return page, invalidArgument(ctx, "after", "cursor")
+		}
+		page.After = cursor
+	}
+
+	return page.Normalized(), nil
+}
+
+// edgeCursor encodes the keyset position of a single row as its edge cursor.
+func edgeCursor(cursor entity.Cursor) string {
+	return util.EncodeCursor(&cursor)
+}
+
+func newPageInfo(cursors []string, page entity.PageRequest, pageInfo entity.PageInfo) *model.PageInfo {
+	info := &model.PageInfo{
+		HasNextPage:     pageInfo.HasMore,
+		HasPreviousPage: page.After != nil,
+	}
+	if len(cursors) > 0 {
+		info.StartCursor = &cursors[0]
+		info.EndCursor = &cursors[len(cursors)-1]
+	}
+	return info
+}
+
+func newPostConnection(posts []entity.Post, page entity.PageRequest, pageInfo entity.PageInfo) *model.PostConnection {
+	edges := make([]*model.PostEdge, 0, len(posts))
+	cursors := make([]string, 0, len(posts))
+	for _, post := range posts {
+		cursor := edgeCursor(entity.Cursor{CreatedAt: post.CreatedAt, ID: post.ID})
+		edges = append(edges, &model.PostEdge{Node: mapToPost(post), Cursor: cursor})
+		cursors = append(cursors, cursor)
+	}
+	return &model.PostConnection{Edges: edges, PageInfo: newPageInfo(cursors, page, pageInfo)}
+}
+
+func newUserConnection(users []entity.User, page entity.PageRequest, pageInfo entity.PageInfo) *model.UserConnection {
+	edges := make([]*model.UserEdge, 0, len(users))
+	cursors := make([]string, 0, len(users))
+	for _, user := range users {
+		cursor := edgeCursor(entity.Cursor{CreatedAt: user.CreatedAt, ID: user.ID})
+		edges = append(edges, &model.UserEdge{Node: mapToUser(user), Cursor: cursor})
+		cursors = append(cursors, cursor)
+	}
+	return &model.UserConnection{Edges: edges, PageInfo: newPageInfo(cursors, page, pageInfo)}
+}
+
+func newCommentConnection(comments []entity.Comment, sort string, page entity.PageRequest, pageInfo entity.PageInfo) *model.CommentConnection {
+	edges := make([]*model.CommentEdge, 0, len(comments))
+	cursors := make([]string, 0, len(comments))
+	for _, comment := range comments {
+		position := entity.Cursor{CreatedAt: comment.CreatedAt, ID: comment.ID}
+		if sort == entity.CommentSortTop {
+			position.Score = comment.LikeCount
+		}
+		cursor := edgeCursor(position)
+		edges = append(edges, &model.CommentEdge{Node: mapToComment(comment), Cursor: cursor})
+		cursors = append(cursors, cursor)
+	}
+	return &model.CommentConnection{Edges: edges, PageInfo: newPageInfo(cursors, page, pageInfo)}
+}
+
+func newLikeConnection(likes []entity.Like, page entity.PageRequest, pageInfo entity.PageInfo) *model.LikeConnection {
+	edges := make([]*model.LikeEdge, 0, len(likes))
+	cursors := make([]string, 0, len(likes))
+	for _, like := range likes {
+		cursor := edgeCursor(entity.Cursor{CreatedAt: like.CreatedAt, ID: like.ID})
+		edges = append(edges, &model.LikeEdge{Node: mapToLike(like), Cursor: cursor})
+		cursors = append(cursors, cursor)
+	}
+	return &model.LikeConnection{Edges: edges, PageInfo: newPageInfo(cursors, page, pageInfo)}
+}
+
+func newCommentLikeConnection(likes []entity.CommentLike, page entity.PageRequest, pageInfo entity.PageInfo) *model.CommentLikeConnection {
+	edges := make([]*model.CommentLikeEdge, 0, len(likes))
+	cursors := make([]string, 0, len(likes))
+	for _, like := range likes {
+		cursor := edgeCursor(entity.Cursor{CreatedAt: like.CreatedAt, ID: like.ID})
+		edges = append(edges, &model.CommentLikeEdge{Node: mapToCommentLike(like), Cursor: cursor})
+		cursors = append(cursors, cursor)
+	}
+	return &model.CommentLikeConnection{Edges: edges, PageInfo: newPageInfo(cursors, page, pageInfo)}
+}
+
+// localID accepts either a Relay global ID of the expected type or a raw
+// database ID, so existing clients passing UUIDs keep working.
+func localID(id, typeName string) string {
+	gotType, rawID, err := util.FromGlobalID(id)
+	if err != nil || gotType != typeName {
+		return id
+	}
+	return rawID
+}
@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	loaderWait     = 2 * time.Millisecond
+	loaderMaxBatch = 100
+)
+
+// Loader collects the keys requested by concurrently running resolvers for a
+// short window and resolves them with a single fetch. Results are cached for
+// the lifetime of the loader, which is one GraphQL request.
+type Loader[K comparable, V any] struct {
+	fetch    func(ctx context.Context, keys []K) (map[K]V, error)
+	missing  func(key K) (V, error)
+	wait     time.Duration
+	maxBatch int
+
+	mu    sync.Mutex
+	cache map[K]*loaderResult[V]
+	batch *loaderBatch[K, V]
+}
+
+type loaderResult[V any] struct {
+	value V
+	err   error
+	done  chan struct{}
+}
+
+type loaderBatch[K comparable, V any] struct {
+	keys       []K
+	results    []*loaderResult[V]
+	dispatched bool
+}
+
+// NewLoader builds a loader around fetch, which must return the values it
+// found keyed by their key. Keys absent from the result are resolved with
+// missing.
+func NewLoader[K comparable, V any](fetch func(ctx context.Context, keys []K) (map[K]V, error), missing func(key K) (V, error)) *Loader[K, V] {
+	return &Loader[K, V]{
+		fetch:    fetch,
+		missing:  missing,
+		wait:     loaderWait,
+		maxBatch: loaderMaxBatch,
+		cache:    make(map[K]*loaderResult[V]),
+	}
+}
+
+// Load returns the value for key, joining the pending batch or starting a new
+// one when nothing is cached for it yet.
+func (l *Loader[K, V]) Load(ctx context.Context, key K) (V, error) {
+	l.mu.Lock()
+	result, ok := l.cache[key]
+	if !ok {
+		result = &loaderResult[V]{done: make(chan struct{})}
+		l.cache[key] = result
+
+		if l.batch == nil {
+			batch := &loaderBatch[K, V]{}
+			l.batch = batch
+			time.AfterFunc(l.wait, func() { l.dispatch(ctx, batch) })
+		}
+		l.batch.keys = append(l.batch.keys, key)
+		l.batch.results = append(l.batch.results, result)
+
+		if len(l.batch.keys) >= l.maxBatch {
+			batch := l.batch
+			l.batch = nil
+			go l.dispatch(ctx, batch)
+		}
+	}
+	l.mu.Unlock()
+
+	select {
+	case <-result.done:
+		return result.value, result.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+func (l *Loader[K, V]) dispatch(ctx context.Context, batch *loaderBatch[K, V]) {
+	l.mu.Lock()
+	if batch.dispatched {
+		l.mu.Unlock()
+		return
+	}
+	batch.dispatched = true
+	if l.batch == batch {
+		l.batch = nil
+	}
+	l.mu.Unlock()
+
+	values, err := l.fetch(ctx, batch.keys)
+	for i, key := range batch.keys {
+		result := batch.results[i]
+		switch value, found := values[key]; {
+		case err != nil:
+			result.err = err
+		case found:
+			result.value = value
+		default:
+			result.value, result.err = l.missing(key)
+		}
+		close(result.done)
+	}
+}
@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math"
+	entity "raion-assessment/domain/entity"
+	"raion-assessment/pkg/request"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/errcode"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// Error codes reported to clients in extensions.code.
+const (
+	CodeUnauthenticated = "UNAUTHENTICATED"
+	CodeForbidden       = "FORBIDDEN"
+	CodeNotFound        = "NOT_FOUND"
+	CodeConflict        = "CONFLICT"
+	CodeValidation      = "VALIDATION"
+	CodeRateLimited     = "RATE_LIMITED"
+	CodeQuotaExceeded   = "QUOTA_EXCEEDED"
+	CodeInternal        = "INTERNAL"
+)
+
+// domainCodes maps each domain error kind to the code reported for it.
+var domainCodes = []struct {
+	kind error
+	code string
+}{
+	{entity.ErrNotFound, CodeNotFound},
+	{entity.ErrConflict, CodeConflict},
+	{entity.ErrForbidden, CodeForbidden},
+	{entity.ErrUnauthenticated, CodeUnauthenticated},
+	{entity.ErrValidation, CodeValidation},
+	{entity.ErrRateLimited, CodeRateLimited},
+	{entity.ErrQuotaExceeded, CodeQuotaExceeded},
+}
+
+// codedError builds a client-facing error. A new value is returned on every
+// call because gqlgen fills in the path of the field that failed.
+func codedError(code, message string) *gqlerror.Error {
+	err := &gqlerror.Error{Message: message}
+	errcode.Set(err, code)
+	return err
+}
+
+func unauthenticated() error {
+	return codedError(CodeUnauthenticated, "authentication required")
+}
+
+func forbidden(message string) error {
+	return codedError(CodeForbidden, message)
+}
+
+func notFound(message string) error {
+	return codedError(CodeNotFound, message)
+}
+
+// invalidArgument reports a single bad argument that broke the rule named by
+// code, with the same localized message the REST API would use.
+func invalidArgument(ctx context.Context, field, code string) error {
+	return request.Invalid(request.LocaleFromContext(ctx), field, code)
+}
+
+// fromLoader classifies an error returned by one of the request loaders.
+func fromLoader(err error, notFoundMessage string) error {
+	if errors.Is(err, errNotFound) {
+		return notFound(notFoundMessage)
+	}
+	return err
+}
+
+// fromDomain converts a domain error into a coded one, carrying its field
+// errors and retry delay as extensions. Field names are rewritten from the
+// REST API's snake_case to the matching camelCase argument names, and an error
+// about a single field takes that field's message.
+func fromDomain(domainErr *entity.Error) *gqlerror.Error {
+	code := CodeInternal
+	for _, mapping := range domainCodes {
+		if errors.Is(domainErr.Kind, mapping.kind) {
+			code = mapping.code
+			break
+		}
+	}
+
+	err := codedError(code, domainErr.Message)
+	if len(domainErr.Fields) > 0 {
+		fields := make([]entity.FieldError, len(domainErr.Fields))
+		for i, field := range domainErr.Fields {
+			name := argumentName(field.Field)
+			field.Message = strings.ReplaceAll(field.Message, field.Field, name)
+			field.Field = name
+			fields[i] = field
+		}
+		if len(fields) == 1 {
+			err.Message = fields[0].Message
+		}
+		err.Extensions["errors"] = fields
+	}
+	if domainErr.RetryAfter > 0 {
+		err.Extensions["retryAfter"] = int(math.Ceil(domainErr.RetryAfter.Seconds()))
+	}
+	return err
+}
+
+// PresentError passes coded and domain errors through to the client and
+// replaces any other error with a generic INTERNAL one, logging the original
+// so details such as SQL errors never leave the server.
+func PresentError(ctx context.Context, err error) *gqlerror.Error {
+	var gqlErr *gqlerror.Error
+	if errors.As(err, &gqlErr) && gqlErr.Extensions["code"] != nil {
+		return graphql.DefaultErrorPresenter(ctx, err)
+	}
+
+	if domainErr, ok := entity.AsError(err); ok {
+		if domainErr.Err != nil {
+			log.Printf("GraphQL error at %s: %v", graphql.GetPath(ctx), err)
+		}
+		presented := fromDomain(domainErr)
+		presented.Path = graphql.GetPath(ctx)
+		return presented
+	}
+
+	log.Printf("GraphQL internal error at %s: %v", graphql.GetPath(ctx), err)
+	masked := gqlerror.WrapPath(graphql.GetPath(ctx), errors.New("internal server error"))
+	errcode.Set(masked, CodeInternal)
+	return masked
+}
+
+// RecoverPanic turns a panic in a resolver into an INTERNAL error for the
+// field instead of failing the whole response.
+func RecoverPanic(ctx context.Context, p interface{}) error {
+	log.Printf("GraphQL resolver panic at %s: %v", graphql.GetPath(ctx), p)
+	return codedError(CodeInternal, "internal server error")
+}
+
+// argumentName turns a snake_case request field such as old_password into the
+// GraphQL argument name oldPassword.
+func argumentName(field string) string {
+	parts := strings.Split(field, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
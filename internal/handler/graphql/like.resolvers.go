@@ -0,0 +1,167 @@
+package handler
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.64
+
+import (
+	"context"
+	"log"
+	"raion-assessment/domain/schema/graph"
+	"raion-assessment/domain/schema/graph/model"
+)
+
+// User is the resolver for the user field.
+func (r *commentLikeResolver) User(ctx context.Context, obj *model.CommentLike) (*model.User, error) {
+	user, err := r.loadersFor(ctx).users.Load(ctx, obj.UserID)
+	if err != nil {
+		return nil, fromLoader(err, "user not found")
+	}
+	return mapToUser(user), nil
+}
+
+// Comment is the resolver for the comment field.
+func (r *commentLikeResolver) Comment(ctx context.Context, obj *model.CommentLike) (*model.Comment, error) {
+	comment, err := r.loadersFor(ctx).comments.Load(ctx, obj.CommentID)
+	if err != nil {
+		return nil, fromLoader(err, "comment not found")
+	}
+	return mapToComment(comment), nil
+}
+
+// User is the resolver for the user field.
+func (r *likeResolver) User(ctx context.Context, obj *model.Like) (*model.User, error) {
+	user, err := r.loadersFor(ctx).users.Load(ctx, obj.UserID)
+	if err != nil {
+		return nil, fromLoader(err, "user not found")
+	}
+	return mapToUser(user), nil
+}
+
+// Post is the resolver for the post field.
+func (r *likeResolver) Post(ctx context.Context, obj *model.Like) (*model.Post, error) {
+	post, err := r.loadersFor(ctx).posts.Load(ctx, obj.PostID)
+	if err != nil {
+		return nil, fromLoader(err, "post not found")
+	}
+	return mapToPost(post), nil
+}
+
+// LikePost is the resolver for the likePost field.
+func (r *mutationResolver) LikePost(ctx context.Context, postID string) (*model.Like, error) {
+	user, err := currentUser(ctx, r.authService)
+	if err != nil {
+		return nil, err
+	}
+
+	like, err := r.likeService.AddLike(user.ID, localID(postID, "Post"))
+	if err != nil {
+		log.Println("Error liking post:", err)
+		return nil, err
+	}
+
+	return mapToLike(like), nil
+}
+
+// UnlikePost is the resolver for the unlikePost field.
+func (r *mutationResolver) UnlikePost(ctx context.Context, postID string) (bool, error) {
+	user, err := currentUser(ctx, r.authService)
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.likeService.RemoveLike(user.ID, localID(postID, "Post")); err != nil {
+		log.Println("Error unliking post:", err)
+		return false, err
+	}
+
+	return true, nil
+}
+
+// LikeComment is the resolver for the likeComment field.
+func (r *mutationResolver) LikeComment(ctx context.Context, commentID string) (*model.CommentLike, error) {
+	user, err := currentUser(ctx, r.authService)
+	if err != nil {
+		return nil, err
+	}
+
+	like, err := r.likeService.AddCommentLike(user.ID, "", commentID)
+	if err != nil {
+		log.Println("Error liking comment:", err)
+		return nil, err
+	}
+
+	return mapToCommentLike(like), nil
+}
+
+// UnlikeComment is the resolver for the unlikeComment field.
+func (r *mutationResolver) UnlikeComment(ctx context.Context, commentID string) (bool, error) {
+	user, err := currentUser(ctx, r.authService)
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.likeService.RemoveCommentLike(user.ID, "", commentID); err != nil {
+		log.Println("Error unliking comment:", err)
+		return false, err
+	}
+
+	return true, nil
+}
+
+// GetLikesByPostID is the resolver for the getLikesByPostID field.
+func (r *queryResolver) GetLikesByPostID(ctx context.Context, postID string, first *int, after *string) (*model.LikeConnection, error) {
+	page, err := pageRequestFromArgs(ctx, first, after)
+	if err != nil {
+		return nil, err
+	}
+
+	likes, pageInfo, err := r.likeService.GetLikesByPostID(localID(postID, "Post"), page)
+	if err != nil {
+		log.Println("Error fetching likes for post:", err)
+		return nil, err
+	}
+
+	return newLikeConnection(likes, page, pageInfo), nil
+}
+
+// GetLikesByUserID is the resolver for the getLikesByUserID field.
+func (r *queryResolver) GetLikesByUserID(ctx context.Context, userID string, first *int, after *string) (*model.LikeConnection, error) {
+	page, err := pageRequestFromArgs(ctx, first, after)
+	if err != nil {
+		return nil, err
+	}
+
+	likes, pageInfo, err := r.likeService.GetLikesByUserID(localID(userID, "User"), page)
+	if err != nil {
+		log.Println("Error fetching likes for user:", err)
+		return nil, err
+	}
+
+	return newLikeConnection(likes, page, pageInfo), nil
+}
+
+// GetLikesByCommentID is the resolver for the getLikesByCommentID field.
+func (r *queryResolver) GetLikesByCommentID(ctx context.Context, commentID string, first *int, after *string) (*model.CommentLikeConnection, error) {
+	page, err := pageRequestFromArgs(ctx, first, after)
+	if err != nil {
+		return nil, err
+	}
+
+	likes, pageInfo, err := r.likeService.GetLikesByCommentID("", commentID, page)
+	if err != nil {
+		log.Println("Error fetching likes for comment:", err)
+		return nil, err
+	}
+
+	return newCommentLikeConnection(likes, page, pageInfo), nil
+}
+
+// CommentLike returns graph.CommentLikeResolver implementation.
+func (r *Resolver) CommentLike() graph.CommentLikeResolver { return &commentLikeResolver{r} }
+
+// Like returns graph.LikeResolver implementation.
+func (r *Resolver) Like() graph.LikeResolver { return &likeResolver{r} }
+
+type commentLikeResolver struct{ *Resolver }
+type likeResolver struct{ *Resolver }
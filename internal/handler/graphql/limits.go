@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	entity "raion-assessment/domain/entity"
+	"raion-assessment/domain/schema/graph"
+	"raion-assessment/domain/schema/graph/model"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/errcode"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// NewComplexityRoot scores list fields by the number of items they can
+// return, so a nested connection costs its page size times the cost of each
+// node. Fields not listed here cost one plus their children.
+func NewComplexityRoot() graph.ComplexityRoot {
+	var root graph.ComplexityRoot
+
+	root.Query.GetAllPosts = func(childComplexity int, first *int, after *string) int {
+		return listComplexity(childComplexity, first)
+	}
+	root.Query.GetAllUsers = func(childComplexity int, first *int, after *string) int {
+		return listComplexity(childComplexity, first)
+	}
+	root.Query.GetPostsByUserID = func(childComplexity int, userID string, first *int, after *string) int {
+		return listComplexity(childComplexity, first)
+	}
+	root.Query.SearchPosts = func(childComplexity int, query string, first *int, after *string) int {
+		return listComplexity(childComplexity, first)
+	}
+	root.Query.SearchUsers = func(childComplexity int, query string, first *int, after *string) int {
+		return listComplexity(childComplexity, first)
+	}
+	root.Query.GetCommentsByPostID = func(childComplexity int, postID string, sort *model.CommentSort, first *int, after *string) int {
+		return listComplexity(childComplexity, first)
+	}
+	root.Query.GetLikesByPostID = func(childComplexity int, postID string, first *int, after *string) int {
+		return listComplexity(childComplexity, first)
+	}
+	root.Query.GetLikesByUserID = func(childComplexity int, userID string, first *int, after *string) int {
+		return listComplexity(childComplexity, first)
+	}
+	root.Query.GetLikesByCommentID = func(childComplexity int, commentID string, first *int, after *string) int {
+		return listComplexity(childComplexity, first)
+	}
+	root.Post.Comments = func(childComplexity int, sort *model.CommentSort, first *int, after *string) int {
+		return listComplexity(childComplexity, first)
+	}
+	root.Post.Likes = func(childComplexity int, first *int, after *string) int {
+		return listComplexity(childComplexity, first)
+	}
+	root.User.Posts = func(childComplexity int, first *int, after *string) int {
+		return listComplexity(childComplexity, first)
+	}
+
+	return root
+}
+
+// listComplexity multiplies the cost of one item by the page size the field
+// will actually use, applying the same default and cap as the resolvers.
+func listComplexity(childComplexity int, first *int) int {
+	size := entity.PageRequest{Limit: entity.DefaultPageSize}
+	if first != nil && *first > 0 {
+		size.Limit = *first
+	}
+	return 1 + childComplexity*size.Normalized().Limit
+}
+
+// DepthLimit rejects operations whose selections nest deeper than MaxDepth
+// before any resolver runs. Introspection fields are not counted so tooling
+// keeps working.
+type DepthLimit struct {
+	MaxDepth int
+}
+
+var _ interface {
+	graphql.OperationContextMutator
+	graphql.HandlerExtension
+} = DepthLimit{}
+
+func (d DepthLimit) ExtensionName() string {
+	return "DepthLimit"
+}
+
+func (d DepthLimit) Validate(schema graphql.ExecutableSchema) error {
+	if d.MaxDepth <= 0 {
+		return errors.New("DepthLimit.MaxDepth must be positive")
+	}
+	return nil
+}
+
+func (d DepthLimit) MutateOperationContext(ctx context.Context, opCtx *graphql.OperationContext) *gqlerror.Error {
+	depth := selectionDepth(opCtx.Operation.SelectionSet)
+	if depth > d.MaxDepth {
+		err := gqlerror.Errorf("operation has depth %d, which exceeds the limit of %d", depth, d.MaxDepth)
+		errcode.Set(err, "DEPTH_LIMIT_EXCEEDED")
+		return err
+	}
+	return nil
+}
+
+func selectionDepth(selections ast.SelectionSet) int {
+	deepest := 0
+	for _, selection := range selections {
+		depth := 0
+		switch selection := selection.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(selection.Name, "__") {
+				continue
+			}
+			depth = 1 + selectionDepth(selection.SelectionSet)
+		case *ast.InlineFragment:
+			depth = selectionDepth(selection.SelectionSet)
+		case *ast.FragmentSpread:
+			if selection.Definition != nil {
+				depth = selectionDepth(selection.Definition.SelectionSet)
+			}
+		}
+		if depth > deepest {
+			deepest = depth
+		}
+	}
+	return deepest
+}
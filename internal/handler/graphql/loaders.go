@@ -0,0 +1,167 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	entity "raion-assessment/domain/entity"
+
+	"github.com/99designs/gqlgen/graphql"
+)
+
+var errNotFound = errors.New("not found")
+
+type loadersKey struct{}
+
+// pageKey identifies the first page of a child list, such as a post's
+// comments. Keys sharing a sort order and limit are fetched together.
+type pageKey struct {
+	ParentID string
+	Sort     string
+	Limit    int
+}
+
+type pageGroup struct {
+	Sort  string
+	Limit int
+}
+
+// Loaders holds the per-request loaders used by the relationship fields so
+// that resolving a page of objects costs one query per relationship instead
+// of one per object.
+type Loaders struct {
+	viewerOnce    sync.Once
+	viewer        string
+	resolveViewer func() string
+
+	users          *Loader[string, entity.User]
+	posts          *Loader[string, entity.Post]
+	comments       *Loader[string, entity.Comment]
+	postsByUser    *Loader[pageKey, entity.Page[entity.Post]]
+	commentsByPost *Loader[pageKey, entity.Page[entity.Comment]]
+	likesByPost    *Loader[pageKey, entity.Page[entity.Like]]
+}
+
+// WithLoaders attaches a fresh set of loaders to each response: once per
+// query or mutation, and once per event on a subscription so that cached
+// values never outlive the event they were read for.
+func (r *Resolver) WithLoaders(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+	return next(context.WithValue(ctx, loadersKey{}, r.newLoaders(ctx)))
+}
+
+// loadersFor returns the loaders attached to ctx, falling back to an uncached
+// set outside of a response, such as when a subscription starts.
+func (r *Resolver) loadersFor(ctx context.Context) *Loaders {
+	if loaders, ok := ctx.Value(loadersKey{}).(*Loaders); ok {
+		return loaders
+	}
+	return r.newLoaders(ctx)
+}
+
+func (r *Resolver) newLoaders(ctx context.Context) *Loaders {
+	loaders := &Loaders{
+		resolveViewer: func() string {
+			user, err := currentUser(ctx, r.authService)
+			if err != nil {
+				return ""
+			}
+			return user.ID
+		},
+	}
+
+	loaders.users = NewLoader(func(_ context.Context, ids []string) (map[string]entity.User, error) {
+		users, err := r.userService.FetchUsersByIDs(ids)
+		if err != nil {
+			return nil, err
+		}
+		byID := make(map[string]entity.User, len(users))
+		for _, user := range users {
+			byID[user.ID] = user
+		}
+		return byID, nil
+	}, missingEntity[entity.User])
+
+	loaders.posts = NewLoader(func(_ context.Context, ids []string) (map[string]entity.Post, error) {
+		posts, err := r.postService.FetchPostsByIDs(ids, loaders.viewerID())
+		if err != nil {
+			return nil, err
+		}
+		byID := make(map[string]entity.Post, len(posts))
+		for _, post := range posts {
+			byID[post.ID] = post
+		}
+		return byID, nil
+	}, missingEntity[entity.Post])
+
+	loaders.comments = NewLoader(func(_ context.Context, ids []string) (map[string]entity.Comment, error) {
+		comments, err := r.commentService.GetCommentsByIDs(ids, loaders.viewerID())
+		if err != nil {
+			return nil, err
+		}
+		byID := make(map[string]entity.Comment, len(comments))
+		for _, comment := range comments {
+			byID[comment.ID] = comment
+		}
+		return byID, nil
+	}, missingEntity[entity.Comment])
+
+	loaders.postsByUser = NewLoader(func(_ context.Context, keys []pageKey) (map[pageKey]entity.Page[entity.Post], error) {
+		return loadPages(keys, func(userIDs []string, group pageGroup) (map[string]entity.Page[entity.Post], error) {
+			return r.postService.FetchPostsByUserIDs(userIDs, loaders.viewerID(), entity.PageRequest{Limit: group.Limit})
+		})
+	}, emptyPage[entity.Post])
+
+	loaders.commentsByPost = NewLoader(func(_ context.Context, keys []pageKey) (map[pageKey]entity.Page[entity.Comment], error) {
+		return loadPages(keys, func(postIDs []string, group pageGroup) (map[string]entity.Page[entity.Comment], error) {
+			return r.commentService.GetCommentsByPostIDs(postIDs, loaders.viewerID(), group.Sort, entity.PageRequest{Limit: group.Limit})
+		})
+	}, emptyPage[entity.Comment])
+
+	loaders.likesByPost = NewLoader(func(_ context.Context, keys []pageKey) (map[pageKey]entity.Page[entity.Like], error) {
+		return loadPages(keys, func(postIDs []string, group pageGroup) (map[string]entity.Page[entity.Like], error) {
+			return r.likeService.GetLikesByPostIDs(postIDs, entity.PageRequest{Limit: group.Limit})
+		})
+	}, emptyPage[entity.Like])
+
+	return loaders
+}
+
+// viewerID resolves the calling user once per request; "" means anonymous.
+func (l *Loaders) viewerID() string {
+	l.viewerOnce.Do(func() {
+		l.viewer = l.resolveViewer()
+	})
+	return l.viewer
+}
+
+// loadPages splits page keys by sort order and limit and runs one batch
+// fetch per group, which in practice is a single query per field.
+func loadPages[T any](keys []pageKey, fetch func(parentIDs []string, group pageGroup) (map[string]entity.Page[T], error)) (map[pageKey]entity.Page[T], error) {
+	groups := make(map[pageGroup][]string)
+	for _, key := range keys {
+		group := pageGroup{Sort: key.Sort, Limit: key.Limit}
+		groups[group] = append(groups[group], key.ParentID)
+	}
+
+	pages := make(map[pageKey]entity.Page[T], len(keys))
+	for group, parentIDs := range groups {
+		byParent, err := fetch(parentIDs, group)
+		if err != nil {
+			return nil, err
+		}
+		for parentID, page := range byParent {
+			pages[pageKey{ParentID: parentID, Sort: group.Sort, Limit: group.Limit}] = page
+		}
+	}
+	return pages, nil
+}
+
+func missingEntity[T any](string) (T, error) {
+	var zero T
+	return zero, errNotFound
+}
+
+func emptyPage[T any](pageKey) (entity.Page[T], error) {
+	return entity.Page[T]{Items: []T{}}, nil
+}
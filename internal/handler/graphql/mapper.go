@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"sort"
+	"strings"
+
+	entity "raion-assessment/domain/entity"
+	"raion-assessment/domain/schema/graph/model"
+	"raion-assessment/pkg/util"
+)
+
+func mapToPost(post entity.Post) *model.Post {
+	return &model.Post{
+		ID:           util.ToGlobalID("Post", post.ID),
+		DatabaseID:   post.ID,
+		UserID:       post.UserID,
+		Caption:      post.Caption,
+		ImageURL:     post.ImageURL,
+		Media:        mapToPostMedia(post.Media),
+		Status:       model.MediaStatus(strings.ToUpper(post.Status)),
+		LikeCount:    post.LikeCount,
+		CommentCount: post.CommentCount,
+		LikedByMe:    post.LikedByMe,
+		CreatedAt:    post.CreatedAt,
+		UpdatedAt:    post.UpdatedAt,
+	}
+}
+
+func mapToUser(user entity.User) *model.User {
+	return &model.User{
+		ID:         util.ToGlobalID("User", user.ID),
+		DatabaseID: user.ID,
+		Username:   user.Name,
+		Email:      user.Email,
+		Bio:        user.Bio,
+		ImageURL:   user.ImageURL,
+		Avatar:     mapToMedia(user.Avatar),
+		PostCount:  user.PostCount,
+		CreatedAt:  user.CreatedAt,
+		UpdatedAt:  user.UpdatedAt,
+	}
+}
+
+func mapToPostMedia(media []entity.PostMedia) []*model.PostMedia {
+	items := make([]*model.PostMedia, 0, len(media))
+	for _, item := range media {
+		items = append(items, &model.PostMedia{
+			Position: item.Position,
+			AltText:  item.AltText,
+			Media:    mapToMedia(&item.Media),
+		})
+	}
+	return items
+}
+
+func mapToMedia(media *entity.Media) *model.Media {
+	if media == nil {
+		return nil
+	}
+	renditions := make([]*model.Rendition, 0, len(media.Renditions))
+	for _, rendition := range media.Renditions {
+		renditions = append(renditions, &model.Rendition{
+			Name:        rendition.Name,
+			URL:         rendition.URL,
+			ContentType: rendition.ContentType,
+			Width:       rendition.Width,
+			Height:      rendition.Height,
+		})
+	}
+	return &model.Media{
+		DatabaseID:    media.ID,
+		Purpose:       model.MediaPurpose(strings.ToUpper(media.Purpose)),
+		Kind:          model.MediaKind(strings.ToUpper(media.Kind)),
+		Status:        model.MediaStatus(strings.ToUpper(media.Status)),
+		Width:         media.Width,
+		Height:        media.Height,
+		DurationMs:    media.DurationMS,
+		Blurhash:      media.BlurHash,
+		DominantColor: media.DominantColor,
+		Renditions:    renditions,
+	}
+}
+
+func mapToDirectUpload(upload entity.DirectUpload) *model.DirectUpload {
+	headers := make([]*model.HTTPHeader, 0, len(upload.Headers))
+	for name, value := range upload.Headers {
+		headers = append(headers, &model.HTTPHeader{Name: name, Value: value})
+	}
+	sort.Slice(headers, func(i, j int) bool { return headers[i].Name < headers[j].Name })
+	return &model.DirectUpload{
+		ID:           upload.ID,
+		Method:       upload.Method,
+		URL:          upload.URL,
+		Headers:      headers,
+		URLExpiresAt: upload.URLExpiresAt,
+		ExpiresAt:    upload.ExpiresAt,
+	}
+}
+
+func mapToStorageUsage(usage entity.StorageUsage) *model.StorageUsage {
+	return &model.StorageUsage{
+		Used:      int(usage.Used),
+		Reserved:  int(usage.Reserved),
+		Quota:     int(usage.Quota),
+		Remaining: int(usage.Remaining()),
+	}
+}
+
+func mapToComment(comment entity.Comment) *model.Comment {
+	return &model.Comment{
+		ID:        comment.ID,
+		UserID:    comment.UserID,
+		PostID:    comment.PostID,
+		Content:   comment.Content,
+		LikeCount: comment.LikeCount,
+		LikedByMe: comment.LikedByMe,
+		CreatedAt: comment.CreatedAt,
+		UpdatedAt: comment.UpdatedAt,
+	}
+}
+
+func mapToLike(like entity.Like) *model.Like {
+	return &model.Like{
+		ID:        like.ID,
+		UserID:    like.UserID,
+		PostID:    like.PostID,
+		CreatedAt: like.CreatedAt,
+	}
+}
+
+func mapToCommentLike(like entity.CommentLike) *model.CommentLike {
+	return &model.CommentLike{
+		ID:        like.ID,
+		UserID:    like.UserID,
+		CommentID: like.CommentID,
+		CreatedAt: like.CreatedAt,
+	}
+}
+
+func mapToLikeCountChange(change entity.LikeCountChange) *model.LikeCountChange {
+	return &model.LikeCountChange{
+		PostID:    change.PostID,
+		LikeCount: change.LikeCount,
+	}
+}
+
+func mapToNotification(notification entity.Notification) *model.Notification {
+	mapped := &model.Notification{
+		Type:      model.NotificationType(strings.ToUpper(notification.Type)),
+		ActorID:   notification.ActorID,
+		PostID:    notification.PostID,
+		CreatedAt: notification.CreatedAt,
+	}
+	if notification.CommentID != "" {
+		mapped.CommentID = &notification.CommentID
+	}
+	return mapped
+}
@@ -0,0 +1,67 @@
+package handler
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.64
+
+import (
+	"context"
+	"raion-assessment/domain/schema/graph/model"
+	"raion-assessment/pkg/request"
+	"strings"
+)
+
+// CreateDirectUpload is the resolver for the createDirectUpload field.
+func (r *mutationResolver) CreateDirectUpload(ctx context.Context, purpose model.MediaPurpose, contentType string, size int) (*model.DirectUpload, error) {
+	user, err := currentUser(ctx, r.authService)
+	if err != nil {
+		return nil, err
+	}
+
+	input := request.CreateDirectUploadRequest{Purpose: strings.ToLower(string(purpose)), ContentType: contentType, Size: int64(size)}
+	if err := validateInput(ctx, input); err != nil {
+		return nil, err
+	}
+	normalized, err := r.mediaService.CheckDirectUpload(ctx, input.Purpose, input.ContentType, input.Size)
+	if err != nil {
+		return nil, err
+	}
+
+	upload, err := r.uploadService.PresignUpload(user.ID, input.Purpose, normalized, input.Size)
+	if err != nil {
+		return nil, err
+	}
+	return mapToDirectUpload(upload), nil
+}
+
+// CompleteDirectUpload is the resolver for the completeDirectUpload field.
+func (r *mutationResolver) CompleteDirectUpload(ctx context.Context, id string) (*model.Media, error) {
+	user, err := currentUser(ctx, r.authService)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateInput(ctx, request.UploadParams{ID: id}); err != nil {
+		return nil, err
+	}
+
+	media, err := r.mediaService.CompleteDirectUpload(ctx, user.ID, id)
+	if err != nil {
+		return nil, err
+	}
+	return mapToMedia(&media), nil
+}
+
+// StorageUsage is the resolver for the storageUsage field.
+func (r *queryResolver) StorageUsage(ctx context.Context) (*model.StorageUsage, error) {
+	user, err := currentUser(ctx, r.authService)
+	if err != nil {
+		return nil, err
+	}
+
+	usage, err := r.mediaService.FetchStorageUsage(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	return mapToStorageUsage(usage), nil
+}
@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/errcode"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+const persistedQueryCacheSize = 1000
+
+// PersistedQueries is the store behind automatic persisted queries. Queries
+// from the manifest, a JSON object mapping SHA-256 hashes to query text, are
+// always known. Other queries are registered on first use unless AllowList is
+// set, in which case only manifest queries may run at all.
+type PersistedQueries struct {
+	AllowList bool
+
+	manifest map[string]string
+	cache    *lru.LRU[string]
+}
+
+var _ interface {
+	graphql.Cache[string]
+	graphql.OperationParameterMutator
+	graphql.HandlerExtension
+} = &PersistedQueries{}
+
+// LoadPersistedQueries reads the manifest at path; an empty path yields an
+// empty manifest.
+func LoadPersistedQueries(path string, allowList bool) (*PersistedQueries, error) {
+	store := &PersistedQueries{
+		AllowList: allowList,
+		manifest:  make(map[string]string),
+		cache:     lru.New[string](persistedQueryCacheSize),
+	}
+	if path == "" {
+		return store, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading persisted query manifest: %w", err)
+	}
+	if err := json.Unmarshal(data, &store.manifest); err != nil {
+		return nil, fmt.Errorf("error parsing persisted query manifest: %w", err)
+	}
+	for hash, query := range store.manifest {
+		if queryHash(query) != hash {
+			return nil, fmt.Errorf("persisted query manifest: hash %s does not match its query", hash)
+		}
+	}
+	return store, nil
+}
+
+func (p *PersistedQueries) Get(ctx context.Context, hash string) (string, bool) {
+	if query, ok := p.manifest[hash]; ok {
+		return query, true
+	}
+	if p.AllowList {
+		return "", false
+	}
+	return p.cache.Get(ctx, hash)
+}
+
+func (p *PersistedQueries) Add(ctx context.Context, hash string, query string) {
+	if p.AllowList {
+		return
+	}
+	if _, ok := p.manifest[hash]; !ok {
+		p.cache.Add(ctx, hash, query)
+	}
+}
+
+func (p *PersistedQueries) ExtensionName() string {
+	return "PersistedQueryAllowList"
+}
+
+func (p *PersistedQueries) Validate(schema graphql.ExecutableSchema) error {
+	return nil
+}
+
+// MutateOperationParameters runs after the APQ extension has resolved any
+// hash to its query text and, in allow-list mode, refuses everything that is
+// not in the manifest.
+func (p *PersistedQueries) MutateOperationParameters(ctx context.Context, rawParams *graphql.RawParams) *gqlerror.Error {
+	if !p.AllowList {
+		return nil
+	}
+	if _, ok := p.manifest[queryHash(rawParams.Query)]; ok {
+		return nil
+	}
+	err := gqlerror.Errorf("operation is not in the persisted query allow-list")
+	errcode.Set(err, "PERSISTED_QUERY_NOT_ALLOWED")
+	return err
+}
+
+func queryHash(query string) string {
+	sum := sha256.Sum256([]byte(query))
+	return hex.EncodeToString(sum[:])
+}
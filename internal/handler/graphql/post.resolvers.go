@@ -0,0 +1,296 @@
+package handler
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.64
+
+import (
+	"context"
+	"io"
+	"log"
+	domain "raion-assessment/domain/entity"
+	"raion-assessment/domain/schema/graph"
+	"raion-assessment/domain/schema/graph/model"
+	"raion-assessment/pkg/request"
+	"raion-assessment/pkg/util"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+)
+
+// CreatePost is the resolver for the createPost field.
+func (r *mutationResolver) CreatePost(ctx context.Context, caption string, media []*graphql.Upload, altTexts []string, images []*graphql.Upload, image *graphql.Upload, uploadIds []string, mediaIds []string) (*model.Post, error) {
+	user, err := currentUser(ctx, r.authService)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateInput(ctx, request.CreatePostRequest{Caption: caption, AltText: altTexts, UploadIDs: uploadIds, MediaIDs: mediaIds}); err != nil {
+		return nil, err
+	}
+
+	srcs := make([]io.ReadSeeker, 0, len(media)+len(images)+1)
+	for _, upload := range append(media, images...) {
+		srcs = append(srcs, upload.File)
+	}
+	if image != nil {
+		srcs = append(srcs, image.File)
+	}
+	postMedia, err := r.mediaService.UploadPostMedia(ctx, user.ID, srcs, uploadIds, mediaIds, altTexts)
+	if err != nil {
+		log.Println("Error uploading post media:", err)
+		return nil, err
+	}
+
+	createdPost, err := r.postService.CreatePost(domain.Post{
+		UserID:   user.ID,
+		Caption:  caption,
+		ImageURL: postMedia[0].Media.URL(domain.RenditionFull),
+		Media:    postMedia,
+	})
+	if err != nil {
+		log.Println("Error creating post:", err)
+		return nil, err
+	}
+
+	return mapToPost(createdPost), nil
+}
+
+// UpdatePostCaption is the resolver for the updatePostCaption field.
+func (r *mutationResolver) UpdatePostCaption(ctx context.Context, id string, caption string) (*model.Post, error) {
+	user, err := currentUser(ctx, r.authService)
+	if err != nil {
+		return nil, err
+	}
+
+	postID := localID(id, "Post")
+	if err := validateInput(ctx, request.UpdatePostRequest{ID: postID, Caption: &caption}); err != nil {
+		return nil, err
+	}
+	post, err := r.postService.FetchPostByID(postID, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if post.UserID != user.ID {
+		return nil, forbidden("not allowed to update this post")
+	}
+
+	updatedPost, err := r.postService.EditPost(postID, &caption, nil)
+	if err != nil {
+		log.Println("Error updating post:", err)
+		return nil, err
+	}
+
+	return mapToPost(updatedPost), nil
+}
+
+// UpdatePostMedia is the resolver for the updatePostMedia field.
+func (r *mutationResolver) UpdatePostMedia(ctx context.Context, id string, media []*model.PostMediaInput) (*model.Post, error) {
+	user, err := currentUser(ctx, r.authService)
+	if err != nil {
+		return nil, err
+	}
+
+	postID := localID(id, "Post")
+	items := make([]request.PostMediaItem, 0, len(media))
+	for _, item := range media {
+		var altText string
+		if item.AltText != nil {
+			altText = *item.AltText
+		}
+		items = append(items, request.PostMediaItem{MediaID: item.MediaID, AltText: altText})
+	}
+	if err := validateInput(ctx, request.UpdatePostRequest{ID: postID, Media: items}); err != nil {
+		return nil, err
+	}
+
+	post, err := r.postService.FetchPostByID(postID, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if post.UserID != user.ID {
+		return nil, forbidden("not allowed to update this post")
+	}
+
+	arranged, err := util.ArrangePostMedia(request.LocaleFromContext(ctx), post.Media, items)
+	if err != nil {
+		return nil, err
+	}
+	updatedPost, err := r.postService.EditPost(postID, nil, arranged)
+	if err != nil {
+		log.Println("Error updating post media:", err)
+		return nil, err
+	}
+
+	return mapToPost(updatedPost), nil
+}
+
+// DeletePost is the resolver for the deletePost field.
+func (r *mutationResolver) DeletePost(ctx context.Context, id string) (bool, error) {
+	user, err := currentUser(ctx, r.authService)
+	if err != nil {
+		return false, err
+	}
+
+	postID := localID(id, "Post")
+	post, err := r.postService.FetchPostByID(postID, user.ID)
+	if err != nil {
+		return false, err
+	}
+
+	if post.UserID != user.ID {
+		return false, forbidden("not allowed to delete this post")
+	}
+
+	if err := r.postService.DeletePost(postID); err != nil {
+		log.Println("Error deleting post:", err)
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Author is the resolver for the author field.
+func (r *postResolver) Author(ctx context.Context, obj *model.Post) (*model.User, error) {
+	user, err := r.loadersFor(ctx).users.Load(ctx, obj.UserID)
+	if err != nil {
+		return nil, fromLoader(err, "user not found")
+	}
+	return mapToUser(user), nil
+}
+
+// Comments is the resolver for the comments field.
+func (r *postResolver) Comments(ctx context.Context, obj *model.Post, sort *model.CommentSort, first *int, after *string) (*model.CommentConnection, error) {
+	order := domain.CommentSortNewest
+	if sort != nil {
+		order = strings.ToLower(sort.String())
+	}
+
+	page, err := pageRequestFromArgs(ctx, first, after)
+	if err != nil {
+		return nil, err
+	}
+
+	// First pages go through the loader so a page of posts shares one query;
+	// later pages are only requested for a single post at a time.
+	var comments []domain.Comment
+	var pageInfo domain.PageInfo
+	if page.After == nil {
+		result, err := r.loadersFor(ctx).commentsByPost.Load(ctx, pageKey{ParentID: obj.DatabaseID, Sort: order, Limit: page.Limit})
+		if err != nil {
+			log.Println("Error fetching comments:", err)
+			return nil, err
+		}
+		comments, pageInfo = result.Items, result.PageInfo
+	} else {
+		comments, pageInfo, err = r.commentService.GetCommentsByPostID(obj.DatabaseID, r.viewerID(ctx), order, page)
+		if err != nil {
+			log.Println("Error fetching comments:", err)
+			return nil, err
+		}
+	}
+
+	connection := newCommentConnection(comments, order, page, pageInfo)
+	count := obj.CommentCount
+	connection.Count = &count
+	return connection, nil
+}
+
+// Likes is the resolver for the likes field.
+func (r *postResolver) Likes(ctx context.Context, obj *model.Post, first *int, after *string) (*model.LikeConnection, error) {
+	page, err := pageRequestFromArgs(ctx, first, after)
+	if err != nil {
+		return nil, err
+	}
+
+	var likes []domain.Like
+	var pageInfo domain.PageInfo
+	if page.After == nil {
+		result, err := r.loadersFor(ctx).likesByPost.Load(ctx, pageKey{ParentID: obj.DatabaseID, Limit: page.Limit})
+		if err != nil {
+			log.Println("Error fetching likes:", err)
+			return nil, err
+		}
+		likes, pageInfo = result.Items, result.PageInfo
+	} else {
+		likes, pageInfo, err = r.likeService.GetLikesByPostID(obj.DatabaseID, page)
+		if err != nil {
+			log.Println("Error fetching likes:", err)
+			return nil, err
+		}
+	}
+
+	connection := newLikeConnection(likes, page, pageInfo)
+	count := obj.LikeCount
+	connection.Count = &count
+	return connection, nil
+}
+
+// GetAllPosts is the resolver for the getAllPosts field.
+func (r *queryResolver) GetAllPosts(ctx context.Context, first *int, after *string) (*model.PostConnection, error) {
+	page, err := pageRequestFromArgs(ctx, first, after)
+	if err != nil {
+		return nil, err
+	}
+
+	posts, pageInfo, err := r.postService.FetchAllPosts(r.viewerID(ctx), page)
+	if err != nil {
+		log.Println("Error fetching posts:", err)
+		return nil, err
+	}
+
+	return newPostConnection(posts, page, pageInfo), nil
+}
+
+// GetPostByID is the resolver for the getPostByID field.
+func (r *queryResolver) GetPostByID(ctx context.Context, id string) (*model.Post, error) {
+	post, err := r.postService.FetchPostByID(localID(id, "Post"), r.viewerID(ctx))
+	if err != nil {
+		log.Println("Post not found:", err)
+		return nil, err
+	}
+
+	return mapToPost(post), nil
+}
+
+// GetPostsByUserID is the resolver for the getPostsByUserID field.
+func (r *queryResolver) GetPostsByUserID(ctx context.Context, userID string, first *int, after *string) (*model.PostConnection, error) {
+	page, err := pageRequestFromArgs(ctx, first, after)
+	if err != nil {
+		return nil, err
+	}
+
+	posts, pageInfo, err := r.postService.FetchPostsByUserID(localID(userID, "User"), r.viewerID(ctx), page)
+	if err != nil {
+		log.Println("Error fetching posts for user:", err)
+		return nil, err
+	}
+
+	return newPostConnection(posts, page, pageInfo), nil
+}
+
+// SearchPosts is the resolver for the searchPosts field.
+func (r *queryResolver) SearchPosts(ctx context.Context, query string, first *int, after *string) (*model.PostConnection, error) {
+	if err := validateInput(ctx, request.SearchRequest{Query: query}); err != nil {
+		return nil, err
+	}
+
+	page, err := pageRequestFromArgs(ctx, first, after)
+	if err != nil {
+		return nil, err
+	}
+
+	posts, pageInfo, err := r.postService.SearchPosts(query, r.viewerID(ctx), page)
+	if err != nil {
+		log.Println("Error searching posts:", err)
+		return nil, err
+	}
+
+	return newPostConnection(posts, page, pageInfo), nil
+}
+
+// Post returns graph.PostResolver implementation.
+func (r *Resolver) Post() graph.PostResolver { return &postResolver{r} }
+
+type postResolver struct{ *Resolver }
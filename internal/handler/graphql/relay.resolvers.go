@@ -0,0 +1,43 @@
+package handler
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.64
+
+import (
+	"context"
+	"fmt"
+	"raion-assessment/domain/schema/graph"
+	"raion-assessment/domain/schema/graph/model"
+	"raion-assessment/pkg/util"
+)
+
+// Node is the resolver for the node field.
+func (r *queryResolver) Node(ctx context.Context, id string) (model.Node, error) {
+	typeName, rawID, err := util.FromGlobalID(id)
+	if err != nil {
+		return nil, invalidArgument(ctx, "id", "invalid")
+	}
+
+	switch typeName {
+	case "Post":
+		post, err := r.postService.FetchPostByID(rawID, r.viewerID(ctx))
+		if err != nil {
+			return nil, nil
+		}
+		return mapToPost(post), nil
+	case "User":
+		user, err := r.userService.FetchUserByID(rawID)
+		if err != nil {
+			return nil, nil
+		}
+		return mapToUser(user), nil
+	default:
+		return nil, notFound(fmt.Sprintf("unknown node type %q", typeName))
+	}
+}
+
+// Query returns graph.QueryResolver implementation.
+func (r *Resolver) Query() graph.QueryResolver { return &queryResolver{r} }
+
+type queryResolver struct{ *Resolver }
@@ -0,0 +1,40 @@
+package handler
+
+import (
+	contract "raion-assessment/domain/contract"
+)
+
+// Resolver is the gqlgen resolver root. The per-type resolvers generated from
+// domain/schema/graph/*.graphqls hang off it and call into the services.
+type Resolver struct {
+	userService    contract.IUserService
+	postService    contract.IPostService
+	commentService contract.ICommentService
+	likeService    contract.ILikeService
+	authService    contract.IAuthService
+	events         contract.IEventBus
+	mediaService   contract.IMediaService
+	uploadService  contract.IUploadService
+}
+
+func NewResolver(
+	userService contract.IUserService,
+	postService contract.IPostService,
+	commentService contract.ICommentService,
+	likeService contract.ILikeService,
+	authService contract.IAuthService,
+	events contract.IEventBus,
+	mediaService contract.IMediaService,
+	uploadService contract.IUploadService,
+) *Resolver {
+	return &Resolver{
+		userService:    userService,
+		postService:    postService,
+		commentService: commentService,
+		likeService:    likeService,
+		authService:    authService,
+		events:         events,
+		mediaService:   mediaService,
+		uploadService:  uploadService,
+	}
+}
@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/gorilla/websocket"
+)
+
+// forward relays the bus events of type T that pass keep to a subscription,
+// mapped to their GraphQL model. The returned channel is closed when the
+// subscription ends.
+func forward[T any, M any](ctx context.Context, events <-chan interface{}, keep func(T) bool, mapTo func(T) M) <-chan M {
+	out := make(chan M)
+	go func() {
+		defer close(out)
+		for payload := range events {
+			event, ok := payload.(T)
+			if !ok || !keep(event) {
+				continue
+			}
+			select {
+			case out <- mapTo(event):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// Websocket returns the graphql-transport-ws transport. Clients authenticate
+// by sending the same bearer token used over HTTP as the Authorization entry
+// of the connection_init payload; connections without one stay anonymous.
+// Browsers may only connect from the origins checkOrigin accepts for
+// allowOrigins, the CORS setting.
+func (r *Resolver) Websocket(allowOrigins string) transport.Websocket {
+	return transport.Websocket{
+		Upgrader: websocket.Upgrader{
+			CheckOrigin:  checkOrigin(allowOrigins),
+			Subprotocols: []string{"graphql-transport-ws"},
+		},
+		InitTimeout:      10 * time.Second,
+		PingPongInterval: 30 * time.Second,
+		InitFunc: func(ctx context.Context, payload transport.InitPayload) (context.Context, *transport.InitPayload, error) {
+			token := strings.TrimPrefix(payload.Authorization(), "Bearer ")
+			if token == "" {
+				return ctx, nil, nil
+			}
+
+			ctx = context.WithValue(ctx, "token", token)
+			if _, err := currentUser(ctx, r.authService); err != nil {
+				return nil, nil, err
+			}
+			return ctx, nil, nil
+		},
+	}
+}
+
+// checkOrigin accepts WebSocket handshakes from the API's own origin, from the
+// origins listed in allowOrigins, a comma-separated CORS setting, and from
+// clients that send no Origin, which browsers always send. Browsers do not
+// apply CORS to WebSockets, so a "*" in the setting, which lets any site read
+// responses to requests made without credentials, does not let any site open
+// a socket that carries the user's token.
+func checkOrigin(allowOrigins string) func(*http.Request) bool {
+	allowed := make(map[string]bool)
+	for _, origin := range strings.Split(allowOrigins, ",") {
+		if origin = normalizeOrigin(origin); origin != "*" {
+			allowed[origin] = true
+		}
+	}
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			return true
+		}
+		if u, err := url.Parse(origin); err == nil && strings.EqualFold(u.Host, r.Host) {
+			return true
+		}
+		return allowed[normalizeOrigin(origin)]
+	}
+}
+
+func normalizeOrigin(origin string) string {
+	return strings.ToLower(strings.TrimSuffix(strings.TrimSpace(origin), "/"))
+}
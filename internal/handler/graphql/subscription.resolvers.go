@@ -0,0 +1,93 @@
+package handler
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.64
+
+import (
+	"context"
+	domain "raion-assessment/domain/entity"
+	"raion-assessment/domain/schema/graph"
+	"raion-assessment/domain/schema/graph/model"
+)
+
+// Actor is the resolver for the actor field.
+func (r *notificationResolver) Actor(ctx context.Context, obj *model.Notification) (*model.User, error) {
+	user, err := r.loadersFor(ctx).users.Load(ctx, obj.ActorID)
+	if err != nil {
+		return nil, fromLoader(err, "user not found")
+	}
+	return mapToUser(user), nil
+}
+
+// Post is the resolver for the post field.
+func (r *notificationResolver) Post(ctx context.Context, obj *model.Notification) (*model.Post, error) {
+	post, err := r.loadersFor(ctx).posts.Load(ctx, obj.PostID)
+	if err != nil {
+		return nil, fromLoader(err, "post not found")
+	}
+	return mapToPost(post), nil
+}
+
+// Comment is the resolver for the comment field.
+func (r *notificationResolver) Comment(ctx context.Context, obj *model.Notification) (*model.Comment, error) {
+	if obj.CommentID == nil {
+		return nil, nil
+	}
+	comment, err := r.loadersFor(ctx).comments.Load(ctx, *obj.CommentID)
+	if err != nil {
+		return nil, fromLoader(err, "comment not found")
+	}
+	return mapToComment(comment), nil
+}
+
+// PostCreated is the resolver for the postCreated field.
+func (r *subscriptionResolver) PostCreated(ctx context.Context, userID *string) (<-chan *model.Post, error) {
+	authorID := ""
+	if userID != nil {
+		authorID = localID(*userID, "User")
+	}
+
+	return forward(ctx, r.events.Subscribe(ctx, domain.EventPostCreated), func(post domain.Post) bool {
+		return authorID == "" || post.UserID == authorID
+	}, mapToPost), nil
+}
+
+// CommentAdded is the resolver for the commentAdded field.
+func (r *subscriptionResolver) CommentAdded(ctx context.Context, postID string) (<-chan *model.Comment, error) {
+	postID = localID(postID, "Post")
+
+	return forward(ctx, r.events.Subscribe(ctx, domain.EventCommentAdded), func(comment domain.Comment) bool {
+		return comment.PostID == postID
+	}, mapToComment), nil
+}
+
+// LikeCountChanged is the resolver for the likeCountChanged field.
+func (r *subscriptionResolver) LikeCountChanged(ctx context.Context, postID string) (<-chan *model.LikeCountChange, error) {
+	postID = localID(postID, "Post")
+
+	return forward(ctx, r.events.Subscribe(ctx, domain.EventLikeCountChanged), func(change domain.LikeCountChange) bool {
+		return change.PostID == postID
+	}, mapToLikeCountChange), nil
+}
+
+// NotificationReceived is the resolver for the notificationReceived field.
+func (r *subscriptionResolver) NotificationReceived(ctx context.Context) (<-chan *model.Notification, error) {
+	user, err := currentUser(ctx, r.authService)
+	if err != nil {
+		return nil, err
+	}
+
+	return forward(ctx, r.events.Subscribe(ctx, domain.EventNotification), func(notification domain.Notification) bool {
+		return notification.RecipientID == user.ID
+	}, mapToNotification), nil
+}
+
+// Notification returns graph.NotificationResolver implementation.
+func (r *Resolver) Notification() graph.NotificationResolver { return &notificationResolver{r} }
+
+// Subscription returns graph.SubscriptionResolver implementation.
+func (r *Resolver) Subscription() graph.SubscriptionResolver { return &subscriptionResolver{r} }
+
+type notificationResolver struct{ *Resolver }
+type subscriptionResolver struct{ *Resolver }
@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCheckOrigin(t *testing.T) {
+	tests := []struct {
+		allowOrigins string
+		origin       string
+		want         bool
+	}{
+		{"*", "", true},
+		{"*", "https://api.example.com", true},
+		{"*", "https://evil.example", false},
+		{"https://app.example.com, https://admin.example.com/", "https://admin.example.com", true},
+		{"https://app.example.com", "HTTPS://APP.EXAMPLE.COM", true},
+		{"https://app.example.com", "http://app.example.com", false},
+		{"https://app.example.com", "https://evil.example", false},
+		{"https://app.example.com", "null", false},
+	}
+	for _, test := range tests {
+		req := httptest.NewRequest("GET", "http://api.example.com/api/v1/graphql", nil)
+		if test.origin != "" {
+			req.Header.Set("Origin", test.origin)
+		}
+		if got := checkOrigin(test.allowOrigins)(req); got != test.want {
+			t.Errorf("checkOrigin(%q) for Origin %q = %v, want %v", test.allowOrigins, test.origin, got, test.want)
+		}
+	}
+}
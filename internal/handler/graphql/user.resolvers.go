@@ -0,0 +1,153 @@
+package handler
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.64
+
+import (
+	"context"
+	"io"
+	"log"
+	domain "raion-assessment/domain/entity"
+	"raion-assessment/domain/schema/graph"
+	"raion-assessment/domain/schema/graph/model"
+	"raion-assessment/pkg/request"
+	"raion-assessment/pkg/util"
+
+	"github.com/99designs/gqlgen/graphql"
+)
+
+// UpdateUser is the resolver for the updateUser field.
+func (r *mutationResolver) UpdateUser(ctx context.Context, username *string, bio *string, image *graphql.Upload, uploadID *string, mediaID *string) (*model.User, error) {
+	user, err := currentUser(ctx, r.authService)
+	if err != nil {
+		return nil, err
+	}
+
+	var newUsername, newBio, newUploadID, newMediaID string
+	if username != nil {
+		newUsername = *username
+	}
+	if bio != nil {
+		newBio = *bio
+	}
+	if uploadID != nil {
+		newUploadID = *uploadID
+	}
+	if mediaID != nil {
+		newMediaID = *mediaID
+	}
+
+	if err := validateInput(ctx, request.UpdateUserRequest{Username: newUsername, Bio: newBio, UploadID: newUploadID, MediaID: newMediaID}); err != nil {
+		return nil, err
+	}
+
+	var file io.Reader
+	if image != nil {
+		file = image.File
+	}
+	avatar, err := r.mediaService.UploadProfileImage(ctx, user.ID, file, newUploadID, newMediaID)
+	if err != nil {
+		log.Println("Error uploading profile image:", err)
+		return nil, err
+	}
+	imageURL, avatarID := user.ImageURL, ""
+	if avatar != nil {
+		imageURL, avatarID = avatar.URL(domain.RenditionFull), avatar.ID
+	}
+
+	updatedUser, err := r.userService.UpdateUser(user.ID, domain.User{
+		ID:        user.ID,
+		Name:      util.Coalesce(newUsername, user.Name),
+		Email:     user.Email,
+		Bio:       util.Coalesce(newBio, user.Bio),
+		ImageURL:  imageURL,
+		AvatarID:  avatarID,
+		CreatedAt: user.CreatedAt,
+	})
+	if err != nil {
+		log.Println("Error updating user:", err)
+		return nil, err
+	}
+
+	return mapToUser(updatedUser), nil
+}
+
+// GetAllUsers is the resolver for the getAllUsers field.
+func (r *queryResolver) GetAllUsers(ctx context.Context, first *int, after *string) (*model.UserConnection, error) {
+	page, err := pageRequestFromArgs(ctx, first, after)
+	if err != nil {
+		return nil, err
+	}
+
+	users, pageInfo, err := r.userService.FetchAllUsers(page)
+	if err != nil {
+		log.Println("Error fetching users:", err)
+		return nil, err
+	}
+
+	return newUserConnection(users, page, pageInfo), nil
+}
+
+// GetUserByID is the resolver for the getUserByID field.
+func (r *queryResolver) GetUserByID(ctx context.Context, id string) (*model.User, error) {
+	user, err := r.userService.FetchUserByID(localID(id, "User"))
+	if err != nil {
+		log.Println("User not found:", err)
+		return nil, err
+	}
+
+	return mapToUser(user), nil
+}
+
+// SearchUsers is the resolver for the searchUsers field.
+func (r *queryResolver) SearchUsers(ctx context.Context, query string, first *int, after *string) (*model.UserConnection, error) {
+	if err := validateInput(ctx, request.SearchRequest{Query: query}); err != nil {
+		return nil, err
+	}
+
+	page, err := pageRequestFromArgs(ctx, first, after)
+	if err != nil {
+		return nil, err
+	}
+
+	users, pageInfo, err := r.userService.SearchUsers(query, page)
+	if err != nil {
+		log.Println("Error searching users:", err)
+		return nil, err
+	}
+
+	return newUserConnection(users, page, pageInfo), nil
+}
+
+// Posts is the resolver for the posts field.
+func (r *userResolver) Posts(ctx context.Context, obj *model.User, first *int, after *string) (*model.PostConnection, error) {
+	page, err := pageRequestFromArgs(ctx, first, after)
+	if err != nil {
+		return nil, err
+	}
+
+	var posts []domain.Post
+	var pageInfo domain.PageInfo
+	if page.After == nil {
+		result, err := r.loadersFor(ctx).postsByUser.Load(ctx, pageKey{ParentID: obj.DatabaseID, Limit: page.Limit})
+		if err != nil {
+			log.Println("Error fetching posts:", err)
+			return nil, err
+		}
+		posts, pageInfo = result.Items, result.PageInfo
+	} else {
+		posts, pageInfo, err = r.postService.FetchPostsByUserID(obj.DatabaseID, r.viewerID(ctx), page)
+		if err != nil {
+			log.Println("Error fetching posts:", err)
+			return nil, err
+		}
+	}
+
+	return newPostConnection(posts, page, pageInfo), nil
+}
+
+// User returns graph.UserResolver implementation.
+func (r *Resolver) User() graph.UserResolver { return &userResolver{r} }
+
+type userResolver struct{ *Resolver }
@@ -31,16 +31,17 @@ func NewAuthHandler(authService contract.IAuthService) *AuthHandler {
 // @Param request body request.UserRegistrationRequest true "User registration details"
 // @Success 201 {object} response.RegisterResponse "Successful registration response"
 // @Failure 400 {object} response.ErrorResponse "Bad request"
+// @Failure 409 {object} response.ErrorResponse "Email already registered"
 // @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /auth/register [post]
 func (h *AuthHandler) Register(c *fiber.Ctx) error {
 	var req request.UserRegistrationRequest
-	if err := response.ParseAndValidateRequest(c, &req); err != nil {
+	if err := request.Bind(c, &req); err != nil {
 		return err
 	}
 	if err := h.authService.Register(req.Username, req.Email, req.Password); err != nil {
 		log.Printf("Registration failed: %v", err)
-		return response.Error(c, err.Error())
+		return err
 	}
 	return response.Success(c.Status(fiber.StatusCreated), response.RegisterData{Message: "User registered successfully"})
 }
@@ -57,13 +58,13 @@ func (h *AuthHandler) Register(c *fiber.Ctx) error {
 // @Router /auth/login [post]
 func (h *AuthHandler) Login(c *fiber.Ctx) error {
 	var req request.UserLoginRequest
-	if err := response.ParseAndValidateRequest(c, &req); err != nil {
+	if err := request.Bind(c, &req); err != nil {
 		return err
 	}
 	accessToken, refreshToken, err := h.authService.Login(req.Email, req.Password)
 	if err != nil {
 		log.Printf("Login failed: %v", err)
-		return response.Error(c.Status(fiber.StatusUnauthorized), err.Error())
+		return err
 	}
 	return response.Success(c, response.LoginData{
 		AccessToken:  "Bearer " + accessToken,
@@ -83,13 +84,13 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 // @Router /auth/refresh-token [post]
 func (h *AuthHandler) RefreshToken(c *fiber.Ctx) error {
 	var req request.RefreshTokenRequest
-	if err := response.ParseAndValidateRequest(c, &req); err != nil {
+	if err := request.Bind(c, &req); err != nil {
 		return err
 	}
 	newAccessToken, err := h.authService.RefreshToken(req.RefreshToken)
 	if err != nil {
 		log.Printf("Token refresh failed: %v", err)
-		return response.Error(c.Status(fiber.StatusUnauthorized), err.Error())
+		return err
 	}
 	return response.Success(c, response.RefreshTokenData{AccessToken: "Bearer " + newAccessToken})
 }
@@ -106,12 +107,12 @@ func (h *AuthHandler) RefreshToken(c *fiber.Ctx) error {
 func (h *AuthHandler) GetUserInfo(c *fiber.Ctx) error {
 	token, err := util.GetToken(c)
 	if err != nil {
-		return response.Error(c.Status(fiber.StatusUnauthorized), err.Error())
+		return err
 	}
 	user, err := h.authService.GetCurrentUser(c.Context(), token)
 	if err != nil {
 		log.Printf("Error fetching user info: %v", err)
-		return response.Error(c.Status(fiber.StatusUnauthorized), err.Error())
+		return err
 	}
 	return response.Success(c, response.User{
 		ID:        user.ID,
@@ -136,20 +137,20 @@ func (h *AuthHandler) GetUserInfo(c *fiber.Ctx) error {
 func (h *AuthHandler) ChangePassword(c *fiber.Ctx) error {
 	token, err := util.GetToken(c)
 	if err != nil {
-		return response.Error(c.Status(fiber.StatusUnauthorized), err.Error())
+		return err
 	}
 	user, err := h.authService.GetCurrentUser(c.Context(), token)
 	if err != nil {
 		log.Printf("Error fetching user info for password change: %v", err)
-		return response.Error(c.Status(fiber.StatusUnauthorized), "Unauthorized: "+err.Error())
+		return err
 	}
 	var req request.ChangePasswordRequest
-	if err := response.ParseAndValidateRequest(c, &req); err != nil {
+	if err := request.Bind(c, &req); err != nil {
 		return err
 	}
 	if err := h.authService.ChangePassword(user.ID, req.OldPassword, req.NewPassword); err != nil {
 		log.Printf("Password change failed: %v", err)
-		return response.Error(c, err.Error())
+		return err
 	}
 	return response.Success(c, response.ChangePasswordData{Message: "Password changed successfully"})
 }
\ No newline at end of file
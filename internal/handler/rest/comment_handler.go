@@ -24,23 +24,40 @@ func NewCommentHandler(commentService contract.ICommentService, authService cont
 
 // GetCommentsByPostID godoc
 // @Summary Get comments for a post
-// @Description Retrieve all comments related to a specific post.
+// @Description Retrieve all comments related to a specific post, ordered by newest or by like count. When called with a bearer token, each comment reports whether the caller liked it.
 // @Tags comments
 // @Produce json
 // @Param post_id path string true "Post ID"
+// @Param sort query string false "Sort order" Enums(newest, top) default(newest)
+// @Param limit query int false "Page size (max 100)" default(20)
+// @Param cursor query string false "Opaque cursor returned as next_cursor by the previous page"
 // @Success 200 {array} response.GetCommentsResponse "List of comments"
 // @Failure 400 {object} response.ErrorResponse "Bad request"
+// @Failure 404 {object} response.ErrorResponse "Post not found"
 // @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /posts/{post_id}/comments [get]
 func (h *CommentHandler) GetCommentsByPostID(c *fiber.Ctx) error {
-	postID := c.Params("post_id")
+	input := request.ListCommentsRequest{Sort: entity.CommentSortNewest}
+	if err := request.Bind(c, &input); err != nil {
+		return err
+	}
 
-	comments, err := h.commentService.GetCommentsByPostID(postID)
+	var viewerID string
+	if viewer := util.GetOptionalUserFromToken(c, h.authService); viewer != nil {
+		viewerID = viewer.ID
+	}
+
+	page, err := util.ParsePageRequest(c)
 	if err != nil {
-		return response.Error(c, "No comments found", fiber.StatusNotFound)
+		return err
+	}
+
+	comments, pageInfo, err := h.commentService.GetCommentsByPostID(input.PostID, viewerID, input.Sort, page)
+	if err != nil {
+		return err
 	}
 
-	return response.Success(c, comments, fiber.StatusOK)
+	return response.Paginated(c, comments, util.EncodeCursor(pageInfo.Next), pageInfo.HasMore)
 }
 
 // CreateComment godoc
@@ -63,23 +80,19 @@ func (h *CommentHandler) CreateComment(c *fiber.Ctx) error {
 	}
 
 	var input request.CreateCommentRequest
-	if err := c.BodyParser(&input); err != nil {
-		return response.HandleValidationError(c, "Invalid input")
-	}
-
-	if input.Content == "" {
-		return response.HandleValidationError(c, "Comment content cannot be empty")
+	if err := request.Bind(c, &input); err != nil {
+		return err
 	}
 
 	comment := entity.Comment{
-		PostID:  c.Params("post_id"),
+		PostID:  input.PostID,
 		UserID:  user.ID,
 		Content: input.Content,
 	}
 
 	createdComment, err := h.commentService.CreateComment(comment)
 	if err != nil {
-		return response.Error(c, "Failed to create comment", fiber.StatusInternalServerError)
+		return err
 	}
 
 	return response.Success(c, createdComment, fiber.StatusCreated)
@@ -93,6 +106,7 @@ func (h *CommentHandler) CreateComment(c *fiber.Ctx) error {
 // @Security BearerAuth
 // @Success 204 {object} response.DeleteCommentResponse "Successful deletion response"
 // @Failure 400 {object} response.ErrorResponse "Bad request"
+// @Failure 403 {object} response.ErrorResponse "Not the comment's author"
 // @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /comments/{id} [delete]
 func (h *CommentHandler) DeleteComment(c *fiber.Ctx) error {
@@ -101,18 +115,22 @@ func (h *CommentHandler) DeleteComment(c *fiber.Ctx) error {
 		return err
 	}
 
-	id := c.Params("id")
-	comment, err := h.commentService.GetCommentByID(id)
+	var params request.CommentParams
+	if err := request.Bind(c, &params); err != nil {
+		return err
+	}
+
+	comment, err := h.commentService.GetCommentByID(params.ID)
 	if err != nil {
-		return response.Error(c, "Comment not found", fiber.StatusNotFound)
+		return err
 	}
 
 	if comment.UserID != user.ID {
-		return response.Error(c, "Unauthorized to delete this comment", fiber.StatusUnauthorized)
+		return entity.Forbidden("You are not allowed to delete this comment")
 	}
 
-	if err := h.commentService.DeleteComment(id); err != nil {
-		return response.Error(c, "Failed to delete comment", fiber.StatusInternalServerError)
+	if err := h.commentService.DeleteComment(params.ID); err != nil {
+		return err
 	}
 
 	return response.Success(c, fiber.Map{"message": "Comment deleted successfully"}, fiber.StatusNoContent)
@@ -2,6 +2,7 @@ package handler
 
 import (
 	contract "raion-assessment/domain/contract"
+	"raion-assessment/pkg/request"
 	"raion-assessment/pkg/response"
 	"raion-assessment/pkg/util"
 
@@ -28,6 +29,7 @@ func NewLikeHandler(likeService contract.ILikeService, authService contract.IAut
 // @Security BearerAuth
 // @Success 200 {object} response.LikeResponse "Successfully liked post"
 // @Failure 400 {object} response.ErrorResponse "Bad request"
+// @Failure 409 {object} response.ErrorResponse "Post already liked"
 // @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /posts/{post_id}/like [post]
 func (h *LikeHandler) LikePost(c *fiber.Ctx) error {
@@ -36,14 +38,14 @@ func (h *LikeHandler) LikePost(c *fiber.Ctx) error {
 		return err
 	}
 
-	postID := c.Params("post_id")
-	if postID == "" {
-		return response.ValidationError(c, "Post ID is required")
+	var params request.PostIDParams
+	if err := request.Bind(c, &params); err != nil {
+		return err
 	}
 
-	like, err := h.likeService.AddLike(user.ID, postID)
+	like, err := h.likeService.AddLike(user.ID, params.PostID)
 	if err != nil {
-		return response.Error(c, err.Error(), fiber.StatusInternalServerError)
+		return err
 	}
 
 	return response.Success(c, like, fiber.StatusOK)
@@ -65,14 +67,14 @@ func (h *LikeHandler) UnlikePost(c *fiber.Ctx) error {
 		return err
 	}
 
-	postID := c.Params("post_id")
-	if postID == "" {
-		return response.ValidationError(c, "Post ID is required")
+	var params request.PostIDParams
+	if err := request.Bind(c, &params); err != nil {
+		return err
 	}
 
-	err = h.likeService.RemoveLike(user.ID, postID)
+	err = h.likeService.RemoveLike(user.ID, params.PostID)
 	if err != nil {
-		return response.Error(c, err.Error(), fiber.StatusInternalServerError)
+		return err
 	}
 
 	return response.Success(c, fiber.Map{"message": "Post unliked successfully"}, fiber.StatusOK)
@@ -84,22 +86,30 @@ func (h *LikeHandler) UnlikePost(c *fiber.Ctx) error {
 // @Tags likes
 // @Produce json
 // @Param post_id path string true "Post ID"
+// @Param limit query int false "Page size (max 100)" default(20)
+// @Param cursor query string false "Opaque cursor returned as next_cursor by the previous page"
 // @Success 200 {array} response.GetAllLikesResponse "List of users who liked the post"
 // @Failure 400 {object} response.ErrorResponse "Bad request"
+// @Failure 404 {object} response.ErrorResponse "Post not found"
 // @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /posts/{post_id}/likes [get]
 func (h *LikeHandler) GetLikesByPostID(c *fiber.Ctx) error {
-	postID := c.Params("post_id")
-	if postID == "" {
-		return response.ValidationError(c, "Post ID is required")
+	var params request.PostIDParams
+	if err := request.Bind(c, &params); err != nil {
+		return err
 	}
 
-	likes, err := h.likeService.GetLikesByPostID(postID)
+	page, err := util.ParsePageRequest(c)
 	if err != nil {
-		return response.Error(c, err.Error(), fiber.StatusInternalServerError)
+		return err
+	}
+
+	likes, pageInfo, err := h.likeService.GetLikesByPostID(params.PostID, page)
+	if err != nil {
+		return err
 	}
 
-	return response.Success(c, likes, fiber.StatusOK)
+	return response.Paginated(c, likes, util.EncodeCursor(pageInfo.Next), pageInfo.HasMore)
 }
 
 // GetLikesByUserID godoc
@@ -108,20 +118,124 @@ func (h *LikeHandler) GetLikesByPostID(c *fiber.Ctx) error {
 // @Tags likes
 // @Produce json
 // @Param user_id path string true "User ID"
+// @Param limit query int false "Page size (max 100)" default(20)
+// @Param cursor query string false "Opaque cursor returned as next_cursor by the previous page"
 // @Success 200 {array} response.GetAllLikesResponse "List of posts liked by the user"
 // @Failure 400 {object} response.ErrorResponse "Bad request"
+// @Failure 404 {object} response.ErrorResponse "User not found"
 // @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /users/{user_id}/likes [get]
 func (h *LikeHandler) GetLikesByUserID(c *fiber.Ctx) error {
-	userID := c.Params("user_id")
-	if userID == "" {
-		return response.ValidationError(c, "User ID is required")
+	var params request.UserIDParams
+	if err := request.Bind(c, &params); err != nil {
+		return err
+	}
+
+	page, err := util.ParsePageRequest(c)
+	if err != nil {
+		return err
+	}
+
+	likes, pageInfo, err := h.likeService.GetLikesByUserID(params.UserID, page)
+	if err != nil {
+		return err
+	}
+
+	return response.Paginated(c, likes, util.EncodeCursor(pageInfo.Next), pageInfo.HasMore)
+}
+
+// LikeComment godoc
+// @Summary Like a comment
+// @Description Allows a user to like a comment. Requires JWT authentication.
+// @Tags likes
+// @Param post_id path string true "Post ID"
+// @Param comment_id path string true "Comment ID"
+// @Security BearerAuth
+// @Success 200 {object} response.LikeResponse "Successfully liked comment"
+// @Failure 400 {object} response.ErrorResponse "Bad request"
+// @Failure 404 {object} response.ErrorResponse "Comment not found on this post"
+// @Failure 409 {object} response.ErrorResponse "Comment already liked"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /posts/{post_id}/comments/{comment_id}/like [post]
+func (h *LikeHandler) LikeComment(c *fiber.Ctx) error {
+	user, err := util.GetUserFromToken(c, h.authService)
+	if err != nil {
+		return err
+	}
+
+	var params request.CommentIDParams
+	if err := request.Bind(c, &params); err != nil {
+		return err
+	}
+
+	like, err := h.likeService.AddCommentLike(user.ID, params.PostID, params.CommentID)
+	if err != nil {
+		return err
 	}
 
-	likes, err := h.likeService.GetLikesByUserID(userID)
+	return response.Success(c, like, fiber.StatusOK)
+}
+
+// UnlikeComment godoc
+// @Summary Unlike a comment
+// @Description Allows a user to remove their like from a comment. Requires JWT authentication.
+// @Tags likes
+// @Param post_id path string true "Post ID"
+// @Param comment_id path string true "Comment ID"
+// @Security BearerAuth
+// @Success 200 {object} response.LikeResponse "Successfully unliked comment"
+// @Failure 400 {object} response.ErrorResponse "Bad request"
+// @Failure 404 {object} response.ErrorResponse "Comment not found on this post"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /posts/{post_id}/comments/{comment_id}/unlike [post]
+func (h *LikeHandler) UnlikeComment(c *fiber.Ctx) error {
+	user, err := util.GetUserFromToken(c, h.authService)
 	if err != nil {
-		return response.Error(c, err.Error(), fiber.StatusInternalServerError)
+		return err
 	}
 
-	return response.Success(c, likes, fiber.StatusOK)
-}
\ No newline at end of file
+	var params request.CommentIDParams
+	if err := request.Bind(c, &params); err != nil {
+		return err
+	}
+
+	err = h.likeService.RemoveCommentLike(user.ID, params.PostID, params.CommentID)
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, fiber.Map{"message": "Comment unliked successfully"}, fiber.StatusOK)
+}
+
+// GetLikesByCommentID godoc
+// @Summary Get all likes for a comment
+// @Description Fetch all users who liked a specific comment
+// @Tags likes
+// @Produce json
+// @Param post_id path string true "Post ID"
+// @Param comment_id path string true "Comment ID"
+// @Param limit query int false "Page size (max 100)" default(20)
+// @Param cursor query string false "Opaque cursor returned as next_cursor by the previous page"
+// @Success 200 {array} response.GetAllCommentLikesResponse "List of users who liked the comment"
+// @Failure 400 {object} response.ErrorResponse "Bad request"
+// @Failure 404 {object} response.ErrorResponse "Comment not found on this post"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /posts/{post_id}/comments/{comment_id}/likes [get]
+func (h *LikeHandler) GetLikesByCommentID(c *fiber.Ctx) error {
+	var params request.CommentIDParams
+	if err := request.Bind(c, &params); err != nil {
+		return err
+	}
+
+	page, err := util.ParsePageRequest(c)
+	if err != nil {
+		return err
+	}
+
+	likes, pageInfo, err := h.likeService.GetLikesByCommentID(params.PostID, params.CommentID, page)
+	if err != nil {
+		return err
+	}
+
+	return response.Paginated(c, likes, util.EncodeCursor(pageInfo.Next), pageInfo.HasMore)
+}
@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	contract "raion-assessment/domain/contract"
+	entity "raion-assessment/domain/entity"
+	"raion-assessment/pkg/response"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// The list endpoints below share one contract: an existing parent with no
+// children is an empty page rendered as [], and an unknown parent is a 404.
+// The repositories tell the two apart; these fakes answer the way they do.
+const (
+	existingParent = "3f0c2b1a-8d5e-4c7b-9a61-0e2d4f6b8a10"
+	unknownParent  = "9b7e5d3c-1a2f-4e6d-8c0b-7f5a3e1d9c24"
+)
+
+func emptyChildren[T any](parentID, resource string) ([]T, entity.PageInfo, error) {
+	if parentID != existingParent {
+		return nil, entity.PageInfo{}, entity.NotFound(resource + " not found")
+	}
+	return []T{}, entity.PageInfo{}, nil
+}
+
+type emptyCommentService struct {
+	contract.ICommentService
+}
+
+func (emptyCommentService) GetCommentsByPostID(postID, viewerID, sort string, page entity.PageRequest) ([]entity.Comment, entity.PageInfo, error) {
+	return emptyChildren[entity.Comment](postID, "post")
+}
+
+type emptyLikeService struct {
+	contract.ILikeService
+}
+
+func (emptyLikeService) GetLikesByPostID(postID string, page entity.PageRequest) ([]entity.Like, entity.PageInfo, error) {
+	return emptyChildren[entity.Like](postID, "post")
+}
+
+func (emptyLikeService) GetLikesByUserID(userID string, page entity.PageRequest) ([]entity.Like, entity.PageInfo, error) {
+	return emptyChildren[entity.Like](userID, "user")
+}
+
+func (emptyLikeService) GetLikesByCommentID(postID, commentID string, page entity.PageRequest) ([]entity.CommentLike, entity.PageInfo, error) {
+	return emptyChildren[entity.CommentLike](commentID, "comment")
+}
+
+type emptyPostService struct {
+	contract.IPostService
+}
+
+func (emptyPostService) FetchPostsByUserID(userID, viewerID string, page entity.PageRequest) ([]entity.Post, entity.PageInfo, error) {
+	return emptyChildren[entity.Post](userID, "user")
+}
+
+func TestListEndpointsDistinguishEmptyFromMissing(t *testing.T) {
+	commentHandler := NewCommentHandler(emptyCommentService{}, fakeAuth{})
+	likeHandler := NewLikeHandler(emptyLikeService{}, fakeAuth{})
+	postHandler := NewPostHandler(emptyPostService{}, fakeAuth{}, nil)
+
+	app := fiber.New(fiber.Config{ErrorHandler: response.ErrorHandler})
+	app.Get("/api/v1/posts/:post_id/comments", commentHandler.GetCommentsByPostID)
+	app.Get("/api/v1/posts/:post_id/likes", likeHandler.GetLikesByPostID)
+	app.Get("/api/v1/posts/:post_id/comments/:comment_id/likes", likeHandler.GetLikesByCommentID)
+	app.Get("/api/v1/users/:user_id/likes", likeHandler.GetLikesByUserID)
+	app.Get("/api/v1/posts/user/:user_id", postHandler.GetPostsByUserID)
+
+	endpoints := []struct {
+		name string
+		path func(parentID string) string
+	}{
+		{"comments of a post", func(id string) string { return "/api/v1/posts/" + id + "/comments" }},
+		{"likes of a post", func(id string) string { return "/api/v1/posts/" + id + "/likes" }},
+		{"likes of a comment", func(id string) string { return "/api/v1/posts/" + existingParent + "/comments/" + id + "/likes" }},
+		{"likes by a user", func(id string) string { return "/api/v1/users/" + id + "/likes" }},
+		{"posts by a user", func(id string) string { return "/api/v1/posts/user/" + id }},
+	}
+	for _, endpoint := range endpoints {
+		t.Run(endpoint.name, func(t *testing.T) {
+			status, data := getList(t, app, endpoint.path(existingParent))
+			if status != fiber.StatusOK || data != "[]" {
+				t.Errorf("existing parent without children: got %d with data %s, want 200 with []", status, data)
+			}
+			if status, _ := getList(t, app, endpoint.path(unknownParent)); status != fiber.StatusNotFound {
+				t.Errorf("unknown parent: got %d, want 404", status)
+			}
+		})
+	}
+}
+
+// getList fetches a list endpoint and returns the status and the raw JSON of
+// the data field.
+func getList(t *testing.T, app *fiber.App, target string) (int, string) {
+	t.Helper()
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, target, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var envelope struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if resp.StatusCode == fiber.StatusOK {
+		if err := json.Unmarshal(body, &envelope); err != nil {
+			t.Fatalf("decoding %s: %v", body, err)
+		}
+	}
+	return resp.StatusCode, string(envelope.Data)
+}
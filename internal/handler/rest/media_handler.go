@@ -0,0 +1,119 @@
+package handler
+
+import (
+	contract "raion-assessment/domain/contract"
+	"raion-assessment/pkg/request"
+	"raion-assessment/pkg/response"
+	"raion-assessment/pkg/util"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type MediaHandler struct {
+	uploadService contract.IUploadService
+	mediaService  contract.IMediaService
+	authService   contract.IAuthService
+}
+
+func NewMediaHandler(uploadService contract.IUploadService, mediaService contract.IMediaService, authService contract.IAuthService) *MediaHandler {
+	return &MediaHandler{
+		uploadService: uploadService,
+		mediaService:  mediaService,
+		authService:   authService,
+	}
+}
+
+// CreateDirectUpload godoc
+// @Summary Start a direct upload
+// @Description Get a short-lived URL to send a file straight to storage instead of through the API. Declare what the file is for ("posts" accepts images and videos, "profile" only images), its content type and its exact size in bytes; the same limits apply as to files sent to the API. Send the file within 15 minutes as a single request with the returned method, URL and headers, then complete the upload to turn it into media. Requires JWT authentication.
+// @Tags media
+// @Accept json
+// @Produce json
+// @Param request body request.CreateDirectUploadRequest true "The file about to be uploaded"
+// @Security BearerAuth
+// @Success 201 {object} response.CreateDirectUploadResponse "Where and how to send the file"
+// @Failure 400 {object} response.ErrorResponse "Bad request"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized or invalid token"
+// @Failure 507 {object} response.ErrorResponse "Storage quota exceeded"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /media/uploads [post]
+func (h *MediaHandler) CreateDirectUpload(c *fiber.Ctx) error {
+	user, err := util.GetUserFromToken(c, h.authService)
+	if err != nil {
+		return err
+	}
+
+	var input request.CreateDirectUploadRequest
+	if err := request.Bind(c, &input); err != nil {
+		return err
+	}
+	contentType, err := h.mediaService.CheckDirectUpload(request.WithLocale(c.UserContext(), request.Locale(c)), input.Purpose, input.ContentType, input.Size)
+	if err != nil {
+		return err
+	}
+
+	upload, err := h.uploadService.PresignUpload(user.ID, input.Purpose, contentType, input.Size)
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, util.MapToDirectUploadResponse(upload), fiber.StatusCreated)
+}
+
+// GetStorageUsage godoc
+// @Summary Get storage usage
+// @Description Get how much of their storage quota the current user has taken up, in bytes: the media they own and the declared size of resumable and direct uploads they have started. Uploads that would not fit in what remains fail with 507 and the code QUOTA_EXCEEDED. Media no post or profile uses any more counts until it is cleaned up. Requires JWT authentication.
+// @Tags media
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.GetStorageUsageResponse "Storage usage and quota"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized or invalid token"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /media/usage [get]
+func (h *MediaHandler) GetStorageUsage(c *fiber.Ctx) error {
+	user, err := util.GetUserFromToken(c, h.authService)
+	if err != nil {
+		return err
+	}
+
+	usage, err := h.mediaService.FetchStorageUsage(user.ID)
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, util.MapToStorageUsageResponse(usage), fiber.StatusOK)
+}
+
+// CompleteDirectUpload godoc
+// @Summary Complete a direct upload
+// @Description Check the file sent to a direct upload's URL and turn it into media. The file must have the declared content type and size. Attach the returned media to a new post with media_id, or use it as the profile image with media_id when updating the user. Videos are transcoded in the background and start out "pending". Requires JWT authentication.
+// @Tags media
+// @Produce json
+// @Param id path string true "Direct upload ID"
+// @Security BearerAuth
+// @Success 201 {object} response.CompleteDirectUploadResponse "The new media"
+// @Failure 400 {object} response.ErrorResponse "Bad request, or the file is missing or not what was declared"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized or invalid token"
+// @Failure 404 {object} response.ErrorResponse "Direct upload not found or expired"
+// @Failure 507 {object} response.ErrorResponse "Storage quota exceeded"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /media/uploads/{id}/complete [post]
+func (h *MediaHandler) CompleteDirectUpload(c *fiber.Ctx) error {
+	user, err := util.GetUserFromToken(c, h.authService)
+	if err != nil {
+		return err
+	}
+
+	var params request.UploadParams
+	if err := request.Bind(c, &params); err != nil {
+		return err
+	}
+
+	ctx := request.WithLocale(c.UserContext(), request.Locale(c))
+	media, err := h.mediaService.CompleteDirectUpload(ctx, user.ID, params.ID)
+	if err != nil {
+		return err
+	}
+
+	return response.Success(c, util.MapToMediaResponse(&media), fiber.StatusCreated)
+}
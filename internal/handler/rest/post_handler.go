@@ -11,32 +11,41 @@ import (
 )
 
 type PostHandler struct {
-	postService contract.IPostService
-	authService contract.IAuthService
+	postService  contract.IPostService
+	authService  contract.IAuthService
+	mediaService contract.IMediaService
 }
 
-func NewPostHandler(postService contract.IPostService, authService contract.IAuthService) *PostHandler {
+func NewPostHandler(postService contract.IPostService, authService contract.IAuthService, mediaService contract.IMediaService) *PostHandler {
 	return &PostHandler{
         postService: postService,
         authService: authService,
+        mediaService: mediaService,
     }
 }
 
 // GetAllPosts godoc
 // @Summary Get all posts
-// @Description Get a list of all posts, along with details like the user who created them, the caption, image URL, and timestamps.
+// @Description Get a list of all posts, along with details like the user who created them, the caption, image URL, and timestamps. When called with a bearer token, each post reports whether the caller liked it.
 // @Tags posts
 // @Produce json
+// @Param limit query int false "Page size (max 100)" default(20)
+// @Param cursor query string false "Opaque cursor returned as next_cursor by the previous page"
 // @Success 200 {object} response.GetAllPostsResponse "Successful fetch posts response"
 // @Failure 400 {object} response.ErrorResponse "Bad request"
 // @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /posts [get]
 func (h *PostHandler) GetAllPosts(c *fiber.Ctx) error {
-	posts, err := h.postService.FetchAllPosts()
+	page, err := util.ParsePageRequest(c)
 	if err != nil {
-		return response.Error(c, err.Error(), fiber.StatusInternalServerError)
+		return err
 	}
-	return response.Success(c, util.MapToPostResponse(posts), fiber.StatusOK)
+
+	posts, pageInfo, err := h.postService.FetchAllPosts(h.viewerID(c), page)
+	if err != nil {
+		return err
+	}
+	return response.Paginated(c, util.MapToPostResponse(posts), util.EncodeCursor(pageInfo.Next), pageInfo.HasMore)
 }
 
 // GetPostByID godoc
@@ -47,13 +56,18 @@ func (h *PostHandler) GetAllPosts(c *fiber.Ctx) error {
 // @Param id path string true "Post ID"
 // @Success 200 {object} response.GetPostByIDResponse "Successful fetch post response" 
 // @Failure 400 {object} response.ErrorResponse "Bad request"
+// @Failure 404 {object} response.ErrorResponse "Post not found"
 // @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /posts/{id} [get]
 func (h *PostHandler) GetPostByID(c *fiber.Ctx) error {
-	id := c.Params("id")
-	post, err := h.postService.FetchPostByID(id)
+	var params request.PostParams
+	if err := request.Bind(c, &params); err != nil {
+		return err
+	}
+
+	post, err := h.postService.FetchPostByID(params.ID, h.viewerID(c))
 	if err != nil {
-		return response.Error(c, "Post not found", fiber.StatusNotFound)
+		return err
 	}
 
 	postResponse := util.MapToPostResponse([]entity.Post{post})[0]
@@ -66,30 +80,48 @@ func (h *PostHandler) GetPostByID(c *fiber.Ctx) error {
 // @Tags posts
 // @Produce json
 // @Param user_id path string true "User ID"
+// @Param limit query int false "Page size (max 100)" default(20)
+// @Param cursor query string false "Opaque cursor returned as next_cursor by the previous page"
 // @Success 200 {object} response.GetAllPostsResponse "Successful fetch posts by user response"
 // @Failure 400 {object} response.ErrorResponse "Bad request"
+// @Failure 404 {object} response.ErrorResponse "User not found"
 // @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /posts/user/{user_id} [get]
 func (h *PostHandler) GetPostsByUserID(c *fiber.Ctx) error {
-	userID := c.Params("user_id")
-	posts, err := h.postService.FetchPostsByUserID(userID)
+	var params request.UserIDParams
+	if err := request.Bind(c, &params); err != nil {
+		return err
+	}
+
+	page, err := util.ParsePageRequest(c)
 	if err != nil {
-		return response.Error(c, "Failed to fetch posts", fiber.StatusInternalServerError)
+		return err
 	}
-	return response.Success(c, util.MapToPostResponse(posts), fiber.StatusOK)
+
+	posts, pageInfo, err := h.postService.FetchPostsByUserID(params.UserID, h.viewerID(c), page)
+	if err != nil {
+		return err
+	}
+	return response.Paginated(c, util.MapToPostResponse(posts), util.EncodeCursor(pageInfo.Next), pageInfo.HasMore)
 }
 
 // CreatePost godoc
 // @Summary Create a new post
-// @Description Create a new post with a caption. Optionally, you can upload an image. If an image is uploaded, its URL will be returned in the response. Requires JWT authentication.
+// @Description Create a new post with a caption and up to 10 images or videos, sent as repeated "media" files in display order; repeated "images" files and a single "image" file are still accepted. Each image must be a JPEG, PNG or WebP of at most 8 MB or a GIF of at most 4 MB, and at most 10000 pixels on each side. Each video must be an MP4, MOV or WebM of at most 32 MB. Videos and animated GIFs may play for at most 60 seconds and are transcoded in the background: the post's status stays "pending" until they are ready, or becomes "failed". The whole request may be at most 40 MB; larger files can be sent beforehand as resumable uploads and attached with repeated "upload_id" fields, after any files, or sent straight to storage as direct uploads and attached with repeated "media_id" fields, after any uploads. Repeated "alt_text" fields describe the files, uploads and media in the same order. image_url in the response is the first image, or the poster frame of a video once it is ready. Requires JWT authentication.
 // @Tags posts
 // @Accept multipart/form-data
 // @Produce json
 // @Param caption formData string true "Post caption"
-// @Param image formData file false "Post image (optional)"
+// @Param media formData file false "Post images and videos, in display order"
+// @Param images formData file false "Post images, in display order, for older clients"
+// @Param alt_text formData []string false "Alt text for each image, in the same order" collectionFormat(multi)
+// @Param image formData file false "Single post image, for older clients"
+// @Param upload_id formData []string false "IDs of finished resumable uploads, in display order after any files" collectionFormat(multi)
+// @Param media_id formData []string false "IDs of media from completed direct uploads, in display order after any uploads" collectionFormat(multi)
 // @Security BearerAuth
 // @Success 201 {object} response.CreatePostResponse "Successful image upload response"
 // @Failure 400 {object} response.ErrorResponse "Bad request"
+// @Failure 507 {object} response.ErrorResponse "Storage quota exceeded"
 // @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /posts [post]
 func (h *PostHandler) CreatePost(c *fiber.Ctx) error {
@@ -98,25 +130,33 @@ func (h *PostHandler) CreatePost(c *fiber.Ctx) error {
 		return err
 	}
 
-	caption := c.FormValue("caption")
-	if caption == "" {
-		return response.ValidationError(c, "Caption is required")
+	var input request.CreatePostRequest
+	if err := request.Bind(c, &input); err != nil {
+		return err
+	}
+
+	files, err := util.OpenPostMediaFiles(c)
+	if err != nil {
+		return err
 	}
+	defer util.CloseFiles(files)
 
-	imageURL, err := util.UploadPostImage(c, user.ID, "./uploads/posts/")
+	ctx := request.WithLocale(c.UserContext(), request.Locale(c))
+	media, err := h.mediaService.UploadPostMedia(ctx, user.ID, util.Readers(files), input.UploadIDs, input.MediaIDs, input.AltText)
 	if err != nil {
-		return response.Error(c, "Failed to upload image", fiber.StatusInternalServerError)
+		return err
 	}
 
 	post := entity.Post{
 		UserID:   user.ID,
-		Caption:  caption,
-		ImageURL: imageURL, 
+		Caption:  input.Caption,
+		ImageURL: media[0].Media.URL(entity.RenditionFull),
+		Media:    media,
 	}
 
 	createdPost, err := h.postService.CreatePost(post)
 	if err != nil {
-		return response.Error(c, err.Error(), fiber.StatusInternalServerError)
+		return err
 	}
 
 	postResponse := util.MapToPostResponse([]entity.Post{createdPost})[0]
@@ -124,16 +164,17 @@ func (h *PostHandler) CreatePost(c *fiber.Ctx) error {
 }
 
 // UpdatePost godoc
-// @Summary Update an existing post's caption
-// @Description Update only the caption of an existing post. Only the post creator is allowed to make this change. Requires JWT authentication.
+// @Summary Update an existing post's caption or images
+// @Description Update the caption of an existing post, rearrange its images, or both, in a single change. An empty caption clears it; a caption left out is kept. "media" lists the images to keep by media_id in their new order, each with its alt text; images left out are removed, at least one must remain, and new images cannot be added. Only the post creator is allowed to make this change. Requires JWT authentication.
 // @Tags posts
 // @Accept json
 // @Produce json
 // @Param id path string true "Post ID"
-// @Param request body request.UpdatePostRequest true "Request body with updated caption"
+// @Param request body request.UpdatePostRequest true "Request body with updated caption and/or images"
 // @Security BearerAuth
 // @Success 200 {object} response.UpdatePostResponse "Successful update response"
 // @Failure 400 {object} response.ErrorResponse "Bad request"
+// @Failure 403 {object} response.ErrorResponse "Not the post's author"
 // @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /posts/{id} [patch]
 func (h *PostHandler) UpdatePost(c *fiber.Ctx) error {
@@ -142,29 +183,35 @@ func (h *PostHandler) UpdatePost(c *fiber.Ctx) error {
 		return err
 	}
 
-	id := c.Params("id")
-	existingPost, err := h.postService.FetchPostByID(id)
-	if err != nil {
-		return response.Error(c, "Post not found", fiber.StatusNotFound)
+	var input request.UpdatePostRequest
+	if err := request.Bind(c, &input); err != nil {
+		return err
 	}
 
-	if existingPost.UserID != user.ID {
-		return response.Error(c, "Unauthorized to update this post", fiber.StatusUnauthorized)
+	if input.Caption == nil && input.Media == nil {
+		return request.Invalid(request.Locale(c), "caption", "required")
 	}
 
-	var input request.UpdatePostRequest
-	if err := c.BodyParser(&input); err != nil {
-		return response.ValidationError(c, "Invalid input")
+	existingPost, err := h.postService.FetchPostByID(input.ID, user.ID)
+	if err != nil {
+		return err
 	}
 
-	if input.Caption == "" {
-		return response.ValidationError(c, "Caption cannot be empty")
+	if existingPost.UserID != user.ID {
+		return entity.Forbidden("You are not allowed to update this post")
 	}
 
-	existingPost.Caption = input.Caption
-	updatedPost, err := h.postService.UpdatePost(id, existingPost)
+	var media []entity.PostMedia
+	if input.Media != nil {
+		media, err = util.ArrangePostMedia(request.Locale(c), existingPost.Media, input.Media)
+		if err != nil {
+			return err
+		}
+	}
+
+	updatedPost, err := h.postService.EditPost(input.ID, input.Caption, media)
 	if err != nil {
-		return response.Error(c, "Failed to update post", fiber.StatusInternalServerError)
+		return err
 	}
 
 	postResponse := util.MapToPostResponse([]entity.Post{updatedPost})[0]
@@ -179,6 +226,7 @@ func (h *PostHandler) UpdatePost(c *fiber.Ctx) error {
 // @Security BearerAuth
 // @Success 204 {object} response.DeletePostResponse "Successful delete post response"
 // @Failure 400 {object} response.ErrorResponse "Bad request"
+// @Failure 403 {object} response.ErrorResponse "Not the post's author"
 // @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /posts/{id} [delete]
 func (h *PostHandler) DeletePost(c *fiber.Ctx) error {
@@ -187,19 +235,23 @@ func (h *PostHandler) DeletePost(c *fiber.Ctx) error {
 		return err
 	}
 
-	id := c.Params("id")
-	post, err := h.postService.FetchPostByID(id)
+	var params request.PostParams
+	if err := request.Bind(c, &params); err != nil {
+		return err
+	}
+
+	post, err := h.postService.FetchPostByID(params.ID, user.ID)
 	if err != nil {
-		return response.Error(c, "Post not found", fiber.StatusNotFound)
+		return err
 	}
 
 	if post.UserID != user.ID {
-		return response.Error(c, "Unauthorized to delete this post", fiber.StatusUnauthorized)
+		return entity.Forbidden("You are not allowed to delete this post")
 	}
 
-	err = h.postService.DeletePost(id)
+	err = h.postService.DeletePost(params.ID)
 	if err != nil {
-		return response.Error(c, "Post not found", fiber.StatusNotFound)
+		return err
 	}
 	return response.Success(c, fiber.Map{"message": "Post deleted successfully"}, fiber.StatusNoContent)
 }
@@ -210,19 +262,33 @@ func (h *PostHandler) DeletePost(c *fiber.Ctx) error {
 // @Tags search
 // @Produce json
 // @Param query query string true "Search query"
+// @Param limit query int false "Page size (max 100)" default(20)
+// @Param cursor query string false "Opaque cursor returned as next_cursor by the previous page"
 // @Success 200 {array} response.SearchPostsResponse "Successful search response"
 // @Failure 400 {object} response.ErrorResponse "Invalid query parameter"
 // @Router /search/posts [get]
 func (h *PostHandler) SearchPosts(c *fiber.Ctx) error {
-	query := c.Query("query")
-	if query == "" {
-		return response.Error(c, "Query parameter is required", fiber.StatusBadRequest)
+	var input request.SearchRequest
+	if err := request.Bind(c, &input); err != nil {
+		return err
+	}
+
+	page, err := util.ParsePageRequest(c)
+	if err != nil {
+		return err
 	}
 
-	posts, err := h.postService.SearchPosts(query)
+	posts, pageInfo, err := h.postService.SearchPosts(input.Query, h.viewerID(c), page)
 	if err != nil {
-		return response.Error(c, "No posts found", fiber.StatusNotFound)
+		return err
 	}
 
-	return response.Success(c, util.MapToPostResponse(posts))
-}
\ No newline at end of file
+	return response.Paginated(c, util.MapToPostResponse(posts), util.EncodeCursor(pageInfo.Next), pageInfo.HasMore)
+}
+
+func (h *PostHandler) viewerID(c *fiber.Ctx) string {
+	if viewer := util.GetOptionalUserFromToken(c, h.authService); viewer != nil {
+		return viewer.ID
+	}
+	return ""
+}
@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	contract "raion-assessment/domain/contract"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// StorageHandler stands in for a storage service when the media store's
+// signed and presigned URLs point back at the API. It only exists for such
+// stores.
+type StorageHandler struct {
+	store contract.IServedMediaStore
+}
+
+func NewStorageHandler(store contract.IServedMediaStore) *StorageHandler {
+	return &StorageHandler{store: store}
+}
+
+// ServeMedia streams the file behind a signed media URL. A single byte range
+// is served on request, so players can seek through videos, and conditional
+// requests are answered from the ETag and Last-Modified date. The file may be
+// cached privately for as long as the URL lasts.
+func (h *StorageHandler) ServeMedia(c *fiber.Ctx) error {
+	key, expires := c.Params("*"), c.Query("expires")
+	file, info, err := h.store.OpenSigned(c.UserContext(), key, expires, c.Query("signature"))
+	if err != nil {
+		return err
+	}
+
+	// OpenSigned has checked that expires is a time still to come.
+	expiresAt, _ := strconv.ParseInt(expires, 10, 64)
+	maxAge := max(expiresAt-time.Now().Unix(), 0)
+	etag := fmt.Sprintf(`"%x-%x"`, info.ModTime().UnixNano(), info.Size())
+	modTime := info.ModTime().UTC().Truncate(time.Second)
+
+	c.Set(fiber.HeaderCacheControl, fmt.Sprintf("private, max-age=%d, immutable", maxAge))
+	c.Set(fiber.HeaderETag, etag)
+	c.Set(fiber.HeaderLastModified, modTime.Format(http.TimeFormat))
+	c.Set(fiber.HeaderXContentTypeOptions, "nosniff")
+	c.Set(fiber.HeaderAcceptRanges, "bytes")
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = fiber.MIMEOctetStream
+	}
+	c.Set(fiber.HeaderContentType, contentType)
+
+	if notModified(c, etag, modTime) {
+		file.Close()
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
+	size := info.Size()
+	start, length := int64(0), size
+	if c.Get(fiber.HeaderRange) != "" && rangeApplies(c, etag, modTime) {
+		ranges, err := c.Range(int(size))
+		switch {
+		case errors.Is(err, fiber.ErrRangeUnsatisfiable):
+			file.Close()
+			c.Set(fiber.HeaderContentRange, fmt.Sprintf("bytes */%d", size))
+			return c.SendStatus(fiber.StatusRequestedRangeNotSatisfiable)
+		case err == nil && ranges.Type == "bytes" && len(ranges.Ranges) == 1:
+			// Several ranges would need a multipart body; the whole file is
+			// sent instead, which clients must accept.
+			start = int64(ranges.Ranges[0].Start)
+			length = int64(ranges.Ranges[0].End) - start + 1
+			c.Status(fiber.StatusPartialContent)
+			c.Set(fiber.HeaderContentRange, fmt.Sprintf("bytes %d-%d/%d", start, start+length-1, size))
+		}
+	}
+
+	if _, err := file.Seek(start, io.SeekStart); err != nil {
+		file.Close()
+		return err
+	}
+	// fasthttp closes the stream once the response is written.
+	return c.SendStream(struct {
+		io.Reader
+		io.Closer
+	}{io.LimitReader(file, length), file}, int(length))
+}
+
+// notModified says whether a conditional request can be answered with 304.
+// If-None-Match, when present, decides on its own.
+func notModified(c *fiber.Ctx, etag string, modTime time.Time) bool {
+	if match := c.Get(fiber.HeaderIfNoneMatch); match != "" {
+		for _, candidate := range strings.Split(match, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == etag || candidate == "*" {
+				return true
+			}
+		}
+		return false
+	}
+	since, err := http.ParseTime(c.Get(fiber.HeaderIfModifiedSince))
+	return err == nil && !modTime.After(since)
+}
+
+// rangeApplies checks If-Range, which asks for a range only if the file is
+// still the one the client has part of.
+func rangeApplies(c *fiber.Ctx, etag string, modTime time.Time) bool {
+	ifRange := c.Get(fiber.HeaderIfRange)
+	if ifRange == "" {
+		return true
+	}
+	if strings.HasPrefix(ifRange, `"`) {
+		return ifRange == etag
+	}
+	at, err := http.ParseTime(ifRange)
+	return err == nil && modTime.Equal(at)
+}
+
+// ReceiveUpload stores the body of a PUT to a presigned upload URL under the
+// key in the path, once the store has checked the URL's signature against the
+// request's content type and length. Like a storage service it answers with
+// an empty 200; the client completes the upload through the API afterwards.
+func (h *StorageHandler) ReceiveUpload(c *fiber.Ctx) error {
+	err := h.store.ReceivePresignedPut(
+		c.UserContext(),
+		c.Params("*"),
+		c.Get(fiber.HeaderContentType),
+		int64(c.Request().Header.ContentLength()),
+		c.Query("expires"),
+		c.Query("signature"),
+		bytes.NewReader(c.Body()),
+	)
+	if err != nil {
+		return err
+	}
+	return c.SendStatus(fiber.StatusOK)
+}
@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	contract "raion-assessment/domain/contract"
+	"raion-assessment/internal/storage"
+	"raion-assessment/pkg/response"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const testMedia = "0123456789abcdef"
+
+// newStorageApp serves a local store holding testMedia under posts/a.mp4 and
+// returns the app and the path of a signed URL for it.
+func newStorageApp(t *testing.T) (*fiber.App, string) {
+	t.Helper()
+	store := storage.NewLocalStore(t.TempDir(), "http://example.com/uploads", []byte("test-key"), time.Hour)
+	if err := store.Put(context.Background(), "posts/a.mp4", bytes.NewReader([]byte(testMedia)), int64(len(testMedia)), "video/mp4"); err != nil {
+		t.Fatal(err)
+	}
+
+	app := fiber.New(fiber.Config{ErrorHandler: response.ErrorHandler})
+	app.Get("/uploads/*", NewStorageHandler(store.(contract.IServedMediaStore)).ServeMedia)
+
+	signed, err := url.Parse(store.SignedURL("posts/a.mp4"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return app, signed.RequestURI()
+}
+
+func serve(t *testing.T, app *fiber.App, target string, header map[string]string) (*http.Response, string) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	for name, value := range header {
+		req.Header.Set(name, value)
+	}
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp, string(body)
+}
+
+func TestServeMediaWholeFile(t *testing.T) {
+	app, target := newStorageApp(t)
+
+	resp, body := serve(t, app, target, nil)
+	if resp.StatusCode != fiber.StatusOK || body != testMedia {
+		t.Fatalf("got %d %q, want 200 %q", resp.StatusCode, body, testMedia)
+	}
+	if got := resp.Header.Get(fiber.HeaderContentType); got != "video/mp4" {
+		t.Errorf("Content-Type = %q, want video/mp4", got)
+	}
+	if got := resp.Header.Get(fiber.HeaderAcceptRanges); got != "bytes" {
+		t.Errorf("Accept-Ranges = %q, want bytes", got)
+	}
+}
+
+func TestServeMediaRange(t *testing.T) {
+	app, target := newStorageApp(t)
+
+	tests := []struct {
+		rangeHeader  string
+		status       int
+		body         string
+		contentRange string
+	}{
+		{"bytes=2-5", fiber.StatusPartialContent, "2345", "bytes 2-5/16"},
+		{"bytes=10-", fiber.StatusPartialContent, "abcdef", "bytes 10-15/16"},
+		{"bytes=-3", fiber.StatusPartialContent, "def", "bytes 13-15/16"},
+		{"bytes=20-30", fiber.StatusRequestedRangeNotSatisfiable, "Requested Range Not Satisfiable", "bytes */16"},
+		{"bytes=0-1,4-5", fiber.StatusOK, testMedia, ""},
+	}
+	for _, tt := range tests {
+		resp, body := serve(t, app, target, map[string]string{fiber.HeaderRange: tt.rangeHeader})
+		if resp.StatusCode != tt.status || body != tt.body {
+			t.Errorf("Range %s: got %d %q, want %d %q", tt.rangeHeader, resp.StatusCode, body, tt.status, tt.body)
+		}
+		if got := resp.Header.Get(fiber.HeaderContentRange); got != tt.contentRange {
+			t.Errorf("Range %s: Content-Range = %q, want %q", tt.rangeHeader, got, tt.contentRange)
+		}
+	}
+}
+
+func TestServeMediaConditional(t *testing.T) {
+	app, target := newStorageApp(t)
+	first, _ := serve(t, app, target, nil)
+	etag, lastModified := first.Header.Get(fiber.HeaderETag), first.Header.Get(fiber.HeaderLastModified)
+
+	tests := []struct {
+		name   string
+		header map[string]string
+		status int
+	}{
+		{"matching ETag", map[string]string{fiber.HeaderIfNoneMatch: etag}, fiber.StatusNotModified},
+		{"other ETag", map[string]string{fiber.HeaderIfNoneMatch: `"other"`}, fiber.StatusOK},
+		{"not modified since", map[string]string{fiber.HeaderIfModifiedSince: lastModified}, fiber.StatusNotModified},
+		{"If-Range with stale ETag", map[string]string{fiber.HeaderRange: "bytes=0-1", fiber.HeaderIfRange: `"other"`}, fiber.StatusOK},
+		{"If-Range with current ETag", map[string]string{fiber.HeaderRange: "bytes=0-1", fiber.HeaderIfRange: etag}, fiber.StatusPartialContent},
+	}
+	for _, tt := range tests {
+		resp, _ := serve(t, app, target, tt.header)
+		if resp.StatusCode != tt.status {
+			t.Errorf("%s: got %d, want %d", tt.name, resp.StatusCode, tt.status)
+		}
+	}
+}
+
+func TestServeMediaRejectsBadSignature(t *testing.T) {
+	app, target := newStorageApp(t)
+	signed, _ := url.Parse(target)
+	query := signed.Query()
+	query.Set("signature", "forged")
+	signed.RawQuery = query.Encode()
+
+	resp, _ := serve(t, app, signed.RequestURI(), nil)
+	if resp.StatusCode != fiber.StatusForbidden {
+		t.Fatalf("got %d, want 403", resp.StatusCode)
+	}
+}
@@ -0,0 +1,268 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"net/http"
+	contract "raion-assessment/domain/contract"
+	entity "raion-assessment/domain/entity"
+	"raion-assessment/pkg/request"
+	"raion-assessment/pkg/util"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// tus protocol constants. Only version 1.0.0 of the core protocol is spoken,
+// with the creation, termination and expiration extensions.
+const (
+	tusVersion           = "1.0.0"
+	tusExtensions        = "creation,termination,expiration"
+	tusChunkContentType  = "application/offset+octet-stream"
+	maxUploadMetadataLen = 4096
+)
+
+// MaxUploadChunkSize bounds a single PATCH of a resumable upload. Chunks are
+// streamed to the store rather than held in memory; the limit keeps each
+// request short enough to retry cheaply over a poor connection.
+const MaxUploadChunkSize = 8 << 20
+
+type UploadHandler struct {
+	uploadService contract.IUploadService
+	authService   contract.IAuthService
+}
+
+func NewUploadHandler(uploadService contract.IUploadService, authService contract.IAuthService) *UploadHandler {
+	return &UploadHandler{
+		uploadService: uploadService,
+		authService:   authService,
+	}
+}
+
+// TusResumable checks that a client speaks the supported tus version and
+// marks every response with it. OPTIONS requests are exempt so clients can
+// discover the version first.
+func (h *UploadHandler) TusResumable(c *fiber.Ctx) error {
+	c.Set("Tus-Resumable", tusVersion)
+	if c.Method() != fiber.MethodOptions && c.Get("Tus-Resumable") != tusVersion {
+		c.Set("Tus-Version", tusVersion)
+		return fiber.NewError(fiber.StatusPreconditionFailed, "Tus-Resumable must be "+tusVersion)
+	}
+	return c.Next()
+}
+
+// DescribeUploads godoc
+// @Summary Describe resumable uploads
+// @Description Report the tus protocol version, extensions and largest upload size the server supports, and the largest chunk a single PATCH may carry.
+// @Tags uploads
+// @Success 204 "Supported tus features, in the Tus-Version, Tus-Extension, Tus-Max-Size and Tus-Max-Chunk-Size headers"
+// @Router /uploads [options]
+func (h *UploadHandler) DescribeUploads(c *fiber.Ctx) error {
+	c.Set("Tus-Version", tusVersion)
+	c.Set("Tus-Extension", tusExtensions)
+	c.Set("Tus-Max-Size", strconv.Itoa(entity.MaxVideoUploadSize))
+	c.Set("Tus-Max-Chunk-Size", strconv.Itoa(MaxUploadChunkSize))
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// CreateUpload godoc
+// @Summary Start a resumable upload
+// @Description Start a tus 1.0 resumable upload of an image or video of Upload-Length bytes. The upload's address is returned in the Location header; send the file to it with PATCH requests, check how much arrived with HEAD after a dropped connection, and pass the upload ID as upload_id to create a post or update the profile once it is complete. Uploads expire when no chunk arrives for a day, as announced in Upload-Expires. Requires JWT authentication.
+// @Tags uploads
+// @Param Tus-Resumable header string true "tus protocol version" default(1.0.0)
+// @Param Upload-Length header int true "Size of the whole file in bytes"
+// @Param Upload-Metadata header string false "Comma-separated key and base64 value pairs, such as filename"
+// @Security BearerAuth
+// @Success 201 "Upload created at the URL in the Location header"
+// @Failure 400 {object} response.ErrorResponse "Bad request"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized or invalid token"
+// @Failure 412 {object} response.ErrorResponse "Unsupported tus version"
+// @Failure 413 {object} response.ErrorResponse "Upload too large"
+// @Failure 507 {object} response.ErrorResponse "Storage quota exceeded"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /uploads [post]
+func (h *UploadHandler) CreateUpload(c *fiber.Ctx) error {
+	user, err := util.GetUserFromToken(c, h.authService)
+	if err != nil {
+		return err
+	}
+
+	locale := request.Locale(c)
+	length, err := strconv.ParseInt(c.Get("Upload-Length"), 10, 64)
+	if err != nil || length <= 0 {
+		return request.Invalid(locale, "Upload-Length", "number")
+	}
+	if length > entity.MaxVideoUploadSize {
+		return fiber.NewError(fiber.StatusRequestEntityTooLarge, "Upload-Length must be at most "+strconv.Itoa(entity.MaxVideoUploadSize))
+	}
+	metadata := c.Get("Upload-Metadata")
+	if !validUploadMetadata(metadata) {
+		return request.Invalid(locale, "Upload-Metadata", "invalid")
+	}
+
+	upload, err := h.uploadService.CreateUpload(user.ID, length, metadata)
+	if err != nil {
+		return err
+	}
+
+	c.Location(c.BaseURL() + "/api/v1/uploads/" + upload.ID)
+	setUploadExpires(c, upload)
+	return c.SendStatus(fiber.StatusCreated)
+}
+
+// GetUploadOffset godoc
+// @Summary Get the offset of a resumable upload
+// @Description Report how many bytes of a resumable upload have arrived, in the Upload-Offset header, so an interrupted upload can carry on from there. Requires JWT authentication.
+// @Tags uploads
+// @Param id path string true "Upload ID"
+// @Param Tus-Resumable header string true "tus protocol version" default(1.0.0)
+// @Security BearerAuth
+// @Success 200 "Upload-Offset and Upload-Length headers"
+// @Failure 404 "Upload not found or expired"
+// @Router /uploads/{id} [head]
+func (h *UploadHandler) GetUploadOffset(c *fiber.Ctx) error {
+	user, params, err := h.bind(c)
+	if err != nil {
+		return err
+	}
+
+	upload, err := h.uploadService.FetchUpload(user.ID, params.ID)
+	if err != nil {
+		return err
+	}
+
+	c.Set("Upload-Offset", strconv.FormatInt(upload.Offset, 10))
+	c.Set("Upload-Length", strconv.FormatInt(upload.Length, 10))
+	if upload.Metadata != "" {
+		c.Set("Upload-Metadata", upload.Metadata)
+	}
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	setUploadExpires(c, upload)
+	return c.SendStatus(fiber.StatusOK)
+}
+
+// AppendUploadChunk godoc
+// @Summary Send a chunk of a resumable upload
+// @Description Append the request body to a resumable upload. Upload-Offset must equal the upload's current offset; the new offset is returned in the same header. The body is streamed to storage and may be at most Tus-Max-Chunk-Size bytes, as reported by OPTIONS /uploads, with its Content-Length set. Requires JWT authentication.
+// @Tags uploads
+// @Accept application/offset+octet-stream
+// @Param id path string true "Upload ID"
+// @Param Tus-Resumable header string true "tus protocol version" default(1.0.0)
+// @Param Upload-Offset header int true "Offset the chunk starts at"
+// @Security BearerAuth
+// @Success 204 "Chunk stored; the new offset is in the Upload-Offset header"
+// @Failure 400 {object} response.ErrorResponse "Bad request"
+// @Failure 404 {object} response.ErrorResponse "Upload not found or expired"
+// @Failure 409 {object} response.ErrorResponse "Upload-Offset does not match the upload"
+// @Failure 411 {object} response.ErrorResponse "Content-Length missing"
+// @Failure 413 {object} response.ErrorResponse "Chunk larger than Tus-Max-Chunk-Size"
+// @Failure 415 {object} response.ErrorResponse "Wrong content type"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /uploads/{id} [patch]
+func (h *UploadHandler) AppendUploadChunk(c *fiber.Ctx) error {
+	user, params, err := h.bind(c)
+	if err != nil {
+		return err
+	}
+
+	if c.Get(fiber.HeaderContentType) != tusChunkContentType {
+		return fiber.NewError(fiber.StatusUnsupportedMediaType, "Content-Type must be "+tusChunkContentType)
+	}
+	offset, err := strconv.ParseInt(c.Get("Upload-Offset"), 10, 64)
+	if err != nil || offset < 0 {
+		return request.Invalid(request.Locale(c), "Upload-Offset", "number")
+	}
+
+	size := c.Request().Header.ContentLength()
+	if size < 0 {
+		return fiber.ErrLengthRequired
+	}
+	if size > MaxUploadChunkSize {
+		return fiber.NewError(fiber.StatusRequestEntityTooLarge, "chunks must be at most "+strconv.Itoa(MaxUploadChunkSize)+" bytes")
+	}
+
+	upload, err := h.uploadService.AppendChunk(user.ID, params.ID, offset, int64(size), requestBody(c))
+	if err != nil {
+		return err
+	}
+
+	c.Set("Upload-Offset", strconv.FormatInt(upload.Offset, 10))
+	setUploadExpires(c, upload)
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// DeleteUpload godoc
+// @Summary Cancel a resumable upload
+// @Description Delete a resumable upload and every chunk received for it. Requires JWT authentication.
+// @Tags uploads
+// @Param id path string true "Upload ID"
+// @Param Tus-Resumable header string true "tus protocol version" default(1.0.0)
+// @Security BearerAuth
+// @Success 204 "Upload deleted"
+// @Failure 404 {object} response.ErrorResponse "Upload not found or expired"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /uploads/{id} [delete]
+func (h *UploadHandler) DeleteUpload(c *fiber.Ctx) error {
+	user, params, err := h.bind(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.uploadService.DeleteUpload(user.ID, params.ID); err != nil {
+		return err
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// bind authenticates the caller and reads the upload ID from the path. The
+// body is a raw chunk, so request.Bind cannot be used.
+func (h *UploadHandler) bind(c *fiber.Ctx) (*entity.User, request.UploadParams, error) {
+	var params request.UploadParams
+	user, err := util.GetUserFromToken(c, h.authService)
+	if err != nil {
+		return nil, params, err
+	}
+	if err := c.ParamsParser(&params); err != nil {
+		return nil, params, entity.Validation("Invalid path parameters")
+	}
+	if err := request.Validate(request.Locale(c), &params); err != nil {
+		return nil, params, err
+	}
+	return user, params, nil
+}
+
+// requestBody returns the request body as it arrives when the server streams
+// request bodies, and the body read so far otherwise.
+func requestBody(c *fiber.Ctx) io.Reader {
+	if body := c.Context().RequestBodyStream(); body != nil {
+		return body
+	}
+	return bytes.NewReader(c.Body())
+}
+
+func setUploadExpires(c *fiber.Ctx, upload entity.Upload) {
+	c.Set("Upload-Expires", upload.ExpiresAt.UTC().Format(http.TimeFormat))
+}
+
+// validUploadMetadata checks an Upload-Metadata header: comma-separated pairs
+// of a key and an optional base64 value, separated by a space.
+func validUploadMetadata(metadata string) bool {
+	if metadata == "" {
+		return true
+	}
+	if len(metadata) > maxUploadMetadataLen {
+		return false
+	}
+	for _, pair := range strings.Split(metadata, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(pair), " ")
+		if key == "" || strings.ContainsAny(key, " ,") {
+			return false
+		}
+		if _, err := base64.StdEncoding.DecodeString(value); err != nil {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,288 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	contract "raion-assessment/domain/contract"
+	entity "raion-assessment/domain/entity"
+	"raion-assessment/internal/middleware"
+	"raion-assessment/internal/service"
+	"raion-assessment/internal/storage"
+	"raion-assessment/pkg/response"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+const testOwnerID = "owner-1"
+
+// fakeAuth accepts any bearer token as the test owner.
+type fakeAuth struct {
+	contract.IAuthService
+}
+
+func (fakeAuth) GetCurrentUser(ctx context.Context, token string) (*entity.User, error) {
+	return &entity.User{ID: testOwnerID}, nil
+}
+
+// memoryUploadRepo keeps resumable uploads in memory with the semantics of the
+// SQL repository: expired uploads are invisible and chunks only append at the
+// current offset.
+type memoryUploadRepo struct {
+	contract.IUploadRepository
+	mu      sync.Mutex
+	uploads map[string]*entity.Upload
+}
+
+func (r *memoryUploadRepo) CreateUpload(ctx context.Context, upload entity.Upload, ttl time.Duration, quota int64) (*entity.Upload, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	upload.ID = uuid.NewString()
+	upload.CreatedAt = time.Now()
+	upload.ExpiresAt = upload.CreatedAt.Add(ttl)
+	r.uploads[upload.ID] = &upload
+	return r.copy(&upload), nil
+}
+
+func (r *memoryUploadRepo) FetchUpload(ctx context.Context, id, ownerID string) (*entity.Upload, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.copy(r.live(id, ownerID)), nil
+}
+
+func (r *memoryUploadRepo) AppendChunk(ctx context.Context, id, ownerID string, offset, size int64, chunkKey string, ttl time.Duration) (*entity.Upload, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	upload := r.live(id, ownerID)
+	if upload == nil || upload.Offset != offset || offset+size > upload.Length {
+		return nil, nil
+	}
+	upload.Offset += size
+	upload.ChunkKeys = append(upload.ChunkKeys, chunkKey)
+	upload.ExpiresAt = time.Now().Add(ttl)
+	return r.copy(upload), nil
+}
+
+func (r *memoryUploadRepo) DeleteUpload(ctx context.Context, id, ownerID string) (*entity.Upload, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	upload, ok := r.uploads[id]
+	if !ok || upload.OwnerID != ownerID {
+		return nil, nil
+	}
+	delete(r.uploads, id)
+	return upload, nil
+}
+
+func (r *memoryUploadRepo) DeleteExpiredUploads(ctx context.Context, limit int) ([]entity.Upload, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var expired []entity.Upload
+	for id, upload := range r.uploads {
+		if len(expired) < limit && !upload.ExpiresAt.After(time.Now()) {
+			expired = append(expired, *upload)
+			delete(r.uploads, id)
+		}
+	}
+	return expired, nil
+}
+
+func (r *memoryUploadRepo) DeleteExpiredDirectUploads(ctx context.Context, limit int) ([]entity.DirectUpload, error) {
+	return nil, nil
+}
+
+func (r *memoryUploadRepo) live(id, ownerID string) *entity.Upload {
+	upload, ok := r.uploads[id]
+	if !ok || upload.OwnerID != ownerID || !upload.ExpiresAt.After(time.Now()) {
+		return nil
+	}
+	return upload
+}
+
+func (r *memoryUploadRepo) copy(upload *entity.Upload) *entity.Upload {
+	if upload == nil {
+		return nil
+	}
+	copied := *upload
+	copied.ChunkKeys = append([]string(nil), upload.ChunkKeys...)
+	return &copied
+}
+
+func (r *memoryUploadRepo) expire(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.uploads[id].ExpiresAt = time.Now().Add(-time.Second)
+}
+
+type uploadTest struct {
+	t       *testing.T
+	app     *fiber.App
+	repo    *memoryUploadRepo
+	store   contract.IMediaStore
+	uploads contract.IUploadService
+}
+
+// newUploadTest serves the tus routes over an in-memory upload repository and
+// a local store, with request bodies streamed as they are in production.
+func newUploadTest(t *testing.T) *uploadTest {
+	t.Helper()
+	repo := &memoryUploadRepo{uploads: map[string]*entity.Upload{}}
+	store := storage.NewLocalStore(t.TempDir(), "http://example.com/uploads", []byte("test-key"), time.Hour)
+	uploads := service.NewUploadService(repo, store, time.Hour, 1<<30)
+	h := NewUploadHandler(uploads, fakeAuth{})
+
+	app := fiber.New(fiber.Config{ErrorHandler: response.ErrorHandler, StreamRequestBody: true})
+	app.Use(middleware.BodyLimit(1<<10, func(c *fiber.Ctx) bool { return c.Method() == fiber.MethodPatch }))
+	group := app.Group("/api/v1/uploads")
+	group.Options("/", h.TusResumable, h.DescribeUploads)
+	group.Post("/", h.TusResumable, h.CreateUpload)
+	group.Head("/:id", h.TusResumable, h.GetUploadOffset)
+	group.Patch("/:id", h.TusResumable, h.AppendUploadChunk)
+	return &uploadTest{t: t, app: app, repo: repo, store: store, uploads: uploads}
+}
+
+func (u *uploadTest) do(method, target string, header map[string]string, body io.Reader) *http.Response {
+	u.t.Helper()
+	req := httptest.NewRequest(method, target, body)
+	req.Header.Set("Authorization", "Bearer test")
+	req.Header.Set("Tus-Resumable", tusVersion)
+	for name, value := range header {
+		req.Header.Set(name, value)
+	}
+	resp, err := u.app.Test(req, -1)
+	if err != nil {
+		u.t.Fatal(err)
+	}
+	return resp
+}
+
+// create starts an upload of length bytes and returns its ID.
+func (u *uploadTest) create(length int) string {
+	u.t.Helper()
+	resp := u.do(http.MethodPost, "/api/v1/uploads", map[string]string{"Upload-Length": strconv.Itoa(length)}, nil)
+	if resp.StatusCode != fiber.StatusCreated {
+		u.t.Fatalf("create upload: got %d, want 201", resp.StatusCode)
+	}
+	location := resp.Header.Get(fiber.HeaderLocation)
+	return location[strings.LastIndex(location, "/")+1:]
+}
+
+func (u *uploadTest) patch(id string, offset int, chunk string) *http.Response {
+	u.t.Helper()
+	return u.do(http.MethodPatch, "/api/v1/uploads/"+id, map[string]string{
+		fiber.HeaderContentType: tusChunkContentType,
+		"Upload-Offset":         strconv.Itoa(offset),
+	}, strings.NewReader(chunk))
+}
+
+func (u *uploadTest) offset(id string) string {
+	u.t.Helper()
+	resp := u.do(http.MethodHead, "/api/v1/uploads/"+id, nil, nil)
+	if resp.StatusCode != fiber.StatusOK {
+		u.t.Fatalf("HEAD upload: got %d, want 200", resp.StatusCode)
+	}
+	return resp.Header.Get("Upload-Offset")
+}
+
+func TestAppendUploadChunkOffsetMismatch(t *testing.T) {
+	u := newUploadTest(t)
+	id := u.create(10)
+
+	if resp := u.patch(id, 0, "01234"); resp.StatusCode != fiber.StatusNoContent || resp.Header.Get("Upload-Offset") != "5" {
+		t.Fatalf("first chunk: got %d at offset %q, want 204 at 5", resp.StatusCode, resp.Header.Get("Upload-Offset"))
+	}
+	if resp := u.patch(id, 0, "56789"); resp.StatusCode != fiber.StatusConflict {
+		t.Fatalf("chunk at a stale offset: got %d, want 409", resp.StatusCode)
+	}
+	if got := u.offset(id); got != "5" {
+		t.Fatalf("offset after conflict = %s, want 5", got)
+	}
+}
+
+func TestAppendUploadChunkPastLength(t *testing.T) {
+	u := newUploadTest(t)
+	id := u.create(10)
+
+	if resp := u.patch(id, 0, "0123456789a"); resp.StatusCode != fiber.StatusBadRequest {
+		t.Fatalf("chunk past Upload-Length: got %d, want 400", resp.StatusCode)
+	}
+	if got := u.offset(id); got != "0" {
+		t.Fatalf("offset after rejected chunk = %s, want 0", got)
+	}
+}
+
+func TestAppendUploadChunkTooLarge(t *testing.T) {
+	u := newUploadTest(t)
+	id := u.create(MaxUploadChunkSize + 1)
+
+	resp := u.patch(id, 0, strings.Repeat("x", MaxUploadChunkSize+1))
+	if resp.StatusCode != fiber.StatusRequestEntityTooLarge {
+		t.Fatalf("oversized chunk: got %d, want 413", resp.StatusCode)
+	}
+	if got := u.do(http.MethodOptions, "/api/v1/uploads", nil, nil).Header.Get("Tus-Max-Chunk-Size"); got != strconv.Itoa(MaxUploadChunkSize) {
+		t.Fatalf("Tus-Max-Chunk-Size = %q, want %d", got, MaxUploadChunkSize)
+	}
+}
+
+func TestExpiredUpload(t *testing.T) {
+	u := newUploadTest(t)
+	id := u.create(10)
+	if resp := u.patch(id, 0, "01234"); resp.StatusCode != fiber.StatusNoContent {
+		t.Fatalf("chunk: got %d, want 204", resp.StatusCode)
+	}
+	chunkKeys := u.repo.uploads[id].ChunkKeys
+
+	u.repo.expire(id)
+	if resp := u.patch(id, 5, "56789"); resp.StatusCode != fiber.StatusNotFound {
+		t.Fatalf("chunk for an expired upload: got %d, want 404", resp.StatusCode)
+	}
+	if resp := u.do(http.MethodHead, "/api/v1/uploads/"+id, nil, nil); resp.StatusCode != fiber.StatusNotFound {
+		t.Fatalf("HEAD of an expired upload: got %d, want 404", resp.StatusCode)
+	}
+
+	expired, err := u.uploads.ExpireUploads()
+	if err != nil || expired != 1 {
+		t.Fatalf("ExpireUploads() = %d, %v, want 1, nil", expired, err)
+	}
+	for _, key := range chunkKeys {
+		if _, err := u.store.Get(context.Background(), key); !errors.Is(err, entity.ErrNotFound) {
+			t.Errorf("chunk %s of an expired upload is still stored: %v", key, err)
+		}
+	}
+}
+
+func TestOpenUploadReassemblesChunks(t *testing.T) {
+	u := newUploadTest(t)
+	chunks := []string{"resumable ", "uploads ", "", "arrive in pieces"}
+	want := strings.Join(chunks, "")
+	id := u.create(len(want))
+
+	offset := 0
+	for _, chunk := range chunks {
+		if resp := u.patch(id, offset, chunk); resp.StatusCode != fiber.StatusNoContent {
+			t.Fatalf("chunk %q: got %d, want 204", chunk, resp.StatusCode)
+		}
+		offset += len(chunk)
+	}
+
+	if _, err := u.uploads.OpenUpload(testOwnerID, u.create(1)); !errors.Is(err, entity.ErrValidation) {
+		t.Fatalf("opening an unfinished upload: got %v, want a validation error", err)
+	}
+	reader, err := u.uploads.OpenUpload(testOwnerID, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+	if err := iotest.TestReader(reader, []byte(want)); err != nil {
+		t.Fatal(err)
+	}
+}
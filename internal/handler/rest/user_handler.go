@@ -1,10 +1,10 @@
 package handler
 
 import (
-	"fmt"
 	"log"
 	contract "raion-assessment/domain/contract"
 	entity "raion-assessment/domain/entity"
+	"raion-assessment/pkg/request"
 	"raion-assessment/pkg/response"
 	"raion-assessment/pkg/util"
 
@@ -12,14 +12,16 @@ import (
 )
 
 type UserHandler struct {
-	userService contract.IUserService
-	authService contract.IAuthService
+	userService  contract.IUserService
+	authService  contract.IAuthService
+	mediaService contract.IMediaService
 }
 
-func NewUserHandler(userService contract.IUserService, authService contract.IAuthService) *UserHandler {
+func NewUserHandler(userService contract.IUserService, authService contract.IAuthService, mediaService contract.IMediaService) *UserHandler {
     return &UserHandler{
         userService: userService,
         authService: authService,
+        mediaService: mediaService,
     }
 }
 
@@ -28,22 +30,29 @@ func NewUserHandler(userService contract.IUserService, authService contract.IAut
 // @Description Retrieve a list of all users from the database.
 // @Tags users
 // @Produce json
+// @Param limit query int false "Page size (max 100)" default(20)
+// @Param cursor query string false "Opaque cursor returned as next_cursor by the previous page"
 // @Success 200 {object} response.GetAllUsersResponse "Successful fetch users response"
 // @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /users [get]
 func (h *UserHandler) GetAllUsers(c *fiber.Ctx) error {
-	users, err := h.userService.FetchAllUsers()
+	page, err := util.ParsePageRequest(c)
+	if err != nil {
+		return err
+	}
+
+	users, pageInfo, err := h.userService.FetchAllUsers(page)
 	if err != nil {
 		log.Printf("Error fetching users: %v", err)
-		return response.Error(c, "Error fetching users", fiber.StatusInternalServerError)
+		return err
 	}
 
-	var userResponses []response.User
+	userResponses := make([]response.User, 0, len(users))
 	for _, user := range users {
 		userResponses = append(userResponses, util.MapToUserResponse(user))
 	}
 
-	return response.Success(c, userResponses)
+	return response.Paginated(c, userResponses, util.EncodeCursor(pageInfo.Next), pageInfo.HasMore)
 }
 
 // GetUserByID godoc
@@ -56,12 +65,15 @@ func (h *UserHandler) GetAllUsers(c *fiber.Ctx) error {
 // @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /users/{id} [get]
 func (h *UserHandler) GetUserByID(c *fiber.Ctx) error {
-	id := c.Params("id")
+	var params request.UserParams
+	if err := request.Bind(c, &params); err != nil {
+		return err
+	}
 
-	user, err := h.userService.FetchUserByID(id)
+	user, err := h.userService.FetchUserByID(params.ID)
 	if err != nil {
 		log.Printf("Error fetching user by ID: %v", err)
-		return response.Error(c, "User not found", fiber.StatusNotFound)
+		return err
 	}
 
 	return response.Success(c, util.MapToUserResponse(user))
@@ -75,44 +87,46 @@ func (h *UserHandler) GetUserByID(c *fiber.Ctx) error {
 // @Produce json
 // @Param username formData string false "Updated username (optional)"
 // @Param bio formData string false "Updated bio (optional)"
-// @Param image formData file false "Updated image (optional)"
+// @Param image formData file false "Updated image (optional): JPEG, PNG or WebP up to 8 MB, or GIF up to 4 MB"
+// @Param upload_id formData string false "ID of a finished resumable upload to use as the image instead of a file (optional)"
+// @Param media_id formData string false "ID of the media of a completed direct upload to use as the image instead of a file or upload (optional)"
 // @Security BearerAuth
 // @Success 200 {object} response.UpdateUserResponse "Successful update user response"
 // @Failure 400 {object} response.ErrorResponse "Validation error"
 // @Failure 401 {object} response.ErrorResponse "Unauthorized or invalid token"
+// @Failure 507 {object} response.ErrorResponse "Storage quota exceeded"
 // @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /users [patch]
 func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
 	token, err := util.GetToken(c)
 	if err != nil {
-		return response.Error(c.Status(fiber.StatusUnauthorized), "Unauthorized: "+err.Error())
+		return err
 	}
 
 	ctx := c.Context()
 	user, err := h.authService.GetCurrentUser(ctx, token)
 	if err != nil {
 		log.Printf("Unauthorized access: %v", err)
-		return response.Error(c.Status(fiber.StatusUnauthorized), "Unauthorized: "+err.Error())
+		return err
 	}
 
-	username := c.FormValue("username")
-	bio := c.FormValue("bio")
-	imageFile, err := c.FormFile("image")
-	if err != nil && imageFile != nil {
-		log.Printf("Error parsing form data: %v", err)
-		return response.ValidationError(c, "Invalid input")
+	var input request.UpdateUserRequest
+	if err := request.Bind(c, &input); err != nil {
+		return err
 	}
+	username, bio := input.Username, input.Bio
 
-	if username != "" && (len(username) < 3 || len(username) > 50) {
-		return response.ValidationError(c, "Username must be between 3 and 50 characters")
+	file, err := util.OpenFormImage(c, util.ImageOptional)
+	if err != nil {
+		return err
+	}
+	if file != nil {
+		defer file.Close()
 	}
 
-	var imageURL string
-	if imageFile != nil {
-		imageURL, err = util.UploadProfileImage(c, user.ID, "./uploads/profiles/")
-		if err != nil {
-			return response.Error(c.Status(fiber.StatusInternalServerError), "Failed to upload image")
-		}
+	avatar, err := h.mediaService.UploadProfileImage(request.WithLocale(c.UserContext(), request.Locale(c)), user.ID, file, input.UploadID, input.MediaID)
+	if err != nil {
+		return err
 	}
 
 	updatedUser := entity.User{
@@ -120,7 +134,7 @@ func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
 		Name:      username,
 		Email:     user.Email,
 		Bio:       bio,
-		ImageURL:  imageURL,
+		ImageURL:  user.ImageURL,
 		CreatedAt: user.CreatedAt,
 	}
 	
@@ -130,14 +144,15 @@ func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
 	if bio == "" {
 		updatedUser.Bio = user.Bio 
 	}
-	if imageFile == nil {
-		updatedUser.ImageURL = user.ImageURL 
+	if avatar != nil {
+		updatedUser.AvatarID = avatar.ID
+		updatedUser.ImageURL = avatar.URL(entity.RenditionFull)
 	}
 
 	updatedUser, err = h.userService.UpdateUser(user.ID, updatedUser)
 	if err != nil {
 		log.Printf("Error updating user: %v", err)
-		return response.Error(c.Status(fiber.StatusInternalServerError), fmt.Sprintf("Error updating user: %v", err))
+		return err
 	}
 
 	return response.Success(c, util.MapToUserResponse(updatedUser))
@@ -149,30 +164,34 @@ func (h *UserHandler) UpdateUser(c *fiber.Ctx) error {
 // @Tags search
 // @Produce json
 // @Param query query string true "Search query"
+// @Param limit query int false "Page size (max 100)" default(20)
+// @Param cursor query string false "Opaque cursor returned as next_cursor by the previous page"
 // @Success 200 {array} response.SearchUsersResponse "Successful search response"
 // @Failure 400 {object} response.ErrorResponse "Bad request"
 // @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /search/users [get]
 func (h *UserHandler) SearchUsers(c *fiber.Ctx) error {
-	query := c.Query("query")
-	if query == "" {
-		return response.Error(c, "Query parameter is required", fiber.StatusBadRequest)
+	var input request.SearchRequest
+	if err := request.Bind(c, &input); err != nil {
+		return err
 	}
 
-	log.Printf("Received search query: %s", query)
+	log.Printf("Received search query: %s", input.Query)
+
+	page, err := util.ParsePageRequest(c)
+	if err != nil {
+		return err
+	}
 
-	users, err := h.userService.SearchUsers(query)
+	users, pageInfo, err := h.userService.SearchUsers(input.Query, page)
 	if err != nil {
-		if err.Error() == "no users found" {
-			return response.Error(c, "No users found for the given search query", fiber.StatusNotFound)
-		}
-		return response.Error(c, err.Error(), fiber.StatusInternalServerError)
+		return err
 	}
 
-	var userResponses []response.User
+	userResponses := make([]response.User, 0, len(users))
 	for _, user := range users {
 		userResponses = append(userResponses, util.MapToUserResponse(user))
 	}
 
-	return response.Success(c, userResponses)
+	return response.Paginated(c, userResponses, util.EncodeCursor(pageInfo.Next), pageInfo.HasMore)
 }
\ No newline at end of file
@@ -0,0 +1,47 @@
+package job
+
+import (
+	"context"
+	"log"
+	contract "raion-assessment/domain/contract"
+	"time"
+)
+
+// CounterReconciler periodically recomputes the denormalised engagement
+// counters from the source tables to repair any drift left by the triggers.
+type CounterReconciler struct {
+	counterRepo contract.ICounterRepository
+	interval    time.Duration
+}
+
+func NewCounterReconciler(counterRepo contract.ICounterRepository, interval time.Duration) *CounterReconciler {
+	return &CounterReconciler{counterRepo: counterRepo, interval: interval}
+}
+
+// Start runs a reconciliation immediately and then once per interval until
+// the context is cancelled.
+func (j *CounterReconciler) Start(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		j.RunOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (j *CounterReconciler) RunOnce(ctx context.Context) {
+	repaired, err := j.counterRepo.ReconcileCounters(ctx)
+	if err != nil {
+		log.Printf("Counter reconciliation failed: %v", err)
+		return
+	}
+	if repaired > 0 {
+		log.Printf("Counter reconciliation repaired %d rows", repaired)
+	}
+}
@@ -0,0 +1,50 @@
+package job
+
+import (
+	"context"
+	"log"
+	contract "raion-assessment/domain/contract"
+	"time"
+)
+
+// MediaProcessor periodically works through the media waiting to be
+// transcoded. Pending items are claimed in the database, so several app
+// instances can run it side by side.
+type MediaProcessor struct {
+	mediaService contract.IMediaService
+	interval     time.Duration
+}
+
+func NewMediaProcessor(mediaService contract.IMediaService, interval time.Duration) *MediaProcessor {
+	return &MediaProcessor{mediaService: mediaService, interval: interval}
+}
+
+// Start processes pending media immediately and then once per interval until
+// the context is cancelled.
+func (j *MediaProcessor) Start(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		j.RunOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce processes pending media one item at a time until none is left.
+func (j *MediaProcessor) RunOnce(ctx context.Context) {
+	for ctx.Err() == nil {
+		processed, err := j.mediaService.ProcessPendingMedia()
+		if err != nil {
+			log.Printf("Media processing failed: %v", err)
+		}
+		if !processed {
+			return
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package job
+
+import (
+	"context"
+	"log"
+	contract "raion-assessment/domain/contract"
+	"time"
+)
+
+// MediaSweeper periodically deletes media that no post or profile has used
+// for a grace period, such as replaced avatars and the images of deleted
+// posts, along with its files. The grace period leaves time to attach media
+// uploaded ahead of time.
+type MediaSweeper struct {
+	mediaService contract.IMediaService
+	interval     time.Duration
+	gracePeriod  time.Duration
+}
+
+func NewMediaSweeper(mediaService contract.IMediaService, interval, gracePeriod time.Duration) *MediaSweeper {
+	return &MediaSweeper{mediaService: mediaService, interval: interval, gracePeriod: gracePeriod}
+}
+
+// Start sweeps immediately and then once per interval until the context is
+// cancelled.
+func (j *MediaSweeper) Start(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		j.RunOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (j *MediaSweeper) RunOnce(ctx context.Context) {
+	swept, err := j.mediaService.SweepUnreferencedMedia(j.gracePeriod)
+	if err != nil {
+		log.Printf("Media sweep failed: %v", err)
+		return
+	}
+	if swept > 0 {
+		log.Printf("Media sweep removed %d unused media items", swept)
+	}
+}
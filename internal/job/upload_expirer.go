@@ -0,0 +1,47 @@
+package job
+
+import (
+	"context"
+	"log"
+	contract "raion-assessment/domain/contract"
+	"time"
+)
+
+// UploadExpirer periodically deletes resumable and direct uploads that were
+// abandoned or never used, together with what they left in the media store.
+type UploadExpirer struct {
+	uploadService contract.IUploadService
+	interval      time.Duration
+}
+
+func NewUploadExpirer(uploadService contract.IUploadService, interval time.Duration) *UploadExpirer {
+	return &UploadExpirer{uploadService: uploadService, interval: interval}
+}
+
+// Start removes expired uploads immediately and then once per interval until
+// the context is cancelled.
+func (j *UploadExpirer) Start(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		j.RunOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (j *UploadExpirer) RunOnce(ctx context.Context) {
+	expired, err := j.uploadService.ExpireUploads()
+	if err != nil {
+		log.Printf("Upload expiry failed: %v", err)
+		return
+	}
+	if expired > 0 {
+		log.Printf("Upload expiry removed %d uploads", expired)
+	}
+}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"io"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// unreadBodyLimit is how much of a streamed body a handler may leave unread
+// and still have the rest skipped, so the connection can carry the next
+// request. Past it the connection is closed instead.
+const unreadBodyLimit = 256 << 10
+
+// BodyLimit reads request bodies of up to limit bytes into memory and turns
+// larger ones away with 413. The server streams request bodies so that tus
+// chunks can go straight to storage; every other route expects its body read
+// already, and is held to limit here. Requests for which skip returns true are
+// left streaming and must bound what they read themselves.
+func BodyLimit(limit int, skip func(*fiber.Ctx) bool) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		req := c.Request()
+		if !req.IsBodyStream() {
+			return c.Next()
+		}
+		if skip != nil && skip(c) {
+			err := c.Next()
+			discardUnread(c)
+			return err
+		}
+
+		// The server does not skip a body nobody reads, so a request turned
+		// away unread must close the connection.
+		if req.Header.ContentLength() > limit {
+			c.Context().SetConnectionClose()
+			return fiber.ErrRequestEntityTooLarge
+		}
+		body, err := io.ReadAll(io.LimitReader(req.BodyStream(), int64(limit)+1))
+		if err != nil {
+			c.Context().SetConnectionClose()
+			return fiber.NewError(fiber.StatusBadRequest, "Failed to read request body")
+		}
+		if len(body) > limit {
+			c.Context().SetConnectionClose()
+			return fiber.ErrRequestEntityTooLarge
+		}
+		req.SetBody(body)
+		return c.Next()
+	}
+}
+
+// discardUnread skips whatever a handler left of a streamed body, or closes
+// the connection if that is too much or cannot be read.
+func discardUnread(c *fiber.Ctx) {
+	body := c.Request().BodyStream()
+	if body == nil {
+		return
+	}
+	n, err := io.Copy(io.Discard, io.LimitReader(body, unreadBodyLimit+1))
+	if err != nil || n > unreadBodyLimit {
+		c.Context().SetConnectionClose()
+	}
+}
@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestBodyLimit(t *testing.T) {
+	app := fiber.New(fiber.Config{StreamRequestBody: true})
+	app.Use(BodyLimit(8, func(c *fiber.Ctx) bool { return c.Path() == "/stream" }))
+	echo := func(c *fiber.Ctx) error { return c.Send(c.Body()) }
+	app.Post("/echo", echo)
+	app.Post("/stream", func(c *fiber.Ctx) error {
+		n, err := io.Copy(io.Discard, c.Context().RequestBodyStream())
+		if err != nil {
+			return err
+		}
+		return c.SendString(strings.Repeat("x", int(n)))
+	})
+
+	tests := []struct {
+		path    string
+		body    string
+		chunked bool
+		status  int
+		want    string
+	}{
+		{"/echo", "12345678", false, fiber.StatusOK, "12345678"},
+		{"/echo", "123456789", false, fiber.StatusRequestEntityTooLarge, ""},
+		{"/echo", "123456789", true, fiber.StatusRequestEntityTooLarge, ""},
+		{"/stream", "0123456789abcdef", false, fiber.StatusOK, "xxxxxxxxxxxxxxxx"},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+		if tt.chunked {
+			req.ContentLength = -1
+			req.TransferEncoding = []string{"chunked"}
+		}
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		if resp.StatusCode != tt.status || (tt.want != "" && string(body) != tt.want) {
+			t.Errorf("%s with %d bytes: got %d %q, want %d %q", tt.path, len(tt.body), resp.StatusCode, body, tt.status, tt.want)
+		}
+	}
+}
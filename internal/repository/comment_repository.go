@@ -6,7 +6,6 @@ import (
 	contract "raion-assessment/domain/contract"
 	entity "raion-assessment/domain/entity"
 
-	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 )
 
@@ -18,39 +17,63 @@ func NewCommentRepository(db *pgxpool.Pool) contract.ICommentRepository {
     return &commentRepository{db: db}
 }
 
-func (r *commentRepository) GetCommentsByPostID(ctx context.Context, postID string) ([]entity.Comment, error) {
-    query := "SELECT id, user_id, post_id, content, created_at, updated_at FROM comments WHERE post_id = $1 ORDER BY created_at DESC"
-    rows, err := r.db.Query(ctx, query, postID)
+func (r *commentRepository) GetCommentsByPostID(ctx context.Context, postID, viewerID, sort string, page entity.PageRequest) ([]entity.Comment, entity.PageInfo, error) {
+    page = page.Normalized()
+    args := []interface{}{postID, viewerID}
+
+    var after, orderBy string
+    cursorOf := commentCursor
+    if sort == entity.CommentSortTop {
+        after, args = keysetAfterScored(page, "c", "like_count", args)
+        orderBy = "c.like_count DESC, c.created_at DESC, c.id DESC"
+        cursorOf = topCommentCursor
+    } else {
+        after, args = keysetAfter(page, "c", args)
+        orderBy = "c.created_at DESC, c.id DESC"
+    }
+    limit, args := limitArg(page, args)
+
+    query := `
+        SELECT c.id, c.user_id, c.post_id, c.content, c.created_at, c.updated_at, c.like_count,
+               EXISTS (SELECT 1 FROM comment_likes cl WHERE cl.comment_id = c.id AND cl.user_id::text = $2) AS liked_by_me
+        FROM comments c
+        WHERE c.post_id = $1 AND ` + after + `
+        ORDER BY ` + orderBy + `
+        LIMIT ` + limit
+    rows, err := r.db.Query(ctx, query, args...)
     if err != nil {
-        return nil, fmt.Errorf("error fetching comments: %w", err)
+        return nil, entity.PageInfo{}, dbError(err, "error fetching comments", "post")
     }
     defer rows.Close()
 
     var comments []entity.Comment
     for rows.Next() {
         var comment entity.Comment
-        if err := rows.Scan(&comment.ID, &comment.UserID, &comment.PostID, &comment.Content, &comment.CreatedAt, &comment.UpdatedAt); err != nil {
-            return nil, fmt.Errorf("error scanning comment row: %w", err)
+        if err := rows.Scan(&comment.ID, &comment.UserID, &comment.PostID, &comment.Content, &comment.CreatedAt, &comment.UpdatedAt, &comment.LikeCount, &comment.LikedByMe); err != nil {
+            return nil, entity.PageInfo{}, fmt.Errorf("error scanning comment row: %w", err)
         }
         comments = append(comments, comment)
     }
     if err := rows.Err(); err != nil {
-        return nil, fmt.Errorf("error iterating over rows: %w", err)
+        return nil, entity.PageInfo{}, fmt.Errorf("error iterating over rows: %w", err)
     }
-    return comments, nil
+    if len(comments) == 0 {
+        if err := requireParent(ctx, r.db, "posts", postID, "post"); err != nil {
+            return nil, entity.PageInfo{}, err
+        }
+    }
+    comments, pageInfo := trimPage(comments, page, cursorOf)
+    return comments, pageInfo, nil
 }
 
 func (r *commentRepository) GetCommentByID(ctx context.Context, commentID string) (*entity.Comment, error) {
-    query := "SELECT id, user_id, post_id, content, created_at, updated_at FROM comments WHERE id = $1"
+    query := "SELECT id, user_id, post_id, content, created_at, updated_at, like_count FROM comments WHERE id = $1"
     var comment entity.Comment
     err := r.db.QueryRow(ctx, query, commentID).Scan(
-        &comment.ID, &comment.UserID, &comment.PostID, &comment.Content, &comment.CreatedAt, &comment.UpdatedAt,
+        &comment.ID, &comment.UserID, &comment.PostID, &comment.Content, &comment.CreatedAt, &comment.UpdatedAt, &comment.LikeCount,
     )
     if err != nil {
-        if err == pgx.ErrNoRows {
-            return nil, fmt.Errorf("comment not found")
-        }
-        return nil, fmt.Errorf("error retrieving comment: %w", err)
+        return nil, dbError(err, "error retrieving comment", "comment")
     }
     return &comment, nil
 }
@@ -61,7 +84,7 @@ func (r *commentRepository) CreateComment(ctx context.Context, comment entity.Co
         &comment.ID, &comment.CreatedAt, &comment.UpdatedAt,
     )
     if err != nil {
-        return nil, fmt.Errorf("error creating comment: %w", err)
+        return nil, dbError(err, "error creating comment", "comment")
     }
     return &comment, nil
 }
@@ -70,12 +93,87 @@ func (r *commentRepository) DeleteComment(ctx context.Context, commentID string)
     query := "DELETE FROM comments WHERE id = $1"
     result, err := r.db.Exec(ctx, query, commentID)
     if err != nil {
-        return fmt.Errorf("error deleting comment: %w", err)
+        return dbError(err, "error deleting comment", "comment")
     }
 
     rowsAffected := result.RowsAffected()
     if rowsAffected == 0 {
-        return pgx.ErrNoRows
+        return entity.NotFound("comment not found")
     }
     return nil
-}
\ No newline at end of file
+}
+func (r *commentRepository) GetCommentsByIDs(ctx context.Context, commentIDs []string, viewerID string) ([]entity.Comment, error) {
+    if len(commentIDs) == 0 {
+        return []entity.Comment{}, nil
+    }
+
+    query := `
+        SELECT c.id, c.user_id, c.post_id, c.content, c.created_at, c.updated_at, c.like_count,
+               EXISTS (SELECT 1 FROM comment_likes cl WHERE cl.comment_id = c.id AND cl.user_id::text = $2) AS liked_by_me
+        FROM comments c
+        WHERE c.id = ANY($1::uuid[])`
+    rows, err := r.db.Query(ctx, query, commentIDs, viewerID)
+    if err != nil {
+        return nil, fmt.Errorf("error fetching comments by IDs: %w", err)
+    }
+    defer rows.Close()
+
+    var comments []entity.Comment
+    for rows.Next() {
+        var comment entity.Comment
+        if err := rows.Scan(&comment.ID, &comment.UserID, &comment.PostID, &comment.Content, &comment.CreatedAt, &comment.UpdatedAt, &comment.LikeCount, &comment.LikedByMe); err != nil {
+            return nil, fmt.Errorf("error scanning comment row: %w", err)
+        }
+        comments = append(comments, comment)
+    }
+    if err := rows.Err(); err != nil {
+        return nil, fmt.Errorf("error iterating over rows: %w", err)
+    }
+    return comments, nil
+}
+
+// GetCommentsByPostIDs loads the first page of comments for each post in one
+// query, ranking rows per post with a window function.
+func (r *commentRepository) GetCommentsByPostIDs(ctx context.Context, postIDs []string, viewerID, sort string, page entity.PageRequest) (map[string]entity.Page[entity.Comment], error) {
+    if len(postIDs) == 0 {
+        return map[string]entity.Page[entity.Comment]{}, nil
+    }
+
+    page = page.Normalized()
+    orderBy := "c.created_at DESC, c.id DESC"
+    cursorOf := commentCursor
+    if sort == entity.CommentSortTop {
+        orderBy = "c.like_count DESC, c.created_at DESC, c.id DESC"
+        cursorOf = topCommentCursor
+    }
+
+    query := `
+        SELECT id, user_id, post_id, content, created_at, updated_at, like_count, liked_by_me
+        FROM (
+            SELECT c.id, c.user_id, c.post_id, c.content, c.created_at, c.updated_at, c.like_count,
+                   EXISTS (SELECT 1 FROM comment_likes cl WHERE cl.comment_id = c.id AND cl.user_id::text = $2) AS liked_by_me,
+                   ROW_NUMBER() OVER (PARTITION BY c.post_id ORDER BY ` + orderBy + `) AS rn
+            FROM comments c
+            WHERE c.post_id = ANY($1::uuid[])
+        ) ranked
+        WHERE rn <= $3
+        ORDER BY post_id, rn`
+    rows, err := r.db.Query(ctx, query, postIDs, viewerID, page.Limit+1)
+    if err != nil {
+        return nil, fmt.Errorf("error fetching comments for posts: %w", err)
+    }
+    defer rows.Close()
+
+    var comments []entity.Comment
+    for rows.Next() {
+        var comment entity.Comment
+        if err := rows.Scan(&comment.ID, &comment.UserID, &comment.PostID, &comment.Content, &comment.CreatedAt, &comment.UpdatedAt, &comment.LikeCount, &comment.LikedByMe); err != nil {
+            return nil, fmt.Errorf("error scanning comment row: %w", err)
+        }
+        comments = append(comments, comment)
+    }
+    if err := rows.Err(); err != nil {
+        return nil, fmt.Errorf("error iterating over rows: %w", err)
+    }
+    return trimPages(comments, page, func(comment entity.Comment) string { return comment.PostID }, cursorOf), nil
+}
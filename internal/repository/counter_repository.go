@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	contract "raion-assessment/domain/contract"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type counterRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewCounterRepository(db *pgxpool.Pool) contract.ICounterRepository {
+	return &counterRepository{db: db}
+}
+
+var reconcileCounterQueries = []string{
+	`UPDATE posts p SET like_count = c.total
+	 FROM (SELECT p2.id, COUNT(l.id) AS total FROM posts p2 LEFT JOIN likes l ON l.post_id = p2.id GROUP BY p2.id) c
+	 WHERE p.id = c.id AND p.like_count <> c.total`,
+	`UPDATE posts p SET comment_count = c.total
+	 FROM (SELECT p2.id, COUNT(cm.id) AS total FROM posts p2 LEFT JOIN comments cm ON cm.post_id = p2.id GROUP BY p2.id) c
+	 WHERE p.id = c.id AND p.comment_count <> c.total`,
+	`UPDATE comments cm SET like_count = c.total
+	 FROM (SELECT cm2.id, COUNT(cl.id) AS total FROM comments cm2 LEFT JOIN comment_likes cl ON cl.comment_id = cm2.id GROUP BY cm2.id) c
+	 WHERE cm.id = c.id AND cm.like_count <> c.total`,
+	`UPDATE users u SET post_count = c.total
+	 FROM (SELECT u2.id, COUNT(p.id) AS total FROM users u2 LEFT JOIN posts p ON p.user_id = u2.id GROUP BY u2.id) c
+	 WHERE u.id = c.id AND u.post_count <> c.total`,
+	`UPDATE media m SET ref_count = c.total,
+	 unreferenced_at = CASE WHEN c.total = 0 THEN COALESCE(m.unreferenced_at, NOW()) END
+	 FROM (SELECT m2.id, (SELECT COUNT(*) FROM post_media pm WHERE pm.media_id = m2.id) +
+	       (SELECT COUNT(*) FROM users u WHERE u.avatar_media_id = m2.id) AS total FROM media m2) c
+	 WHERE m.id = c.id AND m.ref_count <> c.total`,
+}
+
+// storageDriftQuery finds users whose storage_used no longer matches the
+// media they own. It is only a hint: each one is locked and recounted before
+// it is repaired.
+const storageDriftQuery = `
+	SELECT u.id
+	FROM users u
+	LEFT JOIN media m ON m.owner_id = u.id
+	GROUP BY u.id
+	HAVING u.storage_used <> COALESCE(SUM(m.size_bytes), 0)`
+
+// ReconcileCounters recomputes the denormalised counters and returns how many
+// rows it repaired. Storage usage is repaired one user at a time, under the
+// lock quota checks take, so that it cannot race with media being added.
+func (r *counterRepository) ReconcileCounters(ctx context.Context) (int64, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("error starting reconciliation transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var repaired int64
+	for _, query := range reconcileCounterQueries {
+		result, err := tx.Exec(ctx, query)
+		if err != nil {
+			return 0, fmt.Errorf("error reconciling counters: %w", err)
+		}
+		repaired += result.RowsAffected()
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("error committing reconciliation: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, storageDriftQuery)
+	if err != nil {
+		return 0, fmt.Errorf("error finding storage drift: %w", err)
+	}
+	var userIDs []string
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("error scanning user row: %w", err)
+		}
+		userIDs = append(userIDs, userID)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	for _, userID := range userIDs {
+		fixed, err := r.reconcileStorage(ctx, userID)
+		if err != nil {
+			return repaired, err
+		}
+		repaired += fixed
+	}
+	return repaired, nil
+}
+
+// reconcileStorage recounts the storage a user's media takes up. The user's
+// row is locked first, as reserveStorage does, and the media are summed in a
+// later statement, so the total includes every media row whose insert or
+// resize held that lock before it.
+func (r *counterRepository) reconcileStorage(ctx context.Context, userID string) (int64, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("error starting storage reconciliation transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, "SELECT 1 FROM users WHERE id = $1 FOR UPDATE", userID); err != nil {
+		return 0, fmt.Errorf("error locking user: %w", err)
+	}
+	result, err := tx.Exec(ctx, `
+		UPDATE users u SET storage_used = c.total
+		FROM (SELECT COALESCE(SUM(size_bytes), 0) AS total FROM media WHERE owner_id = $1) c
+		WHERE u.id = $1 AND u.storage_used <> c.total`, userID)
+	if err != nil {
+		return 0, fmt.Errorf("error reconciling storage usage: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("error committing storage reconciliation: %w", err)
+	}
+	return result.RowsAffected(), nil
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	entity "raion-assessment/domain/entity"
+	"strings"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+// PostgreSQL error codes translated by dbError.
+const (
+	pgUniqueViolation           = "23505"
+	pgForeignKeyViolation       = "23503"
+	pgInvalidTextRepresentation = "22P02"
+)
+
+// conflictMessages describes each unique constraint in terms a client can act
+// on. Constraints not listed fall back to "<resource> already exists".
+var conflictMessages = map[string]string{
+	"users_email_key":                      "email is already registered",
+	"posts_user_id_image_url_caption_key":  "an identical post already exists",
+	"likes_user_id_post_id_key":            "post already liked",
+	"comment_likes_user_id_comment_id_key": "comment already liked",
+}
+
+// dbError translates a database error into the domain taxonomy: a missing
+// row or a dangling reference becomes NotFound, a duplicate Conflict and a
+// malformed identifier Validation. Any other error is wrapped with action and
+// left for the caller to report as an internal failure.
+func dbError(err error, action, resource string) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &entity.Error{Kind: entity.ErrNotFound, Message: resource + " not found", Err: err}
+	}
+
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return fmt.Errorf("%s: %w", action, err)
+	}
+
+	switch pgErr.Code {
+	case pgUniqueViolation:
+		message, ok := conflictMessages[pgErr.ConstraintName]
+		if !ok {
+			message = resource + " already exists"
+		}
+		return &entity.Error{Kind: entity.ErrConflict, Message: message, Err: err}
+	case pgForeignKeyViolation:
+		return &entity.Error{Kind: entity.ErrNotFound, Message: referencedResource(pgErr) + " not found", Err: err}
+	case pgInvalidTextRepresentation:
+		return &entity.Error{Kind: entity.ErrValidation, Message: "invalid " + resource + " identifier", Err: err}
+	}
+	return fmt.Errorf("%s: %w", action, err)
+}
+
+// referencedResource names the row a foreign key points at from the
+// constraint's default name, e.g. "likes_post_id_fkey" gives "post".
+func referencedResource(pgErr *pgconn.PgError) string {
+	name := strings.TrimPrefix(pgErr.ConstraintName, pgErr.TableName+"_")
+	name = strings.TrimSuffix(name, "_fkey")
+	name = strings.TrimSuffix(name, "_id")
+	if name == "" {
+		return "referenced record"
+	}
+	return strings.ReplaceAll(name, "_", " ")
+}
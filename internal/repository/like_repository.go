@@ -18,11 +18,19 @@ func NewLikeRepository(db *pgxpool.Pool) contract.ILikeRepository {
 	return &likeRepository{db: db}
 }
 
-func (r *likeRepository) GetLikesByPostID(ctx context.Context, postID string) ([]entity.Like, error) {
-	query := "SELECT id, user_id, post_id, created_at FROM likes WHERE post_id = $1"
-	rows, err := r.db.Query(ctx, query, postID)
+func (r *likeRepository) GetLikesByPostID(ctx context.Context, postID string, page entity.PageRequest) ([]entity.Like, entity.PageInfo, error) {
+	page = page.Normalized()
+	after, args := keysetAfter(page, "l", []interface{}{postID})
+	limit, args := limitArg(page, args)
+	query := `
+		SELECT l.id, l.user_id, l.post_id, l.created_at
+		FROM likes l
+		WHERE l.post_id = $1 AND ` + after + `
+		ORDER BY l.created_at DESC, l.id DESC
+		LIMIT ` + limit
+	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("error fetching likes for post %s: %w", postID, err)
+		return nil, entity.PageInfo{}, dbError(err, "error fetching likes for post "+postID, "post")
 	}
 	defer rows.Close()
 
@@ -30,22 +38,36 @@ func (r *likeRepository) GetLikesByPostID(ctx context.Context, postID string) ([
 	for rows.Next() {
 		var like entity.Like
 		if err := rows.Scan(&like.ID, &like.UserID, &like.PostID, &like.CreatedAt); err != nil {
-			return nil, fmt.Errorf("error scanning like row: %w", err)
+			return nil, entity.PageInfo{}, fmt.Errorf("error scanning like row: %w", err)
 		}
 		likes = append(likes, like)
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating over rows: %w", err)
+		return nil, entity.PageInfo{}, fmt.Errorf("error iterating over rows: %w", err)
+	}
+	if len(likes) == 0 {
+		if err := requireParent(ctx, r.db, "posts", postID, "post"); err != nil {
+			return nil, entity.PageInfo{}, err
+		}
 	}
-	return likes, nil
+	likes, pageInfo := trimPage(likes, page, likeCursor)
+	return likes, pageInfo, nil
 }
 
-func (r *likeRepository) GetLikesByUserID(ctx context.Context, userID string) ([]entity.Like, error) {
-	query := "SELECT id, user_id, post_id, created_at FROM likes WHERE user_id = $1"
-	rows, err := r.db.Query(ctx, query, userID)
+func (r *likeRepository) GetLikesByUserID(ctx context.Context, userID string, page entity.PageRequest) ([]entity.Like, entity.PageInfo, error) {
+	page = page.Normalized()
+	after, args := keysetAfter(page, "l", []interface{}{userID})
+	limit, args := limitArg(page, args)
+	query := `
+		SELECT l.id, l.user_id, l.post_id, l.created_at
+		FROM likes l
+		WHERE l.user_id = $1 AND ` + after + `
+		ORDER BY l.created_at DESC, l.id DESC
+		LIMIT ` + limit
+	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("error fetching likes for user %s: %w", userID, err)
+		return nil, entity.PageInfo{}, dbError(err, "error fetching likes for user "+userID, "user")
 	}
 	defer rows.Close()
 
@@ -53,22 +75,28 @@ func (r *likeRepository) GetLikesByUserID(ctx context.Context, userID string) ([
 	for rows.Next() {
 		var like entity.Like
 		if err := rows.Scan(&like.ID, &like.UserID, &like.PostID, &like.CreatedAt); err != nil {
-			return nil, fmt.Errorf("error scanning like row: %w", err)
+			return nil, entity.PageInfo{}, fmt.Errorf("error scanning like row: %w", err)
 		}
 		likes = append(likes, like)
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating over rows: %w", err)
+		return nil, entity.PageInfo{}, fmt.Errorf("error iterating over rows: %w", err)
+	}
+	if len(likes) == 0 {
+		if err := requireParent(ctx, r.db, "users", userID, "user"); err != nil {
+			return nil, entity.PageInfo{}, err
+		}
 	}
-	return likes, nil
+	likes, pageInfo := trimPage(likes, page, likeCursor)
+	return likes, pageInfo, nil
 }
 
 func (r *likeRepository) AddLike(ctx context.Context, like entity.Like) (*entity.Like, error) {
 	query := "INSERT INTO likes (user_id, post_id) VALUES ($1, $2) RETURNING id, created_at"
 	err := r.db.QueryRow(ctx, query, like.UserID, like.PostID).Scan(&like.ID, &like.CreatedAt)
 	if err != nil {
-		return nil, fmt.Errorf("error adding like: %w", err)
+		return nil, dbError(err, "error adding like", "like")
 	}
 	like.CreatedAt = time.Now()
 	return &like, nil
@@ -78,12 +106,111 @@ func (r *likeRepository) RemoveLike(ctx context.Context, userID, postID string)
 	query := "DELETE FROM likes WHERE user_id = $1 AND post_id = $2"
 	result, err := r.db.Exec(ctx, query, userID, postID)
 	if err != nil {
-		return fmt.Errorf("error removing like: %w", err)
+		return dbError(err, "error removing like", "like")
+	}
+
+	rowsAffected := result.RowsAffected()
+	if rowsAffected == 0 {
+		return entity.NotFound("like not found")
+	}
+	return nil
+}
+
+func (r *likeRepository) GetLikesByCommentID(ctx context.Context, commentID string, page entity.PageRequest) ([]entity.CommentLike, entity.PageInfo, error) {
+	page = page.Normalized()
+	after, args := keysetAfter(page, "l", []interface{}{commentID})
+	limit, args := limitArg(page, args)
+	query := `
+		SELECT l.id, l.user_id, l.comment_id, l.created_at
+		FROM comment_likes l
+		WHERE l.comment_id = $1 AND ` + after + `
+		ORDER BY l.created_at DESC, l.id DESC
+		LIMIT ` + limit
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, entity.PageInfo{}, dbError(err, "error fetching likes for comment "+commentID, "comment")
+	}
+	defer rows.Close()
+
+	var likes []entity.CommentLike
+	for rows.Next() {
+		var like entity.CommentLike
+		if err := rows.Scan(&like.ID, &like.UserID, &like.CommentID, &like.CreatedAt); err != nil {
+			return nil, entity.PageInfo{}, fmt.Errorf("error scanning comment like row: %w", err)
+		}
+		likes = append(likes, like)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, entity.PageInfo{}, fmt.Errorf("error iterating over rows: %w", err)
+	}
+	if len(likes) == 0 {
+		if err := requireParent(ctx, r.db, "comments", commentID, "comment"); err != nil {
+			return nil, entity.PageInfo{}, err
+		}
+	}
+	likes, pageInfo := trimPage(likes, page, commentLikeCursor)
+	return likes, pageInfo, nil
+}
+
+func (r *likeRepository) AddCommentLike(ctx context.Context, like entity.CommentLike) (*entity.CommentLike, error) {
+	query := "INSERT INTO comment_likes (user_id, comment_id) VALUES ($1, $2) RETURNING id, created_at"
+	err := r.db.QueryRow(ctx, query, like.UserID, like.CommentID).Scan(&like.ID, &like.CreatedAt)
+	if err != nil {
+		return nil, dbError(err, "error adding comment like", "comment like")
+	}
+	return &like, nil
+}
+
+func (r *likeRepository) RemoveCommentLike(ctx context.Context, userID, commentID string) error {
+	query := "DELETE FROM comment_likes WHERE user_id = $1 AND comment_id = $2"
+	result, err := r.db.Exec(ctx, query, userID, commentID)
+	if err != nil {
+		return dbError(err, "error removing comment like", "comment like")
 	}
 
 	rowsAffected := result.RowsAffected()
 	if rowsAffected == 0 {
-		return fmt.Errorf("like not found for user %s and post %s", userID, postID)
+		return entity.NotFound("comment like not found")
 	}
 	return nil
 }
+
+// GetLikesByPostIDs loads the first page of likes for each post in one query,
+// ranking rows per post with a window function.
+func (r *likeRepository) GetLikesByPostIDs(ctx context.Context, postIDs []string, page entity.PageRequest) (map[string]entity.Page[entity.Like], error) {
+	if len(postIDs) == 0 {
+		return map[string]entity.Page[entity.Like]{}, nil
+	}
+
+	page = page.Normalized()
+	query := `
+		SELECT id, user_id, post_id, created_at
+		FROM (
+			SELECT l.id, l.user_id, l.post_id, l.created_at,
+				ROW_NUMBER() OVER (PARTITION BY l.post_id ORDER BY l.created_at DESC, l.id DESC) AS rn
+			FROM likes l
+			WHERE l.post_id = ANY($1::uuid[])
+		) ranked
+		WHERE rn <= $2
+		ORDER BY post_id, rn
+	`
+	rows, err := r.db.Query(ctx, query, postIDs, page.Limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching likes for posts: %w", err)
+	}
+	defer rows.Close()
+
+	var likes []entity.Like
+	for rows.Next() {
+		var like entity.Like
+		if err := rows.Scan(&like.ID, &like.UserID, &like.PostID, &like.CreatedAt); err != nil {
+			return nil, fmt.Errorf("error scanning like row: %w", err)
+		}
+		likes = append(likes, like)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+	return trimPages(likes, page, func(like entity.Like) string { return like.PostID }, likeCursor), nil
+}
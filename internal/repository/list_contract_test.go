@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"raion-assessment/config"
+	entity "raion-assessment/domain/entity"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// testDB connects to the database in TEST_DATABASE_URL and migrates it. Tests
+// that need PostgreSQL are skipped when it is not set.
+func testDB(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+	databaseURL := os.Getenv("TEST_DATABASE_URL")
+	if databaseURL == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+	db, err := pgxpool.Connect(context.Background(), databaseURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(db.Close)
+	if err := config.RunSQLMigrations(db); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+// createUser adds a user with no posts, likes or comments and deletes it,
+// with everything that belongs to it, when the test ends.
+func createUser(t *testing.T, db *pgxpool.Pool) string {
+	t.Helper()
+	var userID string
+	err := db.QueryRow(context.Background(), `
+		INSERT INTO users (name, email, password_hash, image_url, bio, created_at, updated_at)
+		VALUES ('list-contract', $1, '', '', '', NOW(), NOW()) RETURNING id`,
+		uuid.NewString()+"@example.com").Scan(&userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Exec(context.Background(), "DELETE FROM users WHERE id = $1", userID) })
+	return userID
+}
+
+func TestListsDistinguishEmptyFromMissingParents(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	authorID, readerID := createUser(t, db), createUser(t, db)
+	var postID, commentID string
+	err := db.QueryRow(ctx, "INSERT INTO posts (user_id, image_url, caption) VALUES ($1, '', 'no likes yet') RETURNING id", authorID).Scan(&postID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = db.QueryRow(ctx, "INSERT INTO comments (user_id, post_id, content) VALUES ($1, $2, 'no likes yet') RETURNING id", authorID, postID).Scan(&commentID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	page := entity.PageRequest{}
+
+	comments := NewCommentRepository(db)
+	likes := NewLikeRepository(db)
+	posts := NewPostRepository(db)
+
+	lists := []struct {
+		name     string
+		parentID string
+		list     func(parentID string) (interface{}, error)
+	}{
+		{"comments of a post", postID, func(id string) (interface{}, error) {
+			items, _, err := comments.GetCommentsByPostID(ctx, id, "", entity.CommentSortNewest, page)
+			return items, err
+		}},
+		{"likes of a post", postID, func(id string) (interface{}, error) {
+			items, _, err := likes.GetLikesByPostID(ctx, id, page)
+			return items, err
+		}},
+		{"likes by a user", readerID, func(id string) (interface{}, error) {
+			items, _, err := likes.GetLikesByUserID(ctx, id, page)
+			return items, err
+		}},
+		{"likes of a comment", commentID, func(id string) (interface{}, error) {
+			items, _, err := likes.GetLikesByCommentID(ctx, id, page)
+			return items, err
+		}},
+		{"posts by a user", readerID, func(id string) (interface{}, error) {
+			items, _, err := posts.FetchPostsByUserID(ctx, id, page)
+			return items, err
+		}},
+	}
+	for _, list := range lists {
+		t.Run(list.name, func(t *testing.T) {
+			items, err := list.list(list.parentID)
+			if err != nil {
+				t.Fatalf("existing parent without children: %v", err)
+			}
+			if encoded, _ := json.Marshal(items); string(encoded) != "[]" {
+				t.Fatalf("existing parent without children: got %s, want []", encoded)
+			}
+			if _, err := list.list(uuid.NewString()); !errors.Is(err, entity.ErrNotFound) {
+				t.Fatalf("unknown parent: got %v, want a not-found error", err)
+			}
+		})
+	}
+}
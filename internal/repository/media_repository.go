@@ -0,0 +1,299 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	contract "raion-assessment/domain/contract"
+	entity "raion-assessment/domain/entity"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type mediaRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewMediaRepository(db *pgxpool.Pool) contract.IMediaRepository {
+	return &mediaRepository{db: db}
+}
+
+// CreateMedia records media, provided its size fits in what is left of the
+// owner's quota.
+func (r *mediaRepository) CreateMedia(ctx context.Context, media entity.Media, quota int64) (*entity.Media, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error starting media transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := reserveStorage(ctx, tx, media.OwnerID, media.SizeBytes, quota, false); err != nil {
+		return nil, err
+	}
+
+	query := `
+		INSERT INTO media (id, owner_id, purpose, kind, status, content_type, width, height, duration_ms,
+			blurhash, dominant_color, renditions, size_bytes, source_key)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, NULLIF($14, ''))
+		RETURNING created_at`
+	err = tx.QueryRow(ctx, query, media.ID, media.OwnerID, media.Purpose, media.Kind, media.Status, media.ContentType,
+		media.Width, media.Height, media.DurationMS, media.BlurHash, media.DominantColor, media.Renditions,
+		media.SizeBytes, media.SourceKey).
+		Scan(&media.CreatedAt)
+	if err != nil {
+		return nil, dbError(err, "error creating media", "media")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("error committing media: %w", err)
+	}
+	return &media, nil
+}
+
+// ClaimPendingMedia takes the oldest pending media item for processing and
+// counts the attempt. Claims not released within 15 minutes, such as those of
+// a worker that crashed, can be taken again. It returns nil when nothing is
+// waiting.
+func (r *mediaRepository) ClaimPendingMedia(ctx context.Context) (*entity.Media, error) {
+	query := `
+		UPDATE media SET attempts = attempts + 1, claimed_at = NOW()
+		WHERE id = (
+			SELECT id FROM media
+			WHERE status = 'pending' AND (claimed_at IS NULL OR claimed_at < NOW() - INTERVAL '15 minutes')
+			ORDER BY created_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, owner_id, purpose, kind, status, content_type, duration_ms, COALESCE(source_key, ''), attempts, created_at`
+	var media entity.Media
+	err := r.db.QueryRow(ctx, query).Scan(&media.ID, &media.OwnerID, &media.Purpose, &media.Kind, &media.Status, &media.ContentType,
+		&media.DurationMS, &media.SourceKey, &media.Attempts, &media.CreatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error claiming pending media: %w", err)
+	}
+	return &media, nil
+}
+
+// CompleteMedia records the result of processing and marks the item ready.
+// Its size becomes that of the renditions, the source being deleted next.
+// Whatever that adds to the size of the source must fit in the owner's quota,
+// checked under the same lock as new media; otherwise nothing is recorded and
+// a quota error is returned. It is a not-found error if the item was deleted
+// while it was being processed.
+func (r *mediaRepository) CompleteMedia(ctx context.Context, media entity.Media, quota int64) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("error starting media transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// The media row is locked before the owner's, in the order deleting
+	// media takes them.
+	var ownerID string
+	var sizeBytes int64
+	err = tx.QueryRow(ctx, "SELECT owner_id, size_bytes FROM media WHERE id = $1 FOR UPDATE", media.ID).Scan(&ownerID, &sizeBytes)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return entity.NotFound("media not found")
+		}
+		return fmt.Errorf("error locking media: %w", err)
+	}
+	if growth := media.SizeBytes - sizeBytes; growth > 0 {
+		if err := reserveStorage(ctx, tx, ownerID, growth, quota, false); err != nil {
+			return err
+		}
+	}
+
+	query := `
+		UPDATE media
+		SET status = 'ready', width = $2, height = $3, blurhash = $4, dominant_color = $5, renditions = $6,
+			size_bytes = $7, source_key = NULL, claimed_at = NULL, error = ''
+		WHERE id = $1`
+	_, err = tx.Exec(ctx, query, media.ID, media.Width, media.Height, media.BlurHash, media.DominantColor, media.Renditions,
+		media.SizeBytes)
+	if err != nil {
+		return fmt.Errorf("error completing media: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error committing media: %w", err)
+	}
+	return nil
+}
+
+// ReleaseMedia gives up a claim after processing failed, recording why. The
+// item is retried later unless failed is set.
+func (r *mediaRepository) ReleaseMedia(ctx context.Context, mediaID, message string, failed bool) error {
+	query := `
+		UPDATE media
+		SET claimed_at = NULL, error = $2, status = CASE WHEN $3 THEN 'failed' ELSE status END
+		WHERE id = $1`
+	_, err := r.db.Exec(ctx, query, mediaID, message, failed)
+	if err != nil {
+		return fmt.Errorf("error releasing media: %w", err)
+	}
+	return nil
+}
+
+// FetchPostMedia returns the images of each of the given posts in display
+// order, keyed by post ID. Posts without images are left out.
+func (r *mediaRepository) FetchPostMedia(ctx context.Context, postIDs []string) (map[string][]entity.PostMedia, error) {
+	query := `
+		SELECT pm.post_id, pm.position, pm.alt_text, ` + mediaColumns + `
+		FROM post_media pm
+		JOIN media m ON m.id = pm.media_id
+		WHERE pm.post_id = ANY($1::uuid[])
+		ORDER BY pm.post_id, pm.position`
+	rows, err := r.db.Query(ctx, query, postIDs)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching post media: %w", err)
+	}
+	defer rows.Close()
+
+	media := make(map[string][]entity.PostMedia)
+	for rows.Next() {
+		var postID string
+		var item entity.PostMedia
+		dest := append([]interface{}{&postID, &item.Position, &item.AltText}, mediaFields(&item.Media)...)
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("error scanning post media row: %w", err)
+		}
+		media[postID] = append(media[postID], item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+	return media, nil
+}
+
+// FetchMedia returns the media items with the given IDs, keyed by ID. IDs
+// without media are left out.
+func (r *mediaRepository) FetchMedia(ctx context.Context, ids []string) (map[string]entity.Media, error) {
+	query := `SELECT ` + mediaColumns + ` FROM media m WHERE m.id = ANY($1::uuid[])`
+	rows, err := r.db.Query(ctx, query, ids)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching media: %w", err)
+	}
+	defer rows.Close()
+
+	media := make(map[string]entity.Media)
+	for rows.Next() {
+		var item entity.Media
+		if err := rows.Scan(mediaFields(&item)...); err != nil {
+			return nil, fmt.Errorf("error scanning media row: %w", err)
+		}
+		media[item.ID] = item
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+	return media, nil
+}
+
+// FetchUserAvatars returns the avatar of each of the given users, keyed by
+// user ID. Users without an uploaded avatar are left out.
+func (r *mediaRepository) FetchUserAvatars(ctx context.Context, userIDs []string) (map[string]entity.Media, error) {
+	query := `
+		SELECT u.id, ` + mediaColumns + `
+		FROM users u
+		JOIN media m ON m.id = u.avatar_media_id
+		WHERE u.id = ANY($1::uuid[])`
+	rows, err := r.db.Query(ctx, query, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching user avatars: %w", err)
+	}
+	defer rows.Close()
+
+	media := make(map[string]entity.Media)
+	for rows.Next() {
+		var userID string
+		var item entity.Media
+		if err := rows.Scan(append([]interface{}{&userID}, mediaFields(&item)...)...); err != nil {
+			return nil, fmt.Errorf("error scanning media row: %w", err)
+		}
+		media[userID] = item
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+	return media, nil
+}
+
+// DeleteUnreferencedMedia removes up to limit media items that no post or
+// profile has used for at least unusedFor and returns them, so their files can
+// be deleted. References are checked again rather than trusting ref_count
+// alone. Pending videos are left to the worker and collected once processed.
+func (r *mediaRepository) DeleteUnreferencedMedia(ctx context.Context, unusedFor time.Duration, limit int) ([]entity.Media, error) {
+	query := `
+		DELETE FROM media m
+		WHERE m.id IN (
+			SELECT id FROM media
+			WHERE ref_count = 0 AND status <> 'pending'
+				AND unreferenced_at <= NOW() - make_interval(secs => $1)
+			ORDER BY unreferenced_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		AND m.ref_count = 0
+		AND NOT EXISTS (SELECT 1 FROM post_media pm WHERE pm.media_id = m.id)
+		AND NOT EXISTS (SELECT 1 FROM users u WHERE u.avatar_media_id = m.id)
+		RETURNING ` + mediaColumns + `, COALESCE(m.source_key, '')`
+	return r.deleteMedia(ctx, query, unusedFor.Seconds(), limit)
+}
+
+// deleteMedia runs a DELETE returning mediaColumns and the source key, and
+// collects the deleted items.
+func (r *mediaRepository) deleteMedia(ctx context.Context, query string, args ...interface{}) ([]entity.Media, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error deleting media: %w", err)
+	}
+	defer rows.Close()
+
+	var media []entity.Media
+	for rows.Next() {
+		var item entity.Media
+		if err := rows.Scan(append(mediaFields(&item), &item.SourceKey)...); err != nil {
+			return nil, fmt.Errorf("error scanning media row: %w", err)
+		}
+		media = append(media, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+	return media, nil
+}
+
+// DeleteOwnerMedia removes every media item the user owns and returns them,
+// so their files can be deleted before the user is.
+func (r *mediaRepository) DeleteOwnerMedia(ctx context.Context, ownerID string) ([]entity.Media, error) {
+	query := `DELETE FROM media m WHERE m.owner_id = $1 RETURNING ` + mediaColumns + `, COALESCE(m.source_key, '')`
+	return r.deleteMedia(ctx, query, ownerID)
+}
+
+// FetchStorageUsage returns how much the user's media takes up and the
+// declared size of the resumable and direct uploads they have in progress.
+// The quota is left for the caller to fill in.
+func (r *mediaRepository) FetchStorageUsage(ctx context.Context, ownerID string) (entity.StorageUsage, error) {
+	var usage entity.StorageUsage
+	err := r.db.QueryRow(ctx, storageUsageQuery, ownerID).Scan(&usage.Used, &usage.Reserved)
+	if err != nil && err != pgx.ErrNoRows {
+		return entity.StorageUsage{}, fmt.Errorf("error fetching storage usage: %w", err)
+	}
+	return usage, nil
+}
+
+const mediaColumns = "m.id, m.owner_id, m.purpose, m.kind, m.status, m.content_type, m.width, m.height, m.duration_ms, " +
+	"m.blurhash, m.dominant_color, m.renditions, m.size_bytes, m.created_at"
+
+// mediaFields returns the scan destinations matching mediaColumns.
+func mediaFields(media *entity.Media) []interface{} {
+	return []interface{}{
+		&media.ID, &media.OwnerID, &media.Purpose, &media.Kind, &media.Status, &media.ContentType, &media.Width, &media.Height,
+		&media.DurationMS, &media.BlurHash, &media.DominantColor, &media.Renditions, &media.SizeBytes, &media.CreatedAt,
+	}
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	entity "raion-assessment/domain/entity"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// keysetAfter returns a row-comparison predicate selecting the rows that sort
+// after the page cursor in (created_at DESC, id DESC) order, appending its
+// parameters to args.
+func keysetAfter(page entity.PageRequest, alias string, args []interface{}) (string, []interface{}) {
+	if page.After == nil {
+		return "TRUE", args
+	}
+	args = append(args, page.After.CreatedAt, page.After.ID)
+	return fmt.Sprintf("(%s.created_at, %s.id) < ($%d, $%d)", alias, alias, len(args)-1, len(args)), args
+}
+
+// keysetAfterScored is keysetAfter for orderings ranked by a counter column
+// first, i.e. (score DESC, created_at DESC, id DESC).
+func keysetAfterScored(page entity.PageRequest, alias, scoreColumn string, args []interface{}) (string, []interface{}) {
+	if page.After == nil {
+		return "TRUE", args
+	}
+	args = append(args, page.After.Score, page.After.CreatedAt, page.After.ID)
+	return fmt.Sprintf("(%s.%s, %s.created_at, %s.id) < ($%d, $%d, $%d)", alias, scoreColumn, alias, alias, len(args)-2, len(args)-1, len(args)), args
+}
+
+// requireParent reports NotFound when table has no row with the given id.
+// List queries call it when they come back empty, so that an empty page of an
+// existing parent and an unknown parent can be told apart.
+func requireParent(ctx context.Context, db *pgxpool.Pool, table, id, resource string) error {
+	var exists bool
+	err := db.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM "+table+" WHERE id = $1)", id).Scan(&exists)
+	if err != nil {
+		return dbError(err, "error checking "+resource, resource)
+	}
+	if !exists {
+		return entity.NotFound(resource + " not found")
+	}
+	return nil
+}
+
+// limitArg appends the row limit for a page, fetching one extra row so that
+// trimPage can tell whether another page follows.
+func limitArg(page entity.PageRequest, args []interface{}) (string, []interface{}) {
+	args = append(args, page.Limit+1)
+	return fmt.Sprintf("$%d", len(args)), args
+}
+
+// trimPage drops the extra row fetched by limitArg and reports the cursor for
+// the next page. An empty page is returned as a non-nil slice so that it is
+// rendered as [] rather than null.
+func trimPage[T any](items []T, page entity.PageRequest, cursorOf func(T) entity.Cursor) ([]T, entity.PageInfo) {
+	if items == nil {
+		items = []T{}
+	}
+	if len(items) <= page.Limit {
+		return items, entity.PageInfo{}
+	}
+	items = items[:page.Limit]
+	next := cursorOf(items[len(items)-1])
+	return items, entity.PageInfo{HasMore: true, Next: &next}
+}
+
+func postCursor(post entity.Post) entity.Cursor {
+	return entity.Cursor{CreatedAt: post.CreatedAt, ID: post.ID}
+}
+
+func userCursor(user entity.User) entity.Cursor {
+	return entity.Cursor{CreatedAt: user.CreatedAt, ID: user.ID}
+}
+
+func commentCursor(comment entity.Comment) entity.Cursor {
+	return entity.Cursor{CreatedAt: comment.CreatedAt, ID: comment.ID}
+}
+
+func topCommentCursor(comment entity.Comment) entity.Cursor {
+	return entity.Cursor{Score: comment.LikeCount, CreatedAt: comment.CreatedAt, ID: comment.ID}
+}
+
+func likeCursor(like entity.Like) entity.Cursor {
+	return entity.Cursor{CreatedAt: like.CreatedAt, ID: like.ID}
+}
+
+func commentLikeCursor(like entity.CommentLike) entity.Cursor {
+	return entity.Cursor{CreatedAt: like.CreatedAt, ID: like.ID}
+}
+
+// trimPages splits rows fetched for several parents, at most limit+1 per
+// parent, into one trimmed page per parent ID.
+func trimPages[T any](items []T, page entity.PageRequest, parentOf func(T) string, cursorOf func(T) entity.Cursor) map[string]entity.Page[T] {
+	grouped := make(map[string][]T)
+	for _, item := range items {
+		parent := parentOf(item)
+		grouped[parent] = append(grouped[parent], item)
+	}
+
+	pages := make(map[string]entity.Page[T], len(grouped))
+	for parent, rows := range grouped {
+		rows, pageInfo := trimPage(rows, page, cursorOf)
+		pages[parent] = entity.Page[T]{Items: rows, PageInfo: pageInfo}
+	}
+	return pages
+}
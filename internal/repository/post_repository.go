@@ -19,133 +19,309 @@ func NewPostRepository(db *pgxpool.Pool) contract.IPostRepository {
 	return &postRepository{db: db}
 }
 
-func (r *postRepository) FetchAllPosts(ctx context.Context) ([]entity.Post, error) {
-	query := "SELECT id, user_id, caption, image_url, created_at, updated_at FROM posts"
-	rows, err := r.db.Query(ctx, query)
+func (r *postRepository) FetchAllPosts(ctx context.Context, page entity.PageRequest) ([]entity.Post, entity.PageInfo, error) {
+	page = page.Normalized()
+	after, args := keysetAfter(page, "p", nil)
+	limit, args := limitArg(page, args)
+	query := `
+		SELECT p.id, p.user_id, p.caption, p.image_url, p.like_count, p.comment_count, p.created_at, p.updated_at
+		FROM posts p
+		WHERE ` + after + `
+		ORDER BY p.created_at DESC, p.id DESC
+		LIMIT ` + limit
+	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("error fetching posts: %w", err)
+		return nil, entity.PageInfo{}, fmt.Errorf("error fetching posts: %w", err)
 	}
 	defer rows.Close()
 
 	var posts []entity.Post
 	for rows.Next() {
 		var post entity.Post
-		if err := rows.Scan(&post.ID, &post.UserID, &post.Caption, &post.ImageURL, &post.CreatedAt, &post.UpdatedAt); err != nil {
-			return nil, fmt.Errorf("error scanning post row: %w", err)
+		if err := rows.Scan(&post.ID, &post.UserID, &post.Caption, &post.ImageURL, &post.LikeCount, &post.CommentCount, &post.CreatedAt, &post.UpdatedAt); err != nil {
+			return nil, entity.PageInfo{}, fmt.Errorf("error scanning post row: %w", err)
 		}
 		posts = append(posts, post)
 	}
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating over rows: %w", err)
+		return nil, entity.PageInfo{}, fmt.Errorf("error iterating over rows: %w", err)
 	}
-	return posts, nil
+	posts, pageInfo := trimPage(posts, page, postCursor)
+	return posts, pageInfo, nil
 }
 
 func (r *postRepository) FetchPostByID(ctx context.Context, postID string) (*entity.Post, error) {
-	query := "SELECT id, user_id, caption, image_url, created_at, updated_at FROM posts WHERE id = $1"
+	query := "SELECT id, user_id, caption, image_url, like_count, comment_count, created_at, updated_at FROM posts WHERE id = $1"
 	row := r.db.QueryRow(ctx, query, postID)
 
 	var post entity.Post
-	if err := row.Scan(&post.ID, &post.UserID, &post.Caption, &post.ImageURL, &post.CreatedAt, &post.UpdatedAt); err != nil {
+	if err := row.Scan(&post.ID, &post.UserID, &post.Caption, &post.ImageURL, &post.LikeCount, &post.CommentCount, &post.CreatedAt, &post.UpdatedAt); err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, nil 
 		}
-		return nil, fmt.Errorf("error fetching post by ID: %w", err)
+		return nil, dbError(err, "error fetching post by ID", "post")
 	}
 	return &post, nil
 }
 
-func (r *postRepository) FetchPostsByUserID(ctx context.Context, userID string) ([]entity.Post, error) {
-	query := "SELECT id, user_id, caption, image_url, created_at, updated_at FROM posts WHERE user_id = $1"
-	rows, err := r.db.Query(ctx, query, userID)
+func (r *postRepository) FetchPostsByUserID(ctx context.Context, userID string, page entity.PageRequest) ([]entity.Post, entity.PageInfo, error) {
+	page = page.Normalized()
+	after, args := keysetAfter(page, "p", []interface{}{userID})
+	limit, args := limitArg(page, args)
+	query := `
+		SELECT p.id, p.user_id, p.caption, p.image_url, p.like_count, p.comment_count, p.created_at, p.updated_at
+		FROM posts p
+		WHERE p.user_id = $1 AND ` + after + `
+		ORDER BY p.created_at DESC, p.id DESC
+		LIMIT ` + limit
+	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("error fetching posts for user %s: %w", userID, err)
+		return nil, entity.PageInfo{}, dbError(err, "error fetching posts for user "+userID, "user")
 	}
 	defer rows.Close()
 
 	var posts []entity.Post
 	for rows.Next() {
 		var post entity.Post
-		if err := rows.Scan(&post.ID, &post.UserID, &post.Caption, &post.ImageURL, &post.CreatedAt, &post.UpdatedAt); err != nil {
-			return nil, fmt.Errorf("error scanning post row: %w", err)
+		if err := rows.Scan(&post.ID, &post.UserID, &post.Caption, &post.ImageURL, &post.LikeCount, &post.CommentCount, &post.CreatedAt, &post.UpdatedAt); err != nil {
+			return nil, entity.PageInfo{}, fmt.Errorf("error scanning post row: %w", err)
 		}
 		posts = append(posts, post)
 	}
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating over rows: %w", err)
+		return nil, entity.PageInfo{}, fmt.Errorf("error iterating over rows: %w", err)
 	}
-	return posts, nil
+	if len(posts) == 0 {
+		if err := requireParent(ctx, r.db, "users", userID, "user"); err != nil {
+			return nil, entity.PageInfo{}, err
+		}
+	}
+	posts, pageInfo := trimPage(posts, page, postCursor)
+	return posts, pageInfo, nil
 }
 
 func (r *postRepository) CreatePost(ctx context.Context, post entity.Post) (*entity.Post, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error starting post transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
 	query := "INSERT INTO posts (user_id, caption, image_url) VALUES ($1, $2, $3) RETURNING id, created_at, updated_at"
-	err := r.db.QueryRow(ctx, query, post.UserID, post.Caption, post.ImageURL).Scan(
+	err = tx.QueryRow(ctx, query, post.UserID, post.Caption, post.ImageURL).Scan(
 		&post.ID, &post.CreatedAt, &post.UpdatedAt,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("error creating post: %w", err)
+		return nil, dbError(err, "error creating post", "post")
+	}
+	if err := insertPostMedia(ctx, tx, post.ID, post.Media); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("error committing post: %w", err)
 	}
 	post.CreatedAt = time.Now()
 	post.UpdatedAt = post.CreatedAt
 	return &post, nil
 }
 
-func (r *postRepository) UpdatePost(ctx context.Context, postID string, post entity.Post) (*entity.Post, error) {
-	query := "UPDATE posts SET caption = $1, image_url = $2, updated_at = NOW() WHERE id = $3 RETURNING id, user_id, caption, image_url, created_at, updated_at"
-	err := r.db.QueryRow(ctx, query, post.Caption, post.ImageURL, postID).Scan(
-		&post.ID, &post.UserID, &post.Caption, &post.ImageURL, &post.CreatedAt, &post.UpdatedAt,
-	)
+// EditPost changes the caption of a post, swaps its images for media, in
+// order, or both, in one transaction. A nil caption or nil media is left as
+// it is; new media also sets image_url, which follows the first image.
+func (r *postRepository) EditPost(ctx context.Context, postID string, caption *string, media []entity.PostMedia, imageURL string) error {
+	tx, err := r.db.Begin(ctx)
 	if err != nil {
-		if err == pgx.ErrNoRows {
-			return nil, nil
+		return fmt.Errorf("error starting post edit transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var newImageURL *string
+	if media != nil {
+		newImageURL = &imageURL
+	}
+	result, err := tx.Exec(ctx,
+		"UPDATE posts SET caption = COALESCE($1, caption), image_url = COALESCE($2, image_url), updated_at = NOW() WHERE id = $3",
+		caption, newImageURL, postID)
+	if err != nil {
+		return dbError(err, "error updating post", "post")
+	}
+	if result.RowsAffected() == 0 {
+		return entity.NotFound("post not found")
+	}
+	if media != nil {
+		if _, err := tx.Exec(ctx, "DELETE FROM post_media WHERE post_id = $1", postID); err != nil {
+			return dbError(err, "error removing post media", "post media")
+		}
+		if err := insertPostMedia(ctx, tx, postID, media); err != nil {
+			return err
 		}
-		return nil, fmt.Errorf("error updating post: %w", err)
 	}
-	return &post, nil
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error committing post edit: %w", err)
+	}
+	return nil
+}
+
+// insertPostMedia attaches media to a post, numbering positions from 0 in
+// slice order.
+func insertPostMedia(ctx context.Context, tx pgx.Tx, postID string, media []entity.PostMedia) error {
+	for i, item := range media {
+		_, err := tx.Exec(ctx,
+			"INSERT INTO post_media (post_id, media_id, position, alt_text) VALUES ($1, $2, $3, $4)",
+			postID, item.Media.ID, i, item.AltText)
+		if err != nil {
+			return dbError(err, "error attaching post media", "media")
+		}
+	}
+	return nil
 }
 
 func (r *postRepository) DeletePost(ctx context.Context, postID string) error {
 	query := "DELETE FROM posts WHERE id = $1"
 	result, err := r.db.Exec(ctx, query, postID)
 	if err != nil {
-		return fmt.Errorf("error deleting post: %w", err)
+		return dbError(err, "error deleting post", "post")
 	}
 
 	rowsAffected := result.RowsAffected()
 	if rowsAffected == 0 {
-		return pgx.ErrNoRows
+		return entity.NotFound("post not found")
 	}
 	return nil
 }
 
-func (r *postRepository) SearchPosts(ctx context.Context, query string) ([]entity.Post, error) {
+func (r *postRepository) SearchPosts(ctx context.Context, query string, page entity.PageRequest) ([]entity.Post, entity.PageInfo, error) {
+	page = page.Normalized()
+	after, args := keysetAfter(page, "p", []interface{}{"%" + query + "%"})
+	limit, args := limitArg(page, args)
 	queryText := `
-		SELECT id, user_id, caption, image_url, created_at, updated_at 
-		FROM posts 
-		WHERE LOWER(caption) LIKE LOWER($1) OR LOWER(image_url) LIKE LOWER($1)
-	`
-	rows, err := r.db.Query(ctx, queryText, "%"+query+"%")
+		SELECT p.id, p.user_id, p.caption, p.image_url, p.like_count, p.comment_count, p.created_at, p.updated_at 
+		FROM posts p 
+		WHERE (LOWER(p.caption) LIKE LOWER($1) OR LOWER(p.image_url) LIKE LOWER($1)) AND ` + after + `
+		ORDER BY p.created_at DESC, p.id DESC
+		LIMIT ` + limit
+	rows, err := r.db.Query(ctx, queryText, args...)
 	if err != nil {
-		return nil, fmt.Errorf("error searching posts: %w", err)
+		return nil, entity.PageInfo{}, fmt.Errorf("error searching posts: %w", err)
 	}
 	defer rows.Close()
 
 	var posts []entity.Post
 	for rows.Next() {
 		var post entity.Post
-		if err := rows.Scan(&post.ID, &post.UserID, &post.Caption, &post.ImageURL, &post.CreatedAt, &post.UpdatedAt); err != nil {
-			return nil, fmt.Errorf("error scanning post row: %w", err)
+		if err := rows.Scan(&post.ID, &post.UserID, &post.Caption, &post.ImageURL, &post.LikeCount, &post.CommentCount, &post.CreatedAt, &post.UpdatedAt); err != nil {
+			return nil, entity.PageInfo{}, fmt.Errorf("error scanning post row: %w", err)
 		}
 		posts = append(posts, post)
 	}
 
+	if err := rows.Err(); err != nil {
+		return nil, entity.PageInfo{}, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	posts, pageInfo := trimPage(posts, page, postCursor)
+	return posts, pageInfo, nil
+}
+
+func (r *postRepository) FetchViewerStates(ctx context.Context, viewerID string, postIDs []string) (map[string]entity.PostViewerState, error) {
+	states := make(map[string]entity.PostViewerState, len(postIDs))
+	if viewerID == "" || len(postIDs) == 0 {
+		return states, nil
+	}
+
+	query := `
+		SELECT p.id,
+			EXISTS (SELECT 1 FROM likes l WHERE l.post_id = p.id AND l.user_id = $1)
+		FROM posts p
+		WHERE p.id = ANY($2::uuid[])
+	`
+	rows, err := r.db.Query(ctx, query, viewerID, postIDs)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching viewer state for posts: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var postID string
+		var state entity.PostViewerState
+		if err := rows.Scan(&postID, &state.LikedByMe); err != nil {
+			return nil, fmt.Errorf("error scanning viewer state row: %w", err)
+		}
+		states[postID] = state
+	}
+
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("error iterating over rows: %w", err)
 	}
+	return states, nil
+}
 
-	if len(posts) == 0 {
-		return nil, fmt.Errorf("no posts found matching query: %s", query)
+func (r *postRepository) FetchPostsByIDs(ctx context.Context, postIDs []string) ([]entity.Post, error) {
+	if len(postIDs) == 0 {
+		return []entity.Post{}, nil
+	}
+
+	query := `
+		SELECT p.id, p.user_id, p.caption, p.image_url, p.like_count, p.comment_count, p.created_at, p.updated_at
+		FROM posts p
+		WHERE p.id = ANY($1::uuid[])
+	`
+	rows, err := r.db.Query(ctx, query, postIDs)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching posts by IDs: %w", err)
 	}
+	defer rows.Close()
 
+	var posts []entity.Post
+	for rows.Next() {
+		var post entity.Post
+		if err := rows.Scan(&post.ID, &post.UserID, &post.Caption, &post.ImageURL, &post.LikeCount, &post.CommentCount, &post.CreatedAt, &post.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("error scanning post row: %w", err)
+		}
+		posts = append(posts, post)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
 	return posts, nil
-}
\ No newline at end of file
+}
+
+// FetchPostsByUserIDs loads the first page of posts for each user in one
+// query, ranking rows per user with a window function.
+func (r *postRepository) FetchPostsByUserIDs(ctx context.Context, userIDs []string, page entity.PageRequest) (map[string]entity.Page[entity.Post], error) {
+	if len(userIDs) == 0 {
+		return map[string]entity.Page[entity.Post]{}, nil
+	}
+
+	page = page.Normalized()
+	query := `
+		SELECT id, user_id, caption, image_url, like_count, comment_count, created_at, updated_at
+		FROM (
+			SELECT p.*, ROW_NUMBER() OVER (PARTITION BY p.user_id ORDER BY p.created_at DESC, p.id DESC) AS rn
+			FROM posts p
+			WHERE p.user_id = ANY($1::uuid[])
+		) ranked
+		WHERE rn <= $2
+		ORDER BY user_id, created_at DESC, id DESC
+	`
+	rows, err := r.db.Query(ctx, query, userIDs, page.Limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching posts for users: %w", err)
+	}
+	defer rows.Close()
+
+	var posts []entity.Post
+	for rows.Next() {
+		var post entity.Post
+		if err := rows.Scan(&post.ID, &post.UserID, &post.Caption, &post.ImageURL, &post.LikeCount, &post.CommentCount, &post.CreatedAt, &post.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("error scanning post row: %w", err)
+		}
+		posts = append(posts, post)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+	return trimPages(posts, page, func(post entity.Post) string { return post.UserID }, postCursor), nil
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	entity "raion-assessment/domain/entity"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// storageUsageQuery reads what a user's media takes up and the declared size
+// of the resumable and direct uploads they have in progress.
+const storageUsageQuery = `
+	SELECT u.storage_used,
+		(SELECT COALESCE(SUM(upload_length), 0) FROM uploads WHERE owner_id = u.id AND expires_at > NOW()) +
+		(SELECT COALESCE(SUM(size), 0) FROM direct_uploads WHERE owner_id = u.id AND expires_at > NOW())
+	FROM users u
+	WHERE u.id = $1`
+
+// reserveStorage fails unless size more bytes fit in the owner's quota. It
+// locks the owner's row for the rest of tx, so checks and the inserts they
+// guard run one at a time per user and concurrent uploads cannot overshoot
+// together. Uploads in progress count only when withUploads is set: media
+// being ingested may well be one of them.
+func reserveStorage(ctx context.Context, tx pgx.Tx, ownerID string, size, quota int64, withUploads bool) error {
+	var usage entity.StorageUsage
+	err := tx.QueryRow(ctx, storageUsageQuery+" FOR UPDATE OF u", ownerID).Scan(&usage.Used, &usage.Reserved)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return entity.NotFound("user not found")
+		}
+		return fmt.Errorf("error locking storage usage: %w", err)
+	}
+
+	usage.Quota = quota
+	if !withUploads {
+		usage.Reserved = 0
+	}
+	if !usage.Fits(size) {
+		return entity.QuotaExceeded(fmt.Sprintf("storage quota exceeded: %d of %d bytes are in use", usage.Used+usage.Reserved, usage.Quota))
+	}
+	return nil
+}
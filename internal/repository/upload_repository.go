@@ -0,0 +1,242 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	contract "raion-assessment/domain/contract"
+	entity "raion-assessment/domain/entity"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+const uploadColumns = "id, owner_id, upload_length, upload_offset, metadata, chunk_keys, expires_at, created_at"
+
+type uploadRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewUploadRepository(db *pgxpool.Pool) contract.IUploadRepository {
+	return &uploadRepository{db: db}
+}
+
+// CreateUpload records a new, empty upload that expires after ttl, provided
+// its length fits in what is left of the owner's quota.
+func (r *uploadRepository) CreateUpload(ctx context.Context, upload entity.Upload, ttl time.Duration, quota int64) (*entity.Upload, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error starting upload transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := reserveStorage(ctx, tx, upload.OwnerID, upload.Length, quota, true); err != nil {
+		return nil, err
+	}
+
+	query := `
+		INSERT INTO uploads (owner_id, upload_length, metadata, expires_at)
+		VALUES ($1, $2, $3, NOW() + make_interval(secs => $4))
+		RETURNING ` + uploadColumns
+	created, err := scanUpload(tx.QueryRow(ctx, query, upload.OwnerID, upload.Length, upload.Metadata, ttl.Seconds()))
+	if err != nil {
+		return nil, dbError(err, "error creating upload", "upload")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("error committing upload: %w", err)
+	}
+	return created, nil
+}
+
+// FetchUpload returns the owner's upload, or nil if there is none or it has
+// expired.
+func (r *uploadRepository) FetchUpload(ctx context.Context, id, ownerID string) (*entity.Upload, error) {
+	query := `SELECT ` + uploadColumns + ` FROM uploads WHERE id = $1 AND owner_id = $2 AND expires_at > NOW()`
+	upload, err := scanUpload(r.db.QueryRow(ctx, query, id, ownerID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, dbError(err, "error fetching upload", "upload")
+	}
+	return upload, nil
+}
+
+// AppendChunk records a stored chunk of size bytes written at offset and
+// pushes the expiry back to ttl from now. It only succeeds while the upload is still at offset,
+// so of two requests racing for the same offset one wins and the other gets
+// nil.
+func (r *uploadRepository) AppendChunk(ctx context.Context, id, ownerID string, offset, size int64, chunkKey string, ttl time.Duration) (*entity.Upload, error) {
+	query := `
+		UPDATE uploads
+		SET upload_offset = upload_offset + $4, chunk_keys = array_append(chunk_keys, $5),
+			expires_at = NOW() + make_interval(secs => $6)
+		WHERE id = $1 AND owner_id = $2 AND upload_offset = $3 AND upload_offset + $4 <= upload_length
+			AND expires_at > NOW()
+		RETURNING ` + uploadColumns
+	upload, err := scanUpload(r.db.QueryRow(ctx, query, id, ownerID, offset, size, chunkKey, ttl.Seconds()))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, dbError(err, "error appending upload chunk", "upload")
+	}
+	return upload, nil
+}
+
+// DeleteUpload removes the owner's upload and returns it so its chunks can be
+// deleted from the store, or nil if there was none.
+func (r *uploadRepository) DeleteUpload(ctx context.Context, id, ownerID string) (*entity.Upload, error) {
+	query := `DELETE FROM uploads WHERE id = $1 AND owner_id = $2 RETURNING ` + uploadColumns
+	upload, err := scanUpload(r.db.QueryRow(ctx, query, id, ownerID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, dbError(err, "error deleting upload", "upload")
+	}
+	return upload, nil
+}
+
+// DeleteExpiredUploads removes up to limit expired uploads and returns them.
+func (r *uploadRepository) DeleteExpiredUploads(ctx context.Context, limit int) ([]entity.Upload, error) {
+	query := `
+		DELETE FROM uploads
+		WHERE id IN (
+			SELECT id FROM uploads WHERE expires_at <= NOW()
+			ORDER BY expires_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + uploadColumns
+	rows, err := r.db.Query(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error deleting expired uploads: %w", err)
+	}
+	defer rows.Close()
+
+	var uploads []entity.Upload
+	for rows.Next() {
+		upload, err := scanUpload(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning upload row: %w", err)
+		}
+		uploads = append(uploads, *upload)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+	return uploads, nil
+}
+
+const directUploadColumns = "id, owner_id, purpose, object_key, content_type, size, expires_at, created_at"
+
+// CreateDirectUpload records a presigned upload that expires after ttl,
+// provided its size fits in what is left of the owner's quota.
+func (r *uploadRepository) CreateDirectUpload(ctx context.Context, upload entity.DirectUpload, ttl time.Duration, quota int64) (*entity.DirectUpload, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error starting upload transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := reserveStorage(ctx, tx, upload.OwnerID, upload.Size, quota, true); err != nil {
+		return nil, err
+	}
+
+	query := `
+		INSERT INTO direct_uploads (id, owner_id, purpose, object_key, content_type, size, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW() + make_interval(secs => $7))
+		RETURNING ` + directUploadColumns
+	created, err := scanDirectUpload(tx.QueryRow(ctx, query, upload.ID, upload.OwnerID, upload.Purpose, upload.Key,
+		upload.ContentType, upload.Size, ttl.Seconds()))
+	if err != nil {
+		return nil, dbError(err, "error creating direct upload", "upload")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("error committing direct upload: %w", err)
+	}
+	return created, nil
+}
+
+// FetchDirectUpload returns the owner's direct upload, or nil if there is
+// none or it has expired.
+func (r *uploadRepository) FetchDirectUpload(ctx context.Context, id, ownerID string) (*entity.DirectUpload, error) {
+	query := `SELECT ` + directUploadColumns + ` FROM direct_uploads WHERE id = $1 AND owner_id = $2 AND expires_at > NOW()`
+	upload, err := scanDirectUpload(r.db.QueryRow(ctx, query, id, ownerID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, dbError(err, "error fetching direct upload", "upload")
+	}
+	return upload, nil
+}
+
+// DeleteDirectUpload removes the owner's direct upload and returns it, or nil
+// if there was none.
+func (r *uploadRepository) DeleteDirectUpload(ctx context.Context, id, ownerID string) (*entity.DirectUpload, error) {
+	query := `DELETE FROM direct_uploads WHERE id = $1 AND owner_id = $2 RETURNING ` + directUploadColumns
+	upload, err := scanDirectUpload(r.db.QueryRow(ctx, query, id, ownerID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, dbError(err, "error deleting direct upload", "upload")
+	}
+	return upload, nil
+}
+
+// DeleteExpiredDirectUploads removes up to limit expired direct uploads and
+// returns them.
+func (r *uploadRepository) DeleteExpiredDirectUploads(ctx context.Context, limit int) ([]entity.DirectUpload, error) {
+	query := `
+		DELETE FROM direct_uploads
+		WHERE id IN (
+			SELECT id FROM direct_uploads WHERE expires_at <= NOW()
+			ORDER BY expires_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + directUploadColumns
+	rows, err := r.db.Query(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error deleting expired direct uploads: %w", err)
+	}
+	defer rows.Close()
+
+	var uploads []entity.DirectUpload
+	for rows.Next() {
+		upload, err := scanDirectUpload(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning direct upload row: %w", err)
+		}
+		uploads = append(uploads, *upload)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+	return uploads, nil
+}
+
+func scanUpload(row pgx.Row) (*entity.Upload, error) {
+	var upload entity.Upload
+	err := row.Scan(&upload.ID, &upload.OwnerID, &upload.Length, &upload.Offset, &upload.Metadata,
+		&upload.ChunkKeys, &upload.ExpiresAt, &upload.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &upload, nil
+}
+
+func scanDirectUpload(row pgx.Row) (*entity.DirectUpload, error) {
+	var upload entity.DirectUpload
+	err := row.Scan(&upload.ID, &upload.OwnerID, &upload.Purpose, &upload.Key, &upload.ContentType, &upload.Size,
+		&upload.ExpiresAt, &upload.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &upload, nil
+}
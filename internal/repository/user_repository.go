@@ -8,7 +8,6 @@ import (
 	entity "raion-assessment/domain/entity"
 	"time"
 
-	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 )
 
@@ -20,42 +19,71 @@ func NewUserRepository(db *pgxpool.Pool) contract.IUserRepository {
 	return &userRepository{db: db}
 }
 
-func (r *userRepository) GetAllUsers(ctx context.Context) ([]entity.User, error) {
-	rows, err := r.db.Query(ctx, "SELECT id, name, email, bio, image_url, created_at, updated_at FROM users")
+func (r *userRepository) GetAllUsers(ctx context.Context, page entity.PageRequest) ([]entity.User, entity.PageInfo, error) {
+	page = page.Normalized()
+	after, args := keysetAfter(page, "u", nil)
+	limit, args := limitArg(page, args)
+	query := `
+		SELECT u.id, u.name, u.email, u.bio, u.image_url, u.post_count, u.created_at, u.updated_at
+		FROM users u
+		WHERE ` + after + `
+		ORDER BY u.created_at DESC, u.id DESC
+		LIMIT ` + limit
+	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("error fetching users: %w", err)
+		return nil, entity.PageInfo{}, fmt.Errorf("error fetching users: %w", err)
 	}
 	defer rows.Close()
 
 	var users []entity.User
 	for rows.Next() {
 		var user entity.User
-		if err := rows.Scan(&user.ID, &user.Name, &user.Email, &user.Bio, &user.ImageURL, &user.CreatedAt, &user.UpdatedAt); err != nil {
-			return nil, fmt.Errorf("error scanning user row: %w", err)
+		if err := rows.Scan(&user.ID, &user.Name, &user.Email, &user.Bio, &user.ImageURL, &user.PostCount, &user.CreatedAt, &user.UpdatedAt); err != nil {
+			return nil, entity.PageInfo{}, fmt.Errorf("error scanning user row: %w", err)
 		}
 		users = append(users, user)
 	}
 
-	if len(users) == 0 {
-		return nil, errors.New("no users found")
-	}
-
-	return users, nil
+	users, pageInfo := trimPage(users, page, userCursor)
+	return users, pageInfo, nil
 }
 
 func (r *userRepository) GetUserByID(ctx context.Context, id string) (entity.User, error) {
 	var user entity.User
-	err := r.db.QueryRow(ctx, "SELECT id, name, email, bio, image_url, created_at, updated_at FROM users WHERE id = $1", id).
-		Scan(&user.ID, &user.Name, &user.Email, &user.Bio, &user.ImageURL, &user.CreatedAt, &user.UpdatedAt)
+	err := r.db.QueryRow(ctx, "SELECT id, name, email, bio, image_url, post_count, created_at, updated_at FROM users WHERE id = $1", id).
+		Scan(&user.ID, &user.Name, &user.Email, &user.Bio, &user.ImageURL, &user.PostCount, &user.CreatedAt, &user.UpdatedAt)
 	if err != nil {
-		if err == pgx.ErrNoRows {
-			return user, errors.New("user not found")
-		}
-		return user, fmt.Errorf("error fetching user: %w", err)
+		return user, dbError(err, "error fetching user", "user")
 	}
 	return user, nil
 }
 
+func (r *userRepository) GetUsersByIDs(ctx context.Context, ids []string) ([]entity.User, error) {
+	if len(ids) == 0 {
+		return []entity.User{}, nil
+	}
+
+	rows, err := r.db.Query(ctx, "SELECT id, name, email, bio, image_url, post_count, created_at, updated_at FROM users WHERE id = ANY($1::uuid[])", ids)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching users by IDs: %w", err)
+	}
+	defer rows.Close()
+
+	var users []entity.User
+	for rows.Next() {
+		var user entity.User
+		if err := rows.Scan(&user.ID, &user.Name, &user.Email, &user.Bio, &user.ImageURL, &user.PostCount, &user.CreatedAt, &user.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("error scanning user row: %w", err)
+		}
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+	return users, nil
+}
+
 func (r *userRepository) CreateUser(ctx context.Context, user entity.User) (entity.User, error) {
 	if user.ImageURL == "" {
 		user.ImageURL = "https://static.vecteezy.com/system/resources/previews/009/292/244/non_2x/default-avatar-icon-of-social-media-user-vector.jpg"
@@ -68,7 +96,7 @@ func (r *userRepository) CreateUser(ctx context.Context, user entity.User) (enti
 		"INSERT INTO users (name, email, password_hash, image_url, bio, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, NOW(), NOW())",
 		user.Name, user.Email, user.PasswordHash, user.ImageURL, user.Bio)
 	if err != nil {
-		return user, fmt.Errorf("error creating user: %w", err)
+		return user, dbError(err, "error creating user", "user")
 	}
 
 	if commandTag.RowsAffected() > 0 {
@@ -88,16 +116,17 @@ func (r *userRepository) CreateUser(ctx context.Context, user entity.User) (enti
 func (r *userRepository) UpdateUser(ctx context.Context, id string, user entity.User) (entity.User, error) { 
 	commandTag, err := r.db.Exec(ctx, 
 		`UPDATE users 
-		 SET name = $1, email = $2, image_url = $3, bio = $4, updated_at = NOW() 
+		 SET name = $1, email = $2, image_url = $3, bio = $4,
+		     avatar_media_id = COALESCE(NULLIF($6, '')::uuid, avatar_media_id), updated_at = NOW() 
 		 WHERE id = $5`, 
-		user.Name, user.Email, user.ImageURL, user.Bio, id)
+		user.Name, user.Email, user.ImageURL, user.Bio, id, user.AvatarID)
 		
 	if err != nil {
-		return user, fmt.Errorf("error updating user: %w", err)
+		return user, dbError(err, "error updating user", "user")
 	}
 
 	if commandTag.RowsAffected() == 0 {
-		return user, errors.New("user not found")
+		return user, entity.NotFound("user not found")
 	}
 
 	user.ID = id
@@ -108,37 +137,41 @@ func (r *userRepository) UpdateUser(ctx context.Context, id string, user entity.
 func (r *userRepository) DeleteUser(ctx context.Context, id string) error {
 	commandTag, err := r.db.Exec(ctx, "DELETE FROM users WHERE id = $1", id)
 	if err != nil {
-		return fmt.Errorf("error deleting user: %w", err)
+		return dbError(err, "error deleting user", "user")
 	}
 
 	if commandTag.RowsAffected() == 0 {
-		return errors.New("user not found")
+		return entity.NotFound("user not found")
 	}
 
 	return nil
 }
 
-func (r *userRepository) SearchUsers(ctx context.Context, query string) ([]entity.User, error) {
-	rows, err := r.db.Query(ctx, 
-		"SELECT id, name, email, created_at, updated_at FROM users WHERE LOWER(name) LIKE LOWER($1) OR LOWER(email) LIKE LOWER($1)", 
-		"%"+query+"%")	
+func (r *userRepository) SearchUsers(ctx context.Context, query string, page entity.PageRequest) ([]entity.User, entity.PageInfo, error) {
+	page = page.Normalized()
+	after, args := keysetAfter(page, "u", []interface{}{"%" + query + "%"})
+	limit, args := limitArg(page, args)
+	queryText := `
+		SELECT u.id, u.name, u.email, u.post_count, u.created_at, u.updated_at
+		FROM users u
+		WHERE (LOWER(u.name) LIKE LOWER($1) OR LOWER(u.email) LIKE LOWER($1)) AND ` + after + `
+		ORDER BY u.created_at DESC, u.id DESC
+		LIMIT ` + limit
+	rows, err := r.db.Query(ctx, queryText, args...)
     if err != nil {
-        return nil, fmt.Errorf("error searching users: %w", err)
+        return nil, entity.PageInfo{}, fmt.Errorf("error searching users: %w", err)
     }
     defer rows.Close()
 
     var users []entity.User
     for rows.Next() {
         var user entity.User
-        if err := rows.Scan(&user.ID, &user.Name, &user.Email, &user.CreatedAt, &user.UpdatedAt); err != nil {
-            return nil, fmt.Errorf("error scanning user row: %w", err)
+        if err := rows.Scan(&user.ID, &user.Name, &user.Email, &user.PostCount, &user.CreatedAt, &user.UpdatedAt); err != nil {
+            return nil, entity.PageInfo{}, fmt.Errorf("error scanning user row: %w", err)
         }
         users = append(users, user)
     }
 
-    if len(users) == 0 {
-        return nil, errors.New("no users found")
-    }
-
-    return users, nil
-}
\ No newline at end of file
+    users, pageInfo := trimPage(users, page, userCursor)
+    return users, pageInfo, nil
+}
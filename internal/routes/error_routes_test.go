@@ -0,0 +1,39 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"raion-assessment/pkg/response"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestUnknownRoutesAreNotFound(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: response.ErrorHandler})
+	app.Get("/api/v1/posts/:id", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+	setupErrorRoutes(app)
+
+	tests := []struct {
+		method string
+		target string
+		want   int
+	}{
+		{http.MethodGet, "/api/v1/posts/1", fiber.StatusOK},
+		{http.MethodGet, "/api/v1/nope", fiber.StatusNotFound},
+		{http.MethodPost, "/api/v1/nope", fiber.StatusNotFound},
+		{http.MethodPut, "/api/v1/nope", fiber.StatusNotFound},
+		{http.MethodDelete, "/api/v1/nope", fiber.StatusNotFound},
+	}
+	for _, test := range tests {
+		resp, err := app.Test(httptest.NewRequest(test.method, test.target, nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != test.want {
+			t.Errorf("%s %s: got %d, want %d", test.method, test.target, resp.StatusCode, test.want)
+		}
+	}
+}
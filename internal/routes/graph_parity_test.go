@@ -0,0 +1,113 @@
+package routes
+
+import (
+	"fmt"
+	"raion-assessment/domain/schema/graph"
+	"raion-assessment/internal/di"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// restGraphQLCounterparts maps every REST route to the GraphQL root field
+// offering the same capability. Adding a REST route without an entry here, or
+// pointing an entry at a field the schema lacks, fails TestGraphQLParity.
+var restGraphQLCounterparts = map[string]string{
+	"GET /api/v1/users/":                                      "Query.getAllUsers",
+	"GET /api/v1/users/:id":                                   "Query.getUserByID",
+	"PATCH /api/v1/users/":                                    "Mutation.updateUser",
+	"GET /api/v1/search/users":                                "Query.searchUsers",
+	"GET /api/v1/search/posts":                                "Query.searchPosts",
+	"POST /api/v1/auth/register":                              "Mutation.register",
+	"POST /api/v1/auth/login":                                 "Mutation.login",
+	"POST /api/v1/auth/refresh-token":                         "Mutation.refreshToken",
+	"GET /api/v1/auth/current-user":                           "Query.me",
+	"PATCH /api/v1/auth/change-password":                      "Mutation.changePassword",
+	"GET /api/v1/posts/":                                      "Query.getAllPosts",
+	"GET /api/v1/posts/:id":                                   "Query.getPostByID",
+	"GET /api/v1/posts/user/:user_id":                         "Query.getPostsByUserID",
+	"POST /api/v1/posts/":                                     "Mutation.createPost",
+	"PATCH /api/v1/posts/:id":                                 "Mutation.updatePostCaption",
+	"DELETE /api/v1/posts/:id":                                "Mutation.deletePost",
+	"GET /api/v1/posts/:post_id/comments/":                    "Query.getCommentsByPostID",
+	"POST /api/v1/posts/:post_id/comments/":                   "Mutation.createComment",
+	"DELETE /api/v1/posts/:post_id/comments/:id":              "Mutation.deleteComment",
+	"GET /api/v1/posts/:post_id/likes":                        "Query.getLikesByPostID",
+	"GET /api/v1/users/:user_id/likes":                        "Query.getLikesByUserID",
+	"POST /api/v1/posts/:post_id/like":                        "Mutation.likePost",
+	"POST /api/v1/posts/:post_id/unlike":                      "Mutation.unlikePost",
+	"GET /api/v1/posts/:post_id/comments/:comment_id/likes":   "Query.getLikesByCommentID",
+	"POST /api/v1/posts/:post_id/comments/:comment_id/like":   "Mutation.likeComment",
+	"POST /api/v1/posts/:post_id/comments/:comment_id/unlike": "Mutation.unlikeComment",
+	"GET /api/v1/media/usage":                                 "Query.storageUsage",
+	"POST /api/v1/media/uploads":                              "Mutation.createDirectUpload",
+	"POST /api/v1/media/uploads/:id/complete":                 "Mutation.completeDirectUpload",
+}
+
+// restOnlyRoutes are the tus resumable upload routes. They carry raw file
+// bytes under a protocol of their own, which GraphQL has no counterpart for;
+// GraphQL clients use them too and pass the finished upload's ID on.
+var restOnlyRoutes = map[string]bool{
+	"OPTIONS /api/v1/uploads/":   true,
+	"POST /api/v1/uploads/":      true,
+	"PATCH /api/v1/uploads/:id":  true,
+	"DELETE /api/v1/uploads/:id": true,
+}
+
+// checkGraphQLParity verifies that every REST route registered on app has a
+// GraphQL counterpart present in graphqlSchema.
+func checkGraphQLParity(app *fiber.App, graphqlSchema *ast.Schema) error {
+	roots := map[string]*ast.Definition{
+		"Query":    graphqlSchema.Query,
+		"Mutation": graphqlSchema.Mutation,
+	}
+
+	var missing []string
+	for _, route := range app.GetRoutes(true) {
+		if route.Method == fiber.MethodHead || !strings.HasPrefix(route.Path, "/api/v1/") || route.Path == "/api/v1/graphql" {
+			continue
+		}
+
+		key := route.Method + " " + route.Path
+		if restOnlyRoutes[key] {
+			continue
+		}
+		counterpart, ok := restGraphQLCounterparts[key]
+		if !ok {
+			missing = append(missing, key+" has no GraphQL counterpart")
+			continue
+		}
+
+		rootName, fieldName, _ := strings.Cut(counterpart, ".")
+		root := roots[rootName]
+		if root == nil {
+			missing = append(missing, key+" maps to unknown root "+rootName)
+			continue
+		}
+		if root.Fields.ForName(fieldName) == nil {
+			missing = append(missing, key+" maps to missing field "+counterpart)
+		}
+	}
+
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("GraphQL schema is missing REST capabilities:\n  %s", strings.Join(missing, "\n  "))
+	}
+	return nil
+}
+
+// TestGraphQLParity registers the REST routes on a bare app and checks each
+// against the GraphQL schema. Handlers are never called, so the container is
+// left empty.
+func TestGraphQLParity(t *testing.T) {
+	app := fiber.New()
+	setupRESTRoutes(app, di.Container{}, "test-secret")
+
+	schema := graph.NewExecutableSchema(graph.Config{}).Schema()
+	if err := checkGraphQLParity(app, schema); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -1,87 +1,79 @@
 package routes
 
 import (
-	"bytes"
+	"context"
 	"log"
 	"net/http"
-	"raion-assessment/domain/schema"
+	"raion-assessment/config"
+	"raion-assessment/domain/schema/graph"
 	"raion-assessment/internal/di"
-	"raion-assessment/pkg/util"
+	resolver "raion-assessment/internal/handler/graphql"
+	"raion-assessment/pkg/request"
+	"strings"
 
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
 	"github.com/99designs/gqlgen/graphql/playground"
 	"github.com/gofiber/fiber/v2"
-	"github.com/graphql-go/graphql"
-	"github.com/graphql-go/handler"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
 )
 
-func SetupGraphQLRoute(app *fiber.App, container di.Container) {
-	graphqlSchema, err := createGraphQLSchema(container)
-	if err != nil {
-		log.Printf("Failed to create GraphQL schema: %v", err)
-		return
-	}
+// graphQLMaxUploadSize matches Fiber's request body limit, which bounds
+// multipart requests before they reach the GraphQL handler anyway.
+const graphQLMaxUploadSize = config.MaxRequestBodySize
 
-	graphQLHandler := handler.New(&handler.Config{
-		Schema: &graphqlSchema,
-		Pretty: true,
+func SetupGraphQLRoute(app *fiber.App, container di.Container, graphQLConfig config.GraphQLConfig) {
+	executableSchema := graph.NewExecutableSchema(graph.Config{
+		Resolvers:  container.GraphResolver,
+		Complexity: resolver.NewComplexityRoot(),
 	})
 
-	app.Post("/api/v1/graphql", graphqlHandler(graphQLHandler))
-	app.Get("/graphql/docs", playgroundHandler())
-}
-
-func createGraphQLSchema(container di.Container) (graphql.Schema, error) {
-	rootQuery := graphql.NewObject(graphql.ObjectConfig{
-		Name: "Query",
-		Fields: util.MergeFields(
-			util.ConvertFieldDefinitionMap(schema.NewUserQueryType(container).Fields()),
-			util.ConvertFieldDefinitionMap(schema.NewPostQueryType(container).Fields()),
-		),
+	srv := handler.New(executableSchema)
+	srv.AddTransport(container.GraphResolver.Websocket(config.GetCORSAllowOrigins()))
+	srv.AddTransport(transport.GET{})
+	srv.AddTransport(transport.POST{})
+	srv.AddTransport(transport.MultipartForm{
+		MaxUploadSize: graphQLMaxUploadSize,
+		MaxMemory:     graphQLMaxUploadSize,
 	})
+	srv.Use(extension.Introspection{})
 
-	rootMutation := graphql.NewObject(graphql.ObjectConfig{
-		Name: "Mutation",
-		Fields: util.MergeFields(
-			util.ConvertFieldDefinitionMap(schema.NewUserQueryType(container).Fields()),
-			util.ConvertFieldDefinitionMap(schema.NewPostMutationType(container).Fields()),
-		),
-	})
+	persistedQueries, err := resolver.LoadPersistedQueries(graphQLConfig.PersistedQueriesFile, graphQLConfig.AllowListOnly)
+	if err != nil {
+		log.Fatalf("Failed to load persisted queries: %v", err)
+	}
+	srv.Use(extension.AutomaticPersistedQuery{Cache: persistedQueries})
+	srv.Use(persistedQueries)
+	srv.Use(resolver.DepthLimit{MaxDepth: graphQLConfig.MaxDepth})
+	srv.Use(extension.FixedComplexityLimit(graphQLConfig.MaxComplexity))
+	srv.AroundResponses(container.GraphResolver.WithLoaders)
+	srv.SetErrorPresenter(resolver.PresentError)
+	srv.SetRecoverFunc(resolver.RecoverPanic)
 
-	return graphql.NewSchema(graphql.SchemaConfig{
-		Query:    rootQuery,
-		Mutation: rootMutation,
-	})
+	graphqlHandler := adaptor.HTTPHandler(withLocale(withBearerToken(srv)))
+	app.Post("/api/v1/graphql", graphqlHandler)
+	app.Get("/api/v1/graphql", graphqlHandler)
+	app.Get("/graphql/docs", adaptor.HTTPHandler(playground.Handler("GraphQL Playground", "/api/v1/graphql")))
 }
 
-func graphqlHandler(graphQLHandler *handler.Handler) fiber.Handler {
-	return func(c *fiber.Ctx) error {
-		body := c.Body()
-		req, err := http.NewRequest("POST", "/", bytes.NewBuffer(body))
-		if err != nil {
-			return c.Status(fiber.StatusInternalServerError).SendString("Failed to create request")
+// withBearerToken puts the request's bearer token into the context under the
+// "token" key, where the resolvers look for it.
+func withBearerToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if authHeader := r.Header.Get("Authorization"); strings.HasPrefix(authHeader, "Bearer ") {
+			ctx := context.WithValue(r.Context(), "token", strings.TrimPrefix(authHeader, "Bearer "))
+			r = r.WithContext(ctx)
 		}
-
-		util.CopyHeaders(c, req)
-		rec := util.NewResponseRecorder()
-		graphQLHandler.ServeHTTP(rec, req)
-		util.CopyResponseHeaders(c, rec)
-
-		return c.Status(rec.StatusCode).Send(rec.Body.Bytes())
-	}
+		next.ServeHTTP(w, r)
+	})
 }
 
-func playgroundHandler() fiber.Handler {
-	return func(c *fiber.Ctx) error {
-		req, err := http.NewRequest("GET", "/", nil)
-		if err != nil {
-			return c.Status(fiber.StatusInternalServerError).SendString("Failed to create request")
-		}
-
-		util.CopyHeaders(c, req)
-		rec := util.NewResponseRecorder()
-		playground.Handler("GraphQL Playground", "/api/v1/graphql").ServeHTTP(rec, req)
-		util.CopyResponseHeaders(c, rec)
-
-		return c.Status(rec.StatusCode).Send(rec.Body.Bytes())
-	}
-}
\ No newline at end of file
+// withLocale records the language requested in Accept-Language so validation
+// errors from the resolvers are reported in it.
+func withLocale(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		locale := request.LocaleFromHeader(r.Header.Get("Accept-Language"))
+		next.ServeHTTP(w, r.WithContext(request.WithLocale(r.Context(), locale)))
+	})
+}
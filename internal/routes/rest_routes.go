@@ -3,6 +3,7 @@ package routes
 import (
 	"raion-assessment/internal/di"
 	"raion-assessment/internal/middleware"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -14,6 +15,31 @@ func setupRESTRoutes(app *fiber.App, container di.Container, jwtSecret string) {
 	setupSearchRoutes(app, container)
 	setupCommentRoutes(app, container)
 	setupLikeRoutes(app, container)
+	setupUploadRoutes(app, container)
+	setupMediaRoutes(app, container)
+}
+
+func setupUploadRoutes(app *fiber.App, container di.Container) {
+	uploadGroup := app.Group("/api/v1/uploads")
+	tus := container.UploadHandler.TusResumable
+	uploadGroup.Options("/", tus, container.UploadHandler.DescribeUploads)
+	uploadGroup.Post("/", tus, container.UploadHandler.CreateUpload)
+	uploadGroup.Head("/:id", tus, container.UploadHandler.GetUploadOffset)
+	uploadGroup.Patch("/:id", tus, container.UploadHandler.AppendUploadChunk)
+	uploadGroup.Delete("/:id", tus, container.UploadHandler.DeleteUpload)
+}
+
+// isUploadChunk reports whether a request sends a chunk of a resumable
+// upload, whose body the upload handler streams to storage.
+func isUploadChunk(c *fiber.Ctx) bool {
+	return c.Method() == fiber.MethodPatch && strings.HasPrefix(c.Path(), "/api/v1/uploads/")
+}
+
+func setupMediaRoutes(app *fiber.App, container di.Container) {
+	mediaGroup := app.Group("/api/v1/media")
+	mediaGroup.Get("/usage", container.MediaHandler.GetStorageUsage)
+	mediaGroup.Post("/uploads", container.MediaHandler.CreateDirectUpload)
+	mediaGroup.Post("/uploads/:id/complete", container.MediaHandler.CompleteDirectUpload)
 }
 
 func setupLikeRoutes(app *fiber.App, container di.Container) {
@@ -21,6 +47,9 @@ func setupLikeRoutes(app *fiber.App, container di.Container) {
 	likeGroup.Post("/:post_id/like", container.LikeHandler.LikePost)
 	likeGroup.Post("/:post_id/unlike", container.LikeHandler.UnlikePost)
 	likeGroup.Get("/:post_id/likes", container.LikeHandler.GetLikesByPostID)
+	likeGroup.Post("/:post_id/comments/:comment_id/like", container.LikeHandler.LikeComment)
+	likeGroup.Post("/:post_id/comments/:comment_id/unlike", container.LikeHandler.UnlikeComment)
+	likeGroup.Get("/:post_id/comments/:comment_id/likes", container.LikeHandler.GetLikesByCommentID)
 
 	userLikeGroup := app.Group("/api/v1/users")
 	userLikeGroup.Get("/:user_id/likes",container.LikeHandler.GetLikesByUserID)
@@ -1,15 +1,18 @@
 package routes
 
 import (
+	"raion-assessment/config"
 	"raion-assessment/internal/di"
+	"raion-assessment/internal/middleware"
 
 	"github.com/gofiber/fiber/v2"
 )
 
-func SetupRoutes(app *fiber.App, container di.Container, jwtSecret string) {
-	setupStaticRoutes(app)
+func SetupRoutes(app *fiber.App, container di.Container, jwtSecret string, graphQLConfig config.GraphQLConfig) {
+	app.Use(middleware.BodyLimit(config.MaxRequestBodySize, isUploadChunk))
+	setupStorageRoutes(app, container)
 	setupDocsRoutes(app)
 	setupRESTRoutes(app, container, jwtSecret)
-	SetupGraphQLRoute(app, container)
+	SetupGraphQLRoute(app, container, graphQLConfig)
 	setupErrorRoutes(app)
 }
\ No newline at end of file
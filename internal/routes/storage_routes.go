@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"raion-assessment/internal/di"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// setupStorageRoutes serves the local media store at /uploads, the path its
+// URLs point to: media is sent only through a signed URL that has not expired,
+// and presigned uploads are PUT to the same addresses. Other drivers serve
+// media and receive uploads themselves.
+func setupStorageRoutes(app *fiber.App, container di.Container) {
+	if container.StorageHandler == nil {
+		return
+	}
+	app.Get("/uploads/*", container.StorageHandler.ServeMedia)
+	app.Put("/uploads/*", container.StorageHandler.ReceiveUpload)
+}
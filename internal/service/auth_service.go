@@ -25,11 +25,12 @@ const (
 )
 
 var (
-	ErrUserNotFound      = errors.New("user not found")
-	ErrIncorrectPassword = errors.New("incorrect password")
-	ErrPasswordHashing   = errors.New("error hashing password")
-	ErrUpdatingUser      = errors.New("error updating user in database")
-	ErrInvalidToken      = errors.New("invalid or expired token")
+	ErrUserNotFound       = entity.NotFound("user not found")
+	ErrInvalidCredentials = entity.Unauthenticated("invalid email or password")
+	ErrIncorrectPassword  = entity.Validation("incorrect password", entity.FieldError{Field: "old_password", Code: "incorrect", Message: "incorrect password"})
+	ErrPasswordHashing    = errors.New("error hashing password")
+	ErrUpdatingUser       = errors.New("error updating user in database")
+	ErrInvalidToken       = entity.Unauthenticated("invalid or expired token")
 )
 
 func NewAuthService(userRepo contract.IUserRepository, authRepo contract.IAuthRepository, jwtSecret, refreshSecret string) contract.IAuthService {
@@ -58,12 +59,15 @@ func (s *authService) Login(email, password string) (string, string, error) {
 	ctx := context.Background()
 
 	user, err := s.authRepo.GetUserByEmail(ctx, email)
-	if err != nil || user == nil {
-		return "", "", ErrUserNotFound
+	if err != nil {
+		return "", "", err
+	}
+	if user == nil {
+		return "", "", ErrInvalidCredentials
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
-		return "", "", ErrIncorrectPassword
+		return "", "", ErrInvalidCredentials
 	}
 
 	accessToken, err := util.GenerateJWT(user.ID, s.jwtSecret, AccessTokenExpiration)
@@ -105,8 +109,11 @@ func (s *authService) GetCurrentUser(ctx context.Context, token string) (*entity
 	}
 
 	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if errors.Is(err, entity.ErrNotFound) {
+		return nil, ErrInvalidToken
+	}
 	if err != nil {
-		return nil, ErrUserNotFound
+		return nil, err
 	}
 
 	return &entity.User{
@@ -2,29 +2,26 @@ package service
 
 import (
 	"context"
-	"errors"
 	contract "raion-assessment/domain/contract"
 	entity "raion-assessment/domain/entity"
 )
 
 type commentService struct {
 	commentRepo contract.ICommentRepository
+	events      contract.IEventBus
 }
 
-func NewCommentService(repo contract.ICommentRepository) contract.ICommentService {
-	return &commentService{commentRepo: repo}
+func NewCommentService(repo contract.ICommentRepository, events contract.IEventBus) contract.ICommentService {
+	return &commentService{commentRepo: repo, events: events}
 }
 
-func (s *commentService) GetCommentsByPostID(postID string) ([]entity.Comment, error) {
+func (s *commentService) GetCommentsByPostID(postID, viewerID, sort string, page entity.PageRequest) ([]entity.Comment, entity.PageInfo, error) {
 	ctx := context.Background()
-	comments, err := s.commentRepo.GetCommentsByPostID(ctx, postID)
+	comments, pageInfo, err := s.commentRepo.GetCommentsByPostID(ctx, postID, viewerID, sort, page)
 	if err != nil {
-		return nil, err
+		return nil, entity.PageInfo{}, err
 	}
-	if len(comments) == 0 {
-		return nil, errors.New("not found")
-	}
-	return comments, nil
+	return comments, pageInfo, nil
 }
 
 func (s *commentService) GetCommentByID(commentID string) (entity.Comment, error) {
@@ -34,7 +31,7 @@ func (s *commentService) GetCommentByID(commentID string) (entity.Comment, error
 		return entity.Comment{}, err
 	}
 	if comment == nil {
-		return entity.Comment{}, errors.New("not found")
+		return entity.Comment{}, entity.NotFound("comment not found")
 	}
 	return *comment, nil
 }
@@ -46,8 +43,9 @@ func (s *commentService) CreateComment(comment entity.Comment) (entity.Comment,
 		return entity.Comment{}, err
 	}
 	if createdComment == nil {
-		return entity.Comment{}, errors.New("not found")
+		return entity.Comment{}, entity.NotFound("comment not found")
 	}
+	s.events.Publish(entity.EventCommentAdded, *createdComment)
 	return *createdComment, nil
 }
 
@@ -59,3 +57,13 @@ func (s *commentService) DeleteComment(commentID string) error {
 	}
 	return nil
 }
+
+func (s *commentService) GetCommentsByIDs(ids []string, viewerID string) ([]entity.Comment, error) {
+	ctx := context.Background()
+	return s.commentRepo.GetCommentsByIDs(ctx, ids, viewerID)
+}
+
+func (s *commentService) GetCommentsByPostIDs(postIDs []string, viewerID, sort string, page entity.PageRequest) (map[string]entity.Page[entity.Comment], error) {
+	ctx := context.Background()
+	return s.commentRepo.GetCommentsByPostIDs(ctx, postIDs, viewerID, sort, page)
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"net/http"
+	entity "raion-assessment/domain/entity"
+	"raion-assessment/pkg/request"
+	"time"
+)
+
+// imageLimits is the allow-list of image types accepted for upload, as
+// detected from the file's leading bytes, with the largest size accepted for
+// each. The type the client claims is ignored.
+var imageLimits = map[string]int64{
+	"image/jpeg": 8 << 20,
+	"image/png":  8 << 20,
+	"image/webp": 8 << 20,
+	"image/gif":  4 << 20,
+}
+
+const (
+	// maxImageUploadSize is the largest of the per-type limits; nothing
+	// beyond it is read from an upload.
+	maxImageUploadSize = 8 << 20
+
+	// maxImageDimension and maxImagePixels reject decompression bombs: small
+	// files that declare enormous canvases and would exhaust memory when
+	// decoded.
+	maxImageDimension = 10000
+	maxImagePixels    = 40_000_000
+)
+
+// checkedUpload is an upload that passed inspection, held in memory. Kind is
+// a domain media kind; duration is only set for videos that declare one.
+type checkedUpload struct {
+	data        []byte
+	contentType string
+	kind        string
+	duration    time.Duration
+}
+
+// inspectImage reads an upload and checks it against the allow-list, the size
+// limit for its type and the dimension limits. Failures are reported as field
+// errors on field.
+func inspectImage(ctx context.Context, src io.Reader, field string) (checkedUpload, error) {
+	data, err := io.ReadAll(io.LimitReader(src, maxImageUploadSize+1))
+	if err != nil {
+		return checkedUpload{}, err
+	}
+	return checkImage(request.LocaleFromContext(ctx), data, field)
+}
+
+func checkImage(locale string, data []byte, field string) (checkedUpload, error) {
+	contentType := http.DetectContentType(data)
+	limit, ok := imageLimits[contentType]
+	if !ok {
+		return checkedUpload{}, request.Invalid(locale, field, "file_type")
+	}
+	if int64(len(data)) > limit {
+		return checkedUpload{}, request.InvalidParam(locale, field, "file_size", fmt.Sprintf("%d MB", limit>>20))
+	}
+
+	width, height, err := imageDimensions(data, contentType)
+	if err != nil {
+		return checkedUpload{}, request.Invalid(locale, field, "image")
+	}
+	if width > maxImageDimension || height > maxImageDimension || width*height > maxImagePixels {
+		return checkedUpload{}, request.InvalidParam(locale, field, "dimensions", fmt.Sprintf("%dx%d", maxImageDimension, maxImageDimension))
+	}
+
+	return checkedUpload{data: data, contentType: contentType, kind: entity.MediaKindImage}, nil
+}
+
+// imageDimensions reads the canvas size from the image header without
+// decoding any pixels.
+func imageDimensions(data []byte, contentType string) (int, int, error) {
+	if contentType == "image/webp" {
+		return webpDimensions(data)
+	}
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return 0, 0, err
+	}
+	if config.Width <= 0 || config.Height <= 0 {
+		return 0, 0, errors.New("empty image")
+	}
+	return config.Width, config.Height, nil
+}
+
+var errInvalidWebP = errors.New("invalid webp image")
+
+// webpDimensions parses the canvas size from the first chunk of a WebP file,
+// which is VP8X for extended files, VP8 for lossy and VP8L for lossless ones.
+func webpDimensions(data []byte) (int, int, error) {
+	if len(data) < 30 || string(data[0:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return 0, 0, errInvalidWebP
+	}
+	chunk := data[20:]
+	switch string(data[12:16]) {
+	case "VP8X":
+		width := int(chunk[4]) | int(chunk[5])<<8 | int(chunk[6])<<16
+		height := int(chunk[7]) | int(chunk[8])<<8 | int(chunk[9])<<16
+		return width + 1, height + 1, nil
+	case "VP8 ":
+		if chunk[3] != 0x9d || chunk[4] != 0x01 || chunk[5] != 0x2a {
+			return 0, 0, errInvalidWebP
+		}
+		width := int(binary.LittleEndian.Uint16(chunk[6:8]) & 0x3fff)
+		height := int(binary.LittleEndian.Uint16(chunk[8:10]) & 0x3fff)
+		if width == 0 || height == 0 {
+			return 0, 0, errInvalidWebP
+		}
+		return width, height, nil
+	case "VP8L":
+		if chunk[0] != 0x2f {
+			return 0, 0, errInvalidWebP
+		}
+		bits := binary.LittleEndian.Uint32(chunk[1:5])
+		return int(bits&0x3fff) + 1, int(bits>>14&0x3fff) + 1, nil
+	default:
+		return 0, 0, errInvalidWebP
+	}
+}
@@ -2,41 +2,36 @@ package service
 
 import (
 	"context"
-	"errors"
 	contract "raion-assessment/domain/contract"
 	entity "raion-assessment/domain/entity"
 )
 
 type likeService struct {
-	likeRepo contract.ILikeRepository
+	likeRepo    contract.ILikeRepository
+	commentRepo contract.ICommentRepository
+	events      contract.IEventBus
 }
 
-func NewLikeService(repo contract.ILikeRepository) contract.ILikeService {
-	return &likeService{likeRepo: repo}
+func NewLikeService(repo contract.ILikeRepository, commentRepo contract.ICommentRepository, events contract.IEventBus) contract.ILikeService {
+	return &likeService{likeRepo: repo, commentRepo: commentRepo, events: events}
 }
 
-func (s *likeService) GetLikesByPostID(postID string) ([]entity.Like, error) {
+func (s *likeService) GetLikesByPostID(postID string, page entity.PageRequest) ([]entity.Like, entity.PageInfo, error) {
 	ctx := context.Background()
-	likes, err := s.likeRepo.GetLikesByPostID(ctx, postID)
+	likes, pageInfo, err := s.likeRepo.GetLikesByPostID(ctx, postID, page)
 	if err != nil {
-		return nil, err
+		return nil, entity.PageInfo{}, err
 	}
-	if len(likes) == 0 {
-		return nil, errors.New("not found")
-	}
-	return likes, nil
+	return likes, pageInfo, nil
 }
 
-func (s *likeService) GetLikesByUserID(userID string) ([]entity.Like, error) {
+func (s *likeService) GetLikesByUserID(userID string, page entity.PageRequest) ([]entity.Like, entity.PageInfo, error) {
 	ctx := context.Background()
-	likes, err := s.likeRepo.GetLikesByUserID(ctx, userID)
+	likes, pageInfo, err := s.likeRepo.GetLikesByUserID(ctx, userID, page)
 	if err != nil {
-		return nil, err
-	}
-	if len(likes) == 0 {
-		return nil, errors.New("not found")
+		return nil, entity.PageInfo{}, err
 	}
-	return likes, nil
+	return likes, pageInfo, nil
 }
 
 func (s *likeService) AddLike(userID, postID string) (entity.Like, error) {
@@ -49,6 +44,7 @@ func (s *likeService) AddLike(userID, postID string) (entity.Like, error) {
 	if err != nil {
 		return entity.Like{}, err
 	}
+	s.events.Publish(entity.EventPostLiked, *createdLike)
 	return *createdLike, nil
 }
 
@@ -58,5 +54,67 @@ func (s *likeService) RemoveLike(userID, postID string) error {
 	if err != nil {
 		return err
 	}
+	s.events.Publish(entity.EventPostUnliked, entity.Like{UserID: userID, PostID: postID})
 	return nil
-}
\ No newline at end of file
+}
+
+func (s *likeService) GetLikesByCommentID(postID, commentID string, page entity.PageRequest) ([]entity.CommentLike, entity.PageInfo, error) {
+	ctx := context.Background()
+	if err := s.requireCommentOnPost(ctx, postID, commentID); err != nil {
+		return nil, entity.PageInfo{}, err
+	}
+	likes, pageInfo, err := s.likeRepo.GetLikesByCommentID(ctx, commentID, page)
+	if err != nil {
+		return nil, entity.PageInfo{}, err
+	}
+	return likes, pageInfo, nil
+}
+
+func (s *likeService) AddCommentLike(userID, postID, commentID string) (entity.CommentLike, error) {
+	ctx := context.Background()
+	if err := s.requireCommentOnPost(ctx, postID, commentID); err != nil {
+		return entity.CommentLike{}, err
+	}
+	like := entity.CommentLike{
+		UserID:    userID,
+		CommentID: commentID,
+	}
+	createdLike, err := s.likeRepo.AddCommentLike(ctx, like)
+	if err != nil {
+		return entity.CommentLike{}, err
+	}
+	s.events.Publish(entity.EventCommentLiked, *createdLike)
+	return *createdLike, nil
+}
+
+func (s *likeService) RemoveCommentLike(userID, postID, commentID string) error {
+	ctx := context.Background()
+	if err := s.requireCommentOnPost(ctx, postID, commentID); err != nil {
+		return err
+	}
+	if err := s.likeRepo.RemoveCommentLike(ctx, userID, commentID); err != nil {
+		return err
+	}
+	s.events.Publish(entity.EventCommentUnliked, entity.CommentLike{UserID: userID, CommentID: commentID})
+	return nil
+}
+
+func (s *likeService) GetLikesByPostIDs(postIDs []string, page entity.PageRequest) (map[string]entity.Page[entity.Like], error) {
+	ctx := context.Background()
+	return s.likeRepo.GetLikesByPostIDs(ctx, postIDs, page)
+}
+
+// requireCommentOnPost reports NotFound unless the comment exists and, when
+// postID is given, belongs to it, so that a comment cannot be liked or listed
+// under another post. Callers that address a comment on its own, such as
+// GraphQL, pass an empty postID.
+func (s *likeService) requireCommentOnPost(ctx context.Context, postID, commentID string) error {
+	comment, err := s.commentRepo.GetCommentByID(ctx, commentID)
+	if err != nil {
+		return err
+	}
+	if comment == nil || (postID != "" && comment.PostID != postID) {
+		return entity.NotFound("comment not found")
+	}
+	return nil
+}
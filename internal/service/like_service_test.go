@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"errors"
+	contract "raion-assessment/domain/contract"
+	entity "raion-assessment/domain/entity"
+	"testing"
+)
+
+const (
+	commentPostID = "2d4b6f80-1a3c-4e5f-8a7b-9c0d1e2f3a4b"
+	otherPostID   = "7e9a1c3b-5d2f-4a6e-8b0c-1d3f5a7b9c2e"
+	testCommentID = "c1a2b3d4-e5f6-4a7b-8c9d-0e1f2a3b4c5d"
+)
+
+type oneCommentRepo struct {
+	contract.ICommentRepository
+}
+
+func (oneCommentRepo) GetCommentByID(ctx context.Context, commentID string) (*entity.Comment, error) {
+	if commentID != testCommentID {
+		return nil, entity.NotFound("comment not found")
+	}
+	return &entity.Comment{ID: testCommentID, PostID: commentPostID}, nil
+}
+
+// recordingLikeRepo notes whether any comment like was read or written.
+type recordingLikeRepo struct {
+	contract.ILikeRepository
+	touched bool
+}
+
+func (r *recordingLikeRepo) GetLikesByCommentID(ctx context.Context, commentID string, page entity.PageRequest) ([]entity.CommentLike, entity.PageInfo, error) {
+	r.touched = true
+	return []entity.CommentLike{}, entity.PageInfo{}, nil
+}
+
+func (r *recordingLikeRepo) AddCommentLike(ctx context.Context, like entity.CommentLike) (*entity.CommentLike, error) {
+	r.touched = true
+	return &like, nil
+}
+
+func (r *recordingLikeRepo) RemoveCommentLike(ctx context.Context, userID, commentID string) error {
+	r.touched = true
+	return nil
+}
+
+type discardEvents struct {
+	contract.IEventBus
+}
+
+func (discardEvents) Publish(topic string, payload interface{}) {}
+
+func TestCommentLikesRequireTheCommentsPost(t *testing.T) {
+	calls := map[string]func(s contract.ILikeService, postID string) error{
+		"GetLikesByCommentID": func(s contract.ILikeService, postID string) error {
+			_, _, err := s.GetLikesByCommentID(postID, testCommentID, entity.PageRequest{})
+			return err
+		},
+		"AddCommentLike": func(s contract.ILikeService, postID string) error {
+			_, err := s.AddCommentLike("user-1", postID, testCommentID)
+			return err
+		},
+		"RemoveCommentLike": func(s contract.ILikeService, postID string) error {
+			return s.RemoveCommentLike("user-1", postID, testCommentID)
+		},
+	}
+	for name, call := range calls {
+		for _, postID := range []string{commentPostID, ""} {
+			likes := &recordingLikeRepo{}
+			if err := call(NewLikeService(likes, oneCommentRepo{}, discardEvents{}), postID); err != nil || !likes.touched {
+				t.Errorf("%s under post %q: got %v, want the like repository to be used", name, postID, err)
+			}
+		}
+
+		likes := &recordingLikeRepo{}
+		err := call(NewLikeService(likes, oneCommentRepo{}, discardEvents{}), otherPostID)
+		if !errors.Is(err, entity.ErrNotFound) || likes.touched {
+			t.Errorf("%s under another post: got %v, want a not-found error before the like repository is used", name, err)
+		}
+	}
+}
+
+func TestCommentLikesRequireAnExistingComment(t *testing.T) {
+	s := NewLikeService(&recordingLikeRepo{}, oneCommentRepo{}, discardEvents{})
+	const missingCommentID = "0f1e2d3c-4b5a-4969-8877-665544332211"
+
+	for _, postID := range []string{commentPostID, ""} {
+		if _, err := s.AddCommentLike("user-1", postID, missingCommentID); !errors.Is(err, entity.ErrNotFound) {
+			t.Errorf("AddCommentLike under post %q: got %v, want a not-found error", postID, err)
+		}
+		if err := s.RemoveCommentLike("user-1", postID, missingCommentID); !errors.Is(err, entity.ErrNotFound) {
+			t.Errorf("RemoveCommentLike under post %q: got %v, want a not-found error", postID, err)
+		}
+	}
+}
+
+// recordingEvents notes the topics published, in order.
+type recordingEvents struct {
+	contract.IEventBus
+	topics []string
+}
+
+func (e *recordingEvents) Publish(topic string, payload interface{}) {
+	e.topics = append(e.topics, topic)
+}
+
+func TestCommentLikesPublishEvents(t *testing.T) {
+	events := &recordingEvents{}
+	s := NewLikeService(&recordingLikeRepo{}, oneCommentRepo{}, events)
+
+	if _, err := s.AddCommentLike("user-1", commentPostID, testCommentID); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.RemoveCommentLike("user-1", commentPostID, testCommentID); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{entity.EventCommentLiked, entity.EventCommentUnliked}
+	if len(events.topics) != len(want) || events.topics[0] != want[0] || events.topics[1] != want[1] {
+		t.Errorf("published %v, want %v", events.topics, want)
+	}
+}
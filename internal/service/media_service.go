@@ -0,0 +1,454 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	contract "raion-assessment/domain/contract"
+	entity "raion-assessment/domain/entity"
+	"raion-assessment/pkg/imaging"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type renditionSpec struct {
+	name    string
+	maxSide int
+	quality int
+}
+
+// renditionSpecs lists the renditions generated for each media purpose.
+var renditionSpecs = map[string][]renditionSpec{
+	entity.MediaPurposePost: {
+		{entity.RenditionThumbnail, 320, 80},
+		{entity.RenditionFeed, 1080, 82},
+		{entity.RenditionFull, 2048, 85},
+	},
+	entity.MediaPurposeAvatar: {
+		{entity.RenditionThumbnail, 96, 80},
+		{entity.RenditionFeed, 320, 82},
+		{entity.RenditionFull, 1024, 85},
+	},
+}
+
+// placeholderSampleSize is the longest side of the copy BlurHash and the
+// dominant colour are computed from.
+const placeholderSampleSize = 64
+
+// maxProcessingAttempts is how often the worker tries a video before marking
+// it failed.
+const maxProcessingAttempts = 3
+
+// mediaProcessingTimeout bounds processing one item, ffmpeg included. It is
+// shorter than the 15 minutes after which ClaimPendingMedia hands a claimed
+// item to another worker, so a file that makes ffmpeg hang is given up on
+// before it can be processed twice.
+const mediaProcessingTimeout = 10 * time.Minute
+
+// unreferencedMediaBatch is how many unused media items are removed per query.
+const unreferencedMediaBatch = 100
+
+// videoExtensions names the stored source file of each accepted video type.
+var videoExtensions = map[string]string{
+	"video/mp4":       ".mp4",
+	"video/quicktime": ".mov",
+	"video/webm":      ".webm",
+	"image/gif":       ".gif",
+}
+
+type mediaService struct {
+	mediaRepo  contract.IMediaRepository
+	uploads    contract.IUploadService
+	store      contract.IMediaStore
+	transcoder contract.IVideoTranscoder
+	quota      int64
+}
+
+// NewMediaService returns a media service keeping files in store and reading
+// resumable and direct uploads from uploads. Each user may keep up to quota
+// bytes of media.
+func NewMediaService(mediaRepo contract.IMediaRepository, uploads contract.IUploadService, store contract.IMediaStore, transcoder contract.IVideoTranscoder, quota int64) contract.IMediaService {
+	return &mediaService{mediaRepo: mediaRepo, uploads: uploads, store: store, transcoder: transcoder, quota: quota}
+}
+
+// ingestImage decodes a validated upload, renders every rendition for purpose
+// as an upright, metadata-free JPEG and records the result. The files are
+// stored under <purpose>/<owner>/<media id>/ and removed again if they would
+// take the owner past their quota or the record cannot be saved.
+func (s *mediaService) ingestImage(ownerID, purpose string, upload entity.ImageUpload) (entity.Media, error) {
+	ctx := context.Background()
+	if _, ok := renditionSpecs[purpose]; !ok {
+		return entity.Media{}, fmt.Errorf("unknown media purpose %q", purpose)
+	}
+
+	img, err := imaging.Decode(upload.Data)
+	if err != nil {
+		return entity.Media{}, entity.Validation("image could not be decoded", entity.FieldError{
+			Field: "image", Code: "image", Message: "image is not a readable image",
+		})
+	}
+
+	media := entity.Media{
+		ID:          uuid.NewString(),
+		OwnerID:     ownerID,
+		Purpose:     purpose,
+		Kind:        entity.MediaKindImage,
+		Status:      entity.MediaStatusReady,
+		ContentType: upload.ContentType,
+	}
+	prefix := purpose + "/" + ownerID + "/" + media.ID + "/"
+	if err := s.renderStills(ctx, &media, img, imaging.ExifOrientation(upload.Data), purpose, prefix); err != nil {
+		return entity.Media{}, err
+	}
+	media.SizeBytes = renditionsSize(media.Renditions)
+
+	created, err := s.mediaRepo.CreateMedia(ctx, media, s.quota)
+	if err != nil {
+		s.removeRenditions(ctx, media.Renditions)
+		return entity.Media{}, err
+	}
+	return s.withURLs(*created), nil
+}
+
+// ingestVideo stores a validated video or animated GIF as the source for the
+// media worker and records it as pending, provided it fits in the owner's
+// quota. Videos are only attached to posts.
+func (s *mediaService) ingestVideo(ownerID string, upload entity.VideoUpload) (entity.Media, error) {
+	ctx := context.Background()
+	media := entity.Media{
+		ID:          uuid.NewString(),
+		OwnerID:     ownerID,
+		Purpose:     entity.MediaPurposePost,
+		Kind:        entity.MediaKindVideo,
+		Status:      entity.MediaStatusPending,
+		ContentType: upload.ContentType,
+		DurationMS:  int(upload.Duration.Milliseconds()),
+		SizeBytes:   int64(len(upload.Data)),
+	}
+	media.SourceKey = entity.MediaPurposePost + "/" + ownerID + "/" + media.ID + "/source" + videoExtensions[upload.ContentType]
+
+	if err := s.store.Put(ctx, media.SourceKey, bytes.NewReader(upload.Data), int64(len(upload.Data)), upload.ContentType); err != nil {
+		return entity.Media{}, err
+	}
+	created, err := s.mediaRepo.CreateMedia(ctx, media, s.quota)
+	if err != nil {
+		s.removeKey(ctx, media.SourceKey)
+		return entity.Media{}, err
+	}
+	return s.withURLs(*created), nil
+}
+
+// ProcessPendingMedia claims the oldest pending video, transcodes it and
+// stores the MP4 next to poster renditions made like those of an image. It
+// reports whether there was anything to process. A failure is retried on a
+// later call until maxProcessingAttempts is reached, after which the item is
+// marked failed. An item that takes longer than mediaProcessingTimeout is
+// marked failed at once, as it would most likely hang again, and so is one
+// whose renditions do not fit in its owner's quota.
+func (s *mediaService) ProcessPendingMedia() (bool, error) {
+	ctx := context.Background()
+	media, err := s.mediaRepo.ClaimPendingMedia(ctx)
+	if err != nil || media == nil {
+		return false, err
+	}
+
+	processCtx, cancel := context.WithTimeout(ctx, mediaProcessingTimeout)
+	err = s.processVideo(processCtx, media)
+	timedOut := errors.Is(processCtx.Err(), context.DeadlineExceeded)
+	cancel()
+
+	if err != nil {
+		if timedOut {
+			err = fmt.Errorf("processing took longer than %s: %w", mediaProcessingTimeout, err)
+		}
+		failed := timedOut || media.Attempts >= maxProcessingAttempts || errors.Is(err, entity.ErrQuotaExceeded)
+		if releaseErr := s.mediaRepo.ReleaseMedia(ctx, media.ID, err.Error(), failed); releaseErr != nil {
+			log.Printf("Failed to release media %s: %v", media.ID, releaseErr)
+		}
+		return true, fmt.Errorf("processing media %s (attempt %d): %w", media.ID, media.Attempts, err)
+	}
+	return true, nil
+}
+
+func (s *mediaService) processVideo(ctx context.Context, media *entity.Media) error {
+	dir, err := os.MkdirTemp("", "media-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	sourcePath := filepath.Join(dir, "source"+videoExtensions[media.ContentType])
+	if err := s.download(ctx, media.SourceKey, sourcePath); err != nil {
+		return err
+	}
+
+	videoPath := filepath.Join(dir, "video.mp4")
+	if err := s.transcoder.Transcode(ctx, sourcePath, videoPath); err != nil {
+		return err
+	}
+	poster, err := s.transcoder.Poster(ctx, videoPath)
+	if err != nil {
+		return err
+	}
+	img, err := imaging.Decode(poster)
+	if err != nil {
+		return fmt.Errorf("decoding poster frame: %w", err)
+	}
+
+	prefix := path.Dir(media.SourceKey) + "/"
+	video := entity.Rendition{
+		Name:        entity.RenditionVideo,
+		Key:         prefix + "video.mp4",
+		ContentType: "video/mp4",
+		Width:       img.Bounds().Dx(),
+		Height:      img.Bounds().Dy(),
+	}
+	video.Size, err = s.upload(ctx, videoPath, video.Key, video.ContentType)
+	if err != nil {
+		return err
+	}
+	if err := s.renderStills(ctx, media, img, imaging.OrientationNormal, entity.MediaPurposePost, prefix); err != nil {
+		s.removeKey(ctx, video.Key)
+		return err
+	}
+	media.Renditions = append([]entity.Rendition{video}, media.Renditions...)
+	media.SizeBytes = renditionsSize(media.Renditions)
+
+	if err := s.mediaRepo.CompleteMedia(ctx, *media, s.quota); err != nil {
+		s.removeRenditions(ctx, media.Renditions)
+		return err
+	}
+	s.removeKey(ctx, media.SourceKey)
+	return nil
+}
+
+// renderStills fills in media's dimensions and placeholders from img and
+// stores the still renditions of purpose under prefix, appending them to
+// media.Renditions. Stored files are removed again if any rendition fails.
+func (s *mediaService) renderStills(ctx context.Context, media *entity.Media, img image.Image, orientation int, purpose, prefix string) error {
+	media.Width, media.Height = img.Bounds().Dx(), img.Bounds().Dy()
+	if imaging.SwapsAxes(orientation) {
+		media.Width, media.Height = media.Height, media.Width
+	}
+
+	// Placeholders are computed from a tiny upright copy: both only need the
+	// overall colour layout and cost a pass over every pixel.
+	sample := imaging.Orient(imaging.Fit(img, placeholderSampleSize), orientation)
+	xComponents, yComponents := 4, 3
+	if media.Height > media.Width {
+		xComponents, yComponents = 3, 4
+	}
+	media.BlurHash = imaging.BlurHash(sample, xComponents, yComponents)
+	media.DominantColor = imaging.DominantColor(sample)
+
+	var stored []entity.Rendition
+	for _, spec := range renditionSpecs[purpose] {
+		rendered := imaging.Orient(imaging.Fit(img, spec.maxSide), orientation)
+		data, err := imaging.EncodeJPEG(rendered, spec.quality)
+		if err != nil {
+			s.removeRenditions(ctx, stored)
+			return err
+		}
+
+		rendition := entity.Rendition{
+			Name:        spec.name,
+			Key:         prefix + spec.name + ".jpg",
+			ContentType: "image/jpeg",
+			Width:       rendered.Bounds().Dx(),
+			Height:      rendered.Bounds().Dy(),
+			Size:        int64(len(data)),
+		}
+		if err := s.store.Put(ctx, rendition.Key, bytes.NewReader(data), int64(len(data)), rendition.ContentType); err != nil {
+			s.removeRenditions(ctx, stored)
+			return err
+		}
+		stored = append(stored, rendition)
+	}
+	media.Renditions = append(media.Renditions, stored...)
+	return nil
+}
+
+// download copies a stored object to a local file for the transcoder.
+func (s *mediaService) download(ctx context.Context, key, dst string) error {
+	src, err := s.store.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	file, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(file, src); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// upload stores a local file produced by the transcoder under key and
+// returns its size.
+func (s *mediaService) upload(ctx context.Context, src, key, contentType string) (int64, error) {
+	file, err := os.Open(src)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), s.store.Put(ctx, key, file, info.Size(), contentType)
+}
+
+func (s *mediaService) FetchPostMedia(postIDs []string) (map[string][]entity.PostMedia, error) {
+	if len(postIDs) == 0 {
+		return map[string][]entity.PostMedia{}, nil
+	}
+	media, err := s.mediaRepo.FetchPostMedia(context.Background(), postIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, items := range media {
+		for i := range items {
+			items[i].Media = s.withURLs(items[i].Media)
+		}
+	}
+	return media, nil
+}
+
+func (s *mediaService) FetchMedia(ids []string) (map[string]entity.Media, error) {
+	if len(ids) == 0 {
+		return map[string]entity.Media{}, nil
+	}
+	media, err := s.mediaRepo.FetchMedia(context.Background(), ids)
+	if err != nil {
+		return nil, err
+	}
+	for id, item := range media {
+		media[id] = s.withURLs(item)
+	}
+	return media, nil
+}
+
+func (s *mediaService) FetchUserAvatars(userIDs []string) (map[string]entity.Media, error) {
+	if len(userIDs) == 0 {
+		return map[string]entity.Media{}, nil
+	}
+	media, err := s.mediaRepo.FetchUserAvatars(context.Background(), userIDs)
+	if err != nil {
+		return nil, err
+	}
+	for userID, item := range media {
+		media[userID] = s.withURLs(item)
+	}
+	return media, nil
+}
+
+// FetchStorageUsage reports how much of their quota the user has taken up.
+func (s *mediaService) FetchStorageUsage(ownerID string) (entity.StorageUsage, error) {
+	usage, err := s.mediaRepo.FetchStorageUsage(context.Background(), ownerID)
+	if err != nil {
+		return entity.StorageUsage{}, err
+	}
+	usage.Quota = s.quota
+	return usage, nil
+}
+
+// SweepUnreferencedMedia deletes media that no post or profile has used for
+// at least unusedFor, such as replaced avatars and the images of deleted
+// posts, together with its files. It returns how many items there were.
+func (s *mediaService) SweepUnreferencedMedia(unusedFor time.Duration) (int, error) {
+	ctx := context.Background()
+	var swept int
+	for {
+		media, err := s.mediaRepo.DeleteUnreferencedMedia(ctx, unusedFor, unreferencedMediaBatch)
+		if err != nil {
+			return swept, err
+		}
+		s.removeMedia(ctx, media)
+		swept += len(media)
+		if len(media) < unreferencedMediaBatch {
+			return swept, nil
+		}
+	}
+}
+
+// DeleteOwnerMedia deletes every media item the user owns together with its
+// files. Deleting a user removes their media rows through the foreign key,
+// which would leave the files behind where the sweeper cannot find them.
+func (s *mediaService) DeleteOwnerMedia(ownerID string) error {
+	ctx := context.Background()
+	media, err := s.mediaRepo.DeleteOwnerMedia(ctx, ownerID)
+	if err != nil {
+		return err
+	}
+	s.removeMedia(ctx, media)
+	return nil
+}
+
+// renditionsSize totals the stored size of renditions.
+func renditionsSize(renditions []entity.Rendition) int64 {
+	var size int64
+	for _, rendition := range renditions {
+		size += rendition.Size
+	}
+	return size
+}
+
+// SignURL replaces a URL into the media store with a freshly signed one for
+// the same key. Other URLs, such as the default avatar, are returned as they
+// are.
+func (s *mediaService) SignURL(rawURL string) string {
+	key, ok := s.store.KeyOf(rawURL)
+	if !ok {
+		return rawURL
+	}
+	return s.store.SignedURL(key)
+}
+
+// withURLs fills in each rendition's signed URL from the store. URLs are
+// derived when media is read rather than saved, so moving storage never
+// leaves stale links and the links handed out expire.
+func (s *mediaService) withURLs(media entity.Media) entity.Media {
+	renditions := make([]entity.Rendition, len(media.Renditions))
+	for i, rendition := range media.Renditions {
+		rendition.URL = s.store.SignedURL(rendition.Key)
+		renditions[i] = rendition
+	}
+	media.Renditions = renditions
+	return media
+}
+
+// removeMedia deletes the files of media whose records are gone.
+func (s *mediaService) removeMedia(ctx context.Context, media []entity.Media) {
+	for _, item := range media {
+		for _, key := range item.Keys() {
+			s.removeKey(ctx, key)
+		}
+	}
+}
+
+func (s *mediaService) removeRenditions(ctx context.Context, renditions []entity.Rendition) {
+	for _, rendition := range renditions {
+		s.removeKey(ctx, rendition.Key)
+	}
+}
+
+// removeKey deletes a stored file, even once ctx is done: it cleans up after
+// work that may have been cut short by a timeout.
+func (s *mediaService) removeKey(ctx context.Context, key string) {
+	if err := s.store.Delete(context.WithoutCancel(ctx), key); err != nil {
+		log.Printf("Failed to remove media file %s: %v", key, err)
+	}
+}
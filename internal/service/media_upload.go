@@ -0,0 +1,341 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	entity "raion-assessment/domain/entity"
+	"raion-assessment/pkg/request"
+	"strconv"
+	"strings"
+)
+
+// UploadPostMedia ingests the media of a new post: files in order, followed
+// by the finished resumable uploads uploadIDs and the media uploaded ahead of
+// time mediaIDs, pairing each with the alt text at the same index. Images are
+// rendered straight away; videos and animated GIFs are stored for the media
+// worker and start out pending. Every file is checked before any is ingested,
+// so one bad file does not leave the others stored without a post, but only
+// one is held in memory at a time: each is read again to be ingested. The
+// uploads are deleted once their content is ingested.
+func (s *mediaService) UploadPostMedia(ctx context.Context, ownerID string, files []io.ReadSeeker, uploadIDs, mediaIDs, altTexts []string) ([]entity.PostMedia, error) {
+	locale := request.LocaleFromContext(ctx)
+	total := len(files) + len(uploadIDs) + len(mediaIDs)
+	if total == 0 {
+		return nil, request.Invalid(locale, "media", "required")
+	}
+	if total > entity.MaxPostMedia {
+		return nil, request.InvalidParam(locale, "media", "max_items", strconv.Itoa(entity.MaxPostMedia))
+	}
+	if len(altTexts) > total {
+		return nil, request.InvalidParam(locale, "alt_text", "max_items", strconv.Itoa(total))
+	}
+
+	uploaded, err := s.openUploads(ctx, ownerID, uploadIDs, "upload_id")
+	if err != nil {
+		return nil, err
+	}
+	defer closeUploads(uploaded)
+	existing, err := s.loadPostMedia(ctx, ownerID, mediaIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	srcs := make([]io.ReadSeeker, 0, len(files)+len(uploaded))
+	srcs = append(srcs, files...)
+	for _, upload := range uploaded {
+		srcs = append(srcs, upload)
+	}
+	for i, src := range srcs {
+		if _, err := inspectPostMedia(ctx, src, fmt.Sprintf("media[%d]", i)); err != nil {
+			return nil, err
+		}
+	}
+
+	all := make([]entity.Media, 0, total)
+	for i, src := range srcs {
+		if _, err := src.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+		upload, err := inspectPostMedia(ctx, src, fmt.Sprintf("media[%d]", i))
+		if err != nil {
+			return nil, err
+		}
+		ingested, err := s.ingestChecked(ownerID, entity.MediaPurposePost, upload)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, ingested)
+	}
+	s.finishUploads(ownerID, uploadIDs)
+
+	items := make([]entity.PostMedia, 0, total)
+	for i, item := range append(all, existing...) {
+		postMedia := entity.PostMedia{Position: i, Media: item}
+		if i < len(altTexts) {
+			postMedia.AltText = altTexts[i]
+		}
+		items = append(items, postMedia)
+	}
+	return items, nil
+}
+
+// UploadProfileImage sets up a new profile image for the owner from the first
+// of a file, the finished resumable upload uploadID or the image uploaded
+// ahead of time mediaID that is given, and returns nil when none is. The
+// upload is deleted once its content is ingested.
+func (s *mediaService) UploadProfileImage(ctx context.Context, ownerID string, file io.Reader, uploadID, mediaID string) (*entity.Media, error) {
+	var avatar entity.Media
+	var err error
+	switch {
+	case file != nil:
+		avatar, err = s.uploadImage(ctx, ownerID, entity.MediaPurposeAvatar, file)
+	case uploadID != "":
+		var src *spooledUpload
+		if src, err = s.openUpload(ctx, ownerID, uploadID, "upload_id"); err != nil {
+			return nil, err
+		}
+		defer src.Close()
+		if avatar, err = s.uploadImage(ctx, ownerID, entity.MediaPurposeAvatar, src); err == nil {
+			s.finishUploads(ownerID, []string{uploadID})
+		}
+	case mediaID != "":
+		avatar, err = s.loadProfileMedia(ctx, ownerID, mediaID)
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &avatar, nil
+}
+
+// CheckDirectUpload checks the type and size a client declares for a direct
+// upload against the limits its purpose accepts, before a URL is handed out,
+// and returns the type in the form the stored file must match. The declared
+// type is checked against the stored file again on completion.
+func (s *mediaService) CheckDirectUpload(ctx context.Context, purpose, contentType string, size int64) (string, error) {
+	locale := request.LocaleFromContext(ctx)
+	contentType, _, _ = strings.Cut(strings.ToLower(contentType), ";")
+	contentType = strings.TrimSpace(contentType)
+
+	limit, ok := imageLimits[contentType]
+	if !ok && purpose == entity.MediaPurposePost {
+		limit, ok = videoLimits[contentType]
+	}
+	if !ok {
+		code := "file_type"
+		if purpose == entity.MediaPurposePost {
+			code = "media_type"
+		}
+		return "", request.Invalid(locale, "content_type", code)
+	}
+	if size > limit {
+		return "", request.InvalidParam(locale, "size", "file_size", fmt.Sprintf("%d MB", limit>>20))
+	}
+	return contentType, nil
+}
+
+// CompleteDirectUpload reads back the file a client sent to the presigned URL
+// of a direct upload, checks it like any other upload of its purpose and
+// ingests it. The file must have the type and size that were declared for
+// it. The upload and its stored object are deleted once the media exists.
+func (s *mediaService) CompleteDirectUpload(ctx context.Context, ownerID, id string) (entity.Media, error) {
+	locale := request.LocaleFromContext(ctx)
+
+	upload, object, err := s.uploads.OpenDirectUpload(ownerID, id)
+	if err != nil {
+		return entity.Media{}, err
+	}
+	data, err := io.ReadAll(io.LimitReader(object, upload.Size+1))
+	object.Close()
+	if err != nil {
+		return entity.Media{}, err
+	}
+	if int64(len(data)) != upload.Size {
+		return entity.Media{}, request.Invalid(locale, "file", "upload_size")
+	}
+
+	var checked checkedUpload
+	if upload.Purpose == entity.MediaPurposePost {
+		checked, err = inspectPostMedia(ctx, bytes.NewReader(data), "file")
+	} else {
+		checked, err = checkImage(locale, data, "file")
+	}
+	if err != nil {
+		return entity.Media{}, err
+	}
+	if checked.contentType != upload.ContentType {
+		return entity.Media{}, request.Invalid(locale, "file", "content_type")
+	}
+
+	ingested, err := s.ingestChecked(ownerID, upload.Purpose, checked)
+	if err != nil {
+		return entity.Media{}, err
+	}
+	if err := s.uploads.DeleteDirectUpload(ownerID, id); err != nil {
+		log.Printf("Failed to delete completed direct upload %s: %v", id, err)
+	}
+	return ingested, nil
+}
+
+// uploadImage checks an uploaded image, reporting failures on the image
+// field, and ingests it for purpose.
+func (s *mediaService) uploadImage(ctx context.Context, ownerID, purpose string, src io.Reader) (entity.Media, error) {
+	img, err := inspectImage(ctx, src, "image")
+	if err != nil {
+		return entity.Media{}, err
+	}
+	return s.ingestChecked(ownerID, purpose, img)
+}
+
+// ingestChecked hands a checked upload on: videos go to the media worker and
+// images are rendered for purpose.
+func (s *mediaService) ingestChecked(ownerID, purpose string, upload checkedUpload) (entity.Media, error) {
+	if upload.kind == entity.MediaKindVideo {
+		return s.ingestVideo(ownerID, entity.VideoUpload{Data: upload.data, ContentType: upload.contentType, Duration: upload.duration})
+	}
+	return s.ingestImage(ownerID, purpose, entity.ImageUpload{Data: upload.data, ContentType: upload.contentType})
+}
+
+// spooledUpload is a finished resumable upload copied to a temporary file, so
+// that it can be read more than once without being held in memory. Closing it
+// removes the file.
+type spooledUpload struct {
+	*os.File
+}
+
+func (u *spooledUpload) Close() error {
+	err := u.File.Close()
+	if removeErr := os.Remove(u.Name()); err == nil {
+		err = removeErr
+	}
+	return err
+}
+
+// openUpload copies a finished resumable upload of ownerID to a temporary
+// file so it can be checked like a file. Nothing beyond the largest upload
+// size is copied. An unknown, expired or unfinished upload is reported as a
+// field error on field. The caller closes the upload.
+func (s *mediaService) openUpload(ctx context.Context, ownerID, id, field string) (*spooledUpload, error) {
+	locale := request.LocaleFromContext(ctx)
+
+	upload, err := s.uploads.FetchUpload(ownerID, id)
+	if errors.Is(err, entity.ErrNotFound) {
+		return nil, request.Invalid(locale, field, "upload")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !upload.Complete() {
+		return nil, request.Invalid(locale, field, "upload_incomplete")
+	}
+
+	src, err := s.uploads.OpenUpload(ownerID, id)
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+
+	file, err := os.CreateTemp("", "upload-")
+	if err != nil {
+		return nil, err
+	}
+	spooled := &spooledUpload{file}
+	if _, err := io.Copy(file, io.LimitReader(src, entity.MaxVideoUploadSize+1)); err != nil {
+		spooled.Close()
+		return nil, err
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		spooled.Close()
+		return nil, err
+	}
+	return spooled, nil
+}
+
+// openUploads opens each of ids with openUpload, reporting errors on
+// field[i]. The caller closes the uploads with closeUploads.
+func (s *mediaService) openUploads(ctx context.Context, ownerID string, ids []string, field string) ([]*spooledUpload, error) {
+	srcs := make([]*spooledUpload, 0, len(ids))
+	for i, id := range ids {
+		src, err := s.openUpload(ctx, ownerID, id, fmt.Sprintf("%s[%d]", field, i))
+		if err != nil {
+			closeUploads(srcs)
+			return nil, err
+		}
+		srcs = append(srcs, src)
+	}
+	return srcs, nil
+}
+
+// closeUploads closes uploads opened with openUploads, removing their files.
+func closeUploads(uploads []*spooledUpload) {
+	for _, upload := range uploads {
+		upload.Close()
+	}
+}
+
+// finishUploads deletes resumable uploads whose content has been ingested.
+// A failure only leaves the upload to expire, so it is logged and ignored.
+func (s *mediaService) finishUploads(ownerID string, ids []string) {
+	for _, id := range ids {
+		if err := s.uploads.DeleteUpload(ownerID, id); err != nil {
+			log.Printf("Failed to delete finished upload %s: %v", id, err)
+		}
+	}
+}
+
+// loadPostMedia fetches media the owner uploaded ahead of time to attach to a
+// post, reporting unusable IDs on media_id[i].
+func (s *mediaService) loadPostMedia(ctx context.Context, ownerID string, ids []string) ([]entity.Media, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	locale := request.LocaleFromContext(ctx)
+
+	found, err := s.FetchMedia(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]entity.Media, 0, len(ids))
+	seen := make(map[string]bool, len(ids))
+	for i, id := range ids {
+		field := fmt.Sprintf("media_id[%d]", i)
+		if seen[id] {
+			return nil, request.Invalid(locale, field, "duplicate")
+		}
+		seen[id] = true
+
+		item, ok := found[id]
+		if !usableMedia(item, ok, ownerID, entity.MediaPurposePost) {
+			return nil, request.Invalid(locale, field, "media")
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// loadProfileMedia fetches an image the owner uploaded ahead of time to use as
+// their profile image, reporting an unusable ID on media_id.
+func (s *mediaService) loadProfileMedia(ctx context.Context, ownerID, id string) (entity.Media, error) {
+	found, err := s.FetchMedia([]string{id})
+	if err != nil {
+		return entity.Media{}, err
+	}
+	item, ok := found[id]
+	if !usableMedia(item, ok, ownerID, entity.MediaPurposeAvatar) {
+		return entity.Media{}, request.Invalid(request.LocaleFromContext(ctx), "media_id", "media")
+	}
+	return item, nil
+}
+
+// usableMedia says whether media may be attached by ownerID for purpose: it
+// must be theirs, rendered for that purpose and not have failed processing.
+func usableMedia(item entity.Media, ok bool, ownerID, purpose string) bool {
+	return ok && item.OwnerID == ownerID && item.Purpose == purpose && item.Status != entity.MediaStatusFailed
+}
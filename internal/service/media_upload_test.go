@@ -0,0 +1,108 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	contract "raion-assessment/domain/contract"
+	entity "raion-assessment/domain/entity"
+	"testing"
+)
+
+// memoryUploads serves finished resumable uploads from memory and counts how
+// many of their readers are still open.
+type memoryUploads struct {
+	contract.IUploadService
+	data map[string][]byte
+	open int
+}
+
+func (u *memoryUploads) FetchUpload(ownerID, id string) (entity.Upload, error) {
+	data, ok := u.data[id]
+	if !ok {
+		return entity.Upload{}, entity.NotFound("upload not found")
+	}
+	return entity.Upload{ID: id, OwnerID: ownerID, Length: int64(len(data)), Offset: int64(len(data))}, nil
+}
+
+func (u *memoryUploads) OpenUpload(ownerID, id string) (io.ReadCloser, error) {
+	u.open++
+	return &countedReader{Reader: bytes.NewReader(u.data[id]), open: &u.open}, nil
+}
+
+type countedReader struct {
+	io.Reader
+	open *int
+}
+
+func (r *countedReader) Close() error {
+	*r.open--
+	return nil
+}
+
+func TestOpenUploadSpoolsToATemporaryFile(t *testing.T) {
+	oversized := bytes.Repeat([]byte{'x'}, entity.MaxVideoUploadSize+100)
+	uploads := &memoryUploads{data: map[string][]byte{"small": []byte("upload content"), "big": oversized}}
+	s := &mediaService{uploads: uploads}
+
+	tests := []struct {
+		id   string
+		want int
+	}{
+		{"small", len("upload content")},
+		{"big", entity.MaxVideoUploadSize + 1},
+	}
+	for _, test := range tests {
+		t.Run(test.id, func(t *testing.T) {
+			spooled, err := s.openUpload(context.Background(), "owner", test.id, "upload_id")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if uploads.open != 0 {
+				t.Errorf("%d upload readers left open", uploads.open)
+			}
+
+			for pass := 0; pass < 2; pass++ {
+				data, err := io.ReadAll(spooled)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(data) != test.want || !bytes.Equal(data, uploads.data[test.id][:test.want]) {
+					t.Fatalf("pass %d: read %d bytes, want the first %d of the upload", pass, len(data), test.want)
+				}
+				if _, err := spooled.Seek(0, io.SeekStart); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			name := spooled.Name()
+			if err := spooled.Close(); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := os.Stat(name); !errors.Is(err, fs.ErrNotExist) {
+				t.Errorf("temporary file %s left behind: %v", name, err)
+			}
+		})
+	}
+}
+
+// TestUploadPostMediaChecksEveryFileFirst relies on the nil media repository
+// and store: ingesting anything would panic.
+func TestUploadPostMediaChecksEveryFileFirst(t *testing.T) {
+	uploads := &memoryUploads{data: map[string][]byte{"text": []byte("not a media file")}}
+	s := &mediaService{uploads: uploads}
+
+	files := []io.ReadSeeker{bytes.NewReader(gifFile(0))}
+	_, err := s.UploadPostMedia(context.Background(), "owner", files, []string{"text"}, nil, nil)
+
+	domainErr, ok := entity.AsError(err)
+	if !ok || !errors.Is(err, entity.ErrValidation) || len(domainErr.Fields) != 1 || domainErr.Fields[0].Field != "media[1]" {
+		t.Fatalf("got %v, want a validation error on media[1]", err)
+	}
+	if uploads.open != 0 {
+		t.Errorf("%d upload readers left open", uploads.open)
+	}
+}
@@ -2,46 +2,51 @@ package service
 
 import (
 	"context"
-	"errors"
 	contract "raion-assessment/domain/contract"
 	entity "raion-assessment/domain/entity"
 )
 
 type postService struct {
 	postRepo contract.IPostRepository
+	userRepo contract.IUserRepository
+	media    contract.IMediaService
+	events   contract.IEventBus
 }
 
-func NewPostService(repo contract.IPostRepository) contract.IPostService {
-	return &postService{postRepo: repo}
+func NewPostService(repo contract.IPostRepository, userRepo contract.IUserRepository, media contract.IMediaService, events contract.IEventBus) contract.IPostService {
+	return &postService{postRepo: repo, userRepo: userRepo, media: media, events: events}
 }
 
-func (s *postService) FetchAllPosts() ([]entity.Post, error) {
+func (s *postService) FetchAllPosts(viewerID string, page entity.PageRequest) ([]entity.Post, entity.PageInfo, error) {
 	ctx := context.Background()
-	return s.postRepo.FetchAllPosts(ctx)
+	posts, pageInfo, err := s.postRepo.FetchAllPosts(ctx, page)
+	if err != nil {
+		return nil, entity.PageInfo{}, err
+	}
+	posts, err = s.decoratePosts(ctx, posts, viewerID)
+	return posts, pageInfo, err
 }
 
-func (s *postService) FetchPostByID(id string) (entity.Post, error) {
+func (s *postService) FetchPostByID(id, viewerID string) (entity.Post, error) {
 	ctx := context.Background()
 	post, err := s.postRepo.FetchPostByID(ctx, id)
 	if err != nil {
 		return entity.Post{}, err
 	}
 	if post == nil {
-		return entity.Post{}, errors.New("not found")
+		return entity.Post{}, entity.NotFound("post not found")
 	}
-	return *post, nil
+	return s.decoratePost(ctx, *post, viewerID)
 }
 
-func (s *postService) FetchPostsByUserID(userID string) ([]entity.Post, error) {
+func (s *postService) FetchPostsByUserID(userID, viewerID string, page entity.PageRequest) ([]entity.Post, entity.PageInfo, error) {
 	ctx := context.Background()
-	posts, err := s.postRepo.FetchPostsByUserID(ctx, userID)
+	posts, pageInfo, err := s.postRepo.FetchPostsByUserID(ctx, userID, page)
 	if err != nil {
-		return nil, err
-	}
-	if len(posts) == 0 {
-		return nil, errors.New("not found")
+		return nil, entity.PageInfo{}, err
 	}
-	return posts, nil
+	posts, err = s.decoratePosts(ctx, posts, viewerID)
+	return posts, pageInfo, err
 }
 
 func (s *postService) CreatePost(post entity.Post) (entity.Post, error) {
@@ -51,21 +56,40 @@ func (s *postService) CreatePost(post entity.Post) (entity.Post, error) {
 		return entity.Post{}, err
 	}
 	if createdPost == nil {
-		return entity.Post{}, errors.New("not found")
+		return entity.Post{}, entity.NotFound("post not found")
 	}
-	return *createdPost, nil
+	post, err = s.decoratePost(ctx, *createdPost, createdPost.UserID)
+	if err != nil {
+		return entity.Post{}, err
+	}
+	s.events.Publish(entity.EventPostCreated, post)
+	return post, nil
 }
 
-func (s *postService) UpdatePost(id string, post entity.Post) (entity.Post, error) {
+// EditPost sets the caption of a post, replaces its images with media, in
+// order, or both at once; a nil caption or nil media is left unchanged. The
+// first image becomes the post's image_url.
+func (s *postService) EditPost(id string, caption *string, media []entity.PostMedia) (entity.Post, error) {
 	ctx := context.Background()
-	updatedPost, err := s.postRepo.UpdatePost(ctx, id, post)
+	var imageURL string
+	if media != nil {
+		if len(media) == 0 {
+			return entity.Post{}, entity.Validation("a post needs at least one image")
+		}
+		imageURL = media[0].Media.URL(entity.RenditionFull)
+	}
+	if err := s.postRepo.EditPost(ctx, id, caption, media, imageURL); err != nil {
+		return entity.Post{}, err
+	}
+
+	post, err := s.postRepo.FetchPostByID(ctx, id)
 	if err != nil {
 		return entity.Post{}, err
 	}
-	if updatedPost == nil {
-		return entity.Post{}, errors.New("not found")
+	if post == nil {
+		return entity.Post{}, entity.NotFound("post not found")
 	}
-	return *updatedPost, nil
+	return s.decoratePost(ctx, *post, post.UserID)
 }
 
 func (s *postService) DeletePost(id string) error {
@@ -77,14 +101,116 @@ func (s *postService) DeletePost(id string) error {
 	return nil
 }
 
-func (s *postService) SearchPosts(query string) ([]entity.Post, error) {
+func (s *postService) SearchPosts(query, viewerID string, page entity.PageRequest) ([]entity.Post, entity.PageInfo, error) {
 	ctx := context.Background()
-	posts, err := s.postRepo.SearchPosts(ctx, query)
+	posts, pageInfo, err := s.postRepo.SearchPosts(ctx, query, page)
+	if err != nil {
+		return nil, entity.PageInfo{}, err
+	}
+	posts, err = s.decoratePosts(ctx, posts, viewerID)
+	return posts, pageInfo, err
+}
+
+func (s *postService) FetchPostsByIDs(ids []string, viewerID string) ([]entity.Post, error) {
+	ctx := context.Background()
+	posts, err := s.postRepo.FetchPostsByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	return s.decoratePosts(ctx, posts, viewerID)
+}
+
+// FetchPostsByUserIDs returns the first page of posts for each user, decorating
+// every post across all pages in a single pass.
+func (s *postService) FetchPostsByUserIDs(userIDs []string, viewerID string, page entity.PageRequest) (map[string]entity.Page[entity.Post], error) {
+	ctx := context.Background()
+	pages, err := s.postRepo.FetchPostsByUserIDs(ctx, userIDs, page)
+	if err != nil {
+		return nil, err
+	}
+
+	var posts []entity.Post
+	for _, userID := range userIDs {
+		posts = append(posts, pages[userID].Items...)
+	}
+	posts, err = s.decoratePosts(ctx, posts, viewerID)
 	if err != nil {
 		return nil, err
 	}
+
+	offset := 0
+	for _, userID := range userIDs {
+		userPage, ok := pages[userID]
+		if !ok {
+			continue
+		}
+		userPage.Items = posts[offset : offset+len(userPage.Items)]
+		offset += len(userPage.Items)
+		pages[userID] = userPage
+	}
+	return pages, nil
+}
+
+func (s *postService) decoratePost(ctx context.Context, post entity.Post, viewerID string) (entity.Post, error) {
+	posts, err := s.decoratePosts(ctx, []entity.Post{post}, viewerID)
+	if err != nil {
+		return entity.Post{}, err
+	}
+	return posts[0], nil
+}
+
+// decoratePosts fills in the author summary, the image renditions and the
+// viewer-relative flags for a whole page of posts using one query each.
+func (s *postService) decoratePosts(ctx context.Context, posts []entity.Post, viewerID string) ([]entity.Post, error) {
 	if len(posts) == 0 {
-		return nil, errors.New("not found")
+		return posts, nil
+	}
+
+	postIDs := make([]string, 0, len(posts))
+	authorIDs := make([]string, 0, len(posts))
+	seenAuthors := make(map[string]bool, len(posts))
+	for _, post := range posts {
+		postIDs = append(postIDs, post.ID)
+		if !seenAuthors[post.UserID] {
+			seenAuthors[post.UserID] = true
+			authorIDs = append(authorIDs, post.UserID)
+		}
+	}
+
+	authors, err := s.userRepo.GetUsersByIDs(ctx, authorIDs)
+	if err != nil {
+		return nil, err
+	}
+	authorsByID := make(map[string]*entity.PostAuthor, len(authors))
+	for _, author := range authors {
+		authorsByID[author.ID] = &entity.PostAuthor{
+			ID:       author.ID,
+			Name:     author.Name,
+			ImageURL: s.media.SignURL(author.ImageURL),
+		}
+	}
+
+	media, err := s.media.FetchPostMedia(postIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	states, err := s.postRepo.FetchViewerStates(ctx, viewerID, postIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range posts {
+		posts[i].Author = authorsByID[posts[i].UserID]
+		posts[i].Media = media[posts[i].ID]
+		posts[i].Status = posts[i].MediaStatus()
+		if len(posts[i].Media) > 0 {
+			posts[i].ImageURL = posts[i].Media[0].Media.URL(entity.RenditionFull)
+		} else {
+			posts[i].ImageURL = s.media.SignURL(posts[i].ImageURL)
+		}
+		state := states[posts[i].ID]
+		posts[i].LikedByMe = state.LikedByMe
 	}
 	return posts, nil
-}
\ No newline at end of file
+}
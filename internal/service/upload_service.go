@@ -0,0 +1,307 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	contract "raion-assessment/domain/contract"
+	entity "raion-assessment/domain/entity"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// maxUploadChunks bounds how many pieces an upload may arrive in, so a client
+// sending tiny chunks cannot fill the store with objects.
+const maxUploadChunks = 1000
+
+// expiredUploadBatch is how many expired uploads are removed per query.
+const expiredUploadBatch = 100
+
+// presignedURLExpiry is how long a presigned upload URL accepts the file. The
+// upload itself can be completed until it expires like any other.
+const presignedURLExpiry = 15 * time.Minute
+
+type uploadService struct {
+	uploadRepo contract.IUploadRepository
+	store      contract.IMediaStore
+	expiry     time.Duration
+	quota      int64
+}
+
+// NewUploadService returns an upload service keeping chunks in store. Uploads
+// expire once expiry has passed since they were created or last appended to.
+// New uploads must fit in quota next to the owner's media and other uploads.
+func NewUploadService(uploadRepo contract.IUploadRepository, store contract.IMediaStore, expiry time.Duration, quota int64) contract.IUploadService {
+	return &uploadService{uploadRepo: uploadRepo, store: store, expiry: expiry, quota: quota}
+}
+
+func (s *uploadService) CreateUpload(ownerID string, length int64, metadata string) (entity.Upload, error) {
+	ctx := context.Background()
+	upload, err := s.uploadRepo.CreateUpload(ctx, entity.Upload{OwnerID: ownerID, Length: length, Metadata: metadata}, s.expiry, s.quota)
+	if err != nil {
+		return entity.Upload{}, err
+	}
+	return *upload, nil
+}
+
+func (s *uploadService) FetchUpload(ownerID, id string) (entity.Upload, error) {
+	upload, err := s.uploadRepo.FetchUpload(context.Background(), id, ownerID)
+	if err != nil {
+		return entity.Upload{}, err
+	}
+	if upload == nil {
+		return entity.Upload{}, entity.NotFound("upload not found")
+	}
+	return *upload, nil
+}
+
+// AppendChunk stores the size bytes read from chunk as the bytes at offset,
+// which must be where the upload currently ends. The chunk is streamed to the
+// store before it is recorded and removed again if another request got there
+// first. A chunk that ends early is not stored at all.
+func (s *uploadService) AppendChunk(ownerID, id string, offset, size int64, chunk io.Reader) (entity.Upload, error) {
+	ctx := context.Background()
+	upload, err := s.FetchUpload(ownerID, id)
+	if err != nil {
+		return entity.Upload{}, err
+	}
+	if offset != upload.Offset {
+		return entity.Upload{}, entity.Conflict(fmt.Sprintf("upload is at offset %d", upload.Offset))
+	}
+	if offset+size > upload.Length {
+		return entity.Upload{}, entity.Validation("chunk runs past the end of the upload")
+	}
+	if size == 0 {
+		return upload, nil
+	}
+	if len(upload.ChunkKeys) >= maxUploadChunks {
+		return entity.Upload{}, entity.Validation(fmt.Sprintf("upload may be sent in at most %d chunks", maxUploadChunks))
+	}
+
+	key := fmt.Sprintf("tus/%s/%s/%012d-%s", ownerID, id, offset, uuid.NewString())
+	err = s.store.Put(ctx, key, &exactReader{r: chunk, n: size}, size, "application/octet-stream")
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		return entity.Upload{}, entity.Validation("chunk ended before Content-Length bytes arrived")
+	}
+	if err != nil {
+		return entity.Upload{}, err
+	}
+	updated, err := s.uploadRepo.AppendChunk(ctx, id, ownerID, offset, size, key, s.expiry)
+	if err != nil || updated == nil {
+		s.removeObjects(ctx, []string{key})
+		if err != nil {
+			return entity.Upload{}, err
+		}
+		return entity.Upload{}, entity.Conflict("upload changed while the chunk was being stored")
+	}
+	return *updated, nil
+}
+
+// OpenUpload returns the bytes of a finished upload, read chunk by chunk from
+// the store.
+func (s *uploadService) OpenUpload(ownerID, id string) (io.ReadCloser, error) {
+	upload, err := s.FetchUpload(ownerID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !upload.Complete() {
+		return nil, entity.Validation("upload is not complete")
+	}
+	return &chunkReader{ctx: context.Background(), store: s.store, keys: upload.ChunkKeys}, nil
+}
+
+func (s *uploadService) DeleteUpload(ownerID, id string) error {
+	ctx := context.Background()
+	upload, err := s.uploadRepo.DeleteUpload(ctx, id, ownerID)
+	if err != nil {
+		return err
+	}
+	if upload == nil {
+		return entity.NotFound("upload not found")
+	}
+	s.removeObjects(ctx, upload.ChunkKeys)
+	return nil
+}
+
+// PresignUpload reserves a key for a file of the given type and size and
+// returns a URL the client can send it to directly. The type and size are
+// expected to have been checked against the upload limits already.
+func (s *uploadService) PresignUpload(ownerID, purpose, contentType string, size int64) (entity.DirectUpload, error) {
+	ctx := context.Background()
+	if _, ok := renditionSpecs[purpose]; !ok {
+		return entity.DirectUpload{}, fmt.Errorf("unknown media purpose %q", purpose)
+	}
+
+	id := uuid.NewString()
+	upload, err := s.uploadRepo.CreateDirectUpload(ctx, entity.DirectUpload{
+		ID:          id,
+		OwnerID:     ownerID,
+		Purpose:     purpose,
+		Key:         "incoming/" + ownerID + "/" + id,
+		ContentType: contentType,
+		Size:        size,
+	}, s.expiry, s.quota)
+	if err != nil {
+		return entity.DirectUpload{}, err
+	}
+
+	upload.URL, err = s.store.PresignPut(upload.Key, contentType, size, presignedURLExpiry)
+	if err != nil {
+		return entity.DirectUpload{}, err
+	}
+	upload.Method = "PUT"
+	upload.URLExpiresAt = time.Now().Add(presignedURLExpiry)
+	upload.Headers = map[string]string{
+		"Content-Type":   contentType,
+		"Content-Length": strconv.FormatInt(size, 10),
+	}
+	return *upload, nil
+}
+
+// OpenDirectUpload returns a direct upload and the object the client sent for
+// it. It is a validation error if nothing has been sent yet.
+func (s *uploadService) OpenDirectUpload(ownerID, id string) (entity.DirectUpload, io.ReadCloser, error) {
+	ctx := context.Background()
+	upload, err := s.uploadRepo.FetchDirectUpload(ctx, id, ownerID)
+	if err != nil {
+		return entity.DirectUpload{}, nil, err
+	}
+	if upload == nil {
+		return entity.DirectUpload{}, nil, entity.NotFound("upload not found")
+	}
+
+	object, err := s.store.Get(ctx, upload.Key)
+	if errors.Is(err, entity.ErrNotFound) {
+		return entity.DirectUpload{}, nil, entity.Validation("nothing has been uploaded to the upload URL yet")
+	}
+	if err != nil {
+		return entity.DirectUpload{}, nil, err
+	}
+	return *upload, object, nil
+}
+
+// DeleteDirectUpload forgets a direct upload and deletes the object sent for
+// it, if any.
+func (s *uploadService) DeleteDirectUpload(ownerID, id string) error {
+	ctx := context.Background()
+	upload, err := s.uploadRepo.DeleteDirectUpload(ctx, id, ownerID)
+	if err != nil {
+		return err
+	}
+	if upload == nil {
+		return entity.NotFound("upload not found")
+	}
+	s.removeObjects(ctx, []string{upload.Key})
+	return nil
+}
+
+// ExpireUploads deletes every expired resumable upload with its chunks and
+// every expired direct upload with its object, and returns how many there
+// were.
+func (s *uploadService) ExpireUploads() (int, error) {
+	ctx := context.Background()
+	var expired int
+	for {
+		uploads, err := s.uploadRepo.DeleteExpiredUploads(ctx, expiredUploadBatch)
+		if err != nil {
+			return expired, err
+		}
+		for _, upload := range uploads {
+			s.removeObjects(ctx, upload.ChunkKeys)
+		}
+		expired += len(uploads)
+		if len(uploads) < expiredUploadBatch {
+			break
+		}
+	}
+	for {
+		uploads, err := s.uploadRepo.DeleteExpiredDirectUploads(ctx, expiredUploadBatch)
+		if err != nil {
+			return expired, err
+		}
+		for _, upload := range uploads {
+			s.removeObjects(ctx, []string{upload.Key})
+		}
+		expired += len(uploads)
+		if len(uploads) < expiredUploadBatch {
+			return expired, nil
+		}
+	}
+}
+
+func (s *uploadService) removeObjects(ctx context.Context, keys []string) {
+	for _, key := range keys {
+		if err := s.store.Delete(ctx, key); err != nil {
+			log.Printf("Failed to remove upload object %s: %v", key, err)
+		}
+	}
+}
+
+// exactReader reads exactly n bytes from r, failing with io.ErrUnexpectedEOF
+// if r ends sooner, so a chunk cut short by a dropped connection is never
+// stored as though it were whole.
+type exactReader struct {
+	r io.Reader
+	n int64
+}
+
+func (e *exactReader) Read(p []byte) (int, error) {
+	if e.n <= 0 {
+		return 0, io.EOF
+	}
+	if int64(len(p)) > e.n {
+		p = p[:e.n]
+	}
+	n, err := e.r.Read(p)
+	e.n -= int64(n)
+	if err == io.EOF && e.n > 0 {
+		err = io.ErrUnexpectedEOF
+	}
+	return n, err
+}
+
+// chunkReader reads the chunks of an upload one after another, opening each
+// only once the previous one is used up.
+type chunkReader struct {
+	ctx     context.Context
+	store   contract.IMediaStore
+	keys    []string
+	current io.ReadCloser
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for {
+		if r.current == nil {
+			if len(r.keys) == 0 {
+				return 0, io.EOF
+			}
+			current, err := r.store.Get(r.ctx, r.keys[0])
+			if err != nil {
+				return 0, err
+			}
+			r.current, r.keys = current, r.keys[1:]
+		}
+
+		n, err := r.current.Read(p)
+		if err == io.EOF {
+			r.current.Close()
+			r.current = nil
+			if n == 0 {
+				continue
+			}
+			err = nil
+		}
+		return n, err
+	}
+}
+
+func (r *chunkReader) Close() error {
+	if r.current == nil {
+		return nil
+	}
+	return r.current.Close()
+}
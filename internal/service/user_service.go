@@ -8,20 +8,39 @@ import (
 
 type userService struct {
 	userRepo contract.IUserRepository
+	media    contract.IMediaService
 }
 
-func NewUserService(repo contract.IUserRepository) contract.IUserService {
-	return &userService{userRepo: repo}
+func NewUserService(repo contract.IUserRepository, media contract.IMediaService) contract.IUserService {
+	return &userService{userRepo: repo, media: media}
 }
 
-func (s *userService) FetchAllUsers() ([]domain.User, error) {
+func (s *userService) FetchAllUsers(page domain.PageRequest) ([]domain.User, domain.PageInfo, error) {
 	ctx := context.Background()
-	return s.userRepo.GetAllUsers(ctx)
+	users, pageInfo, err := s.userRepo.GetAllUsers(ctx, page)
+	if err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+	users, err = s.decorateUsers(users)
+	return users, pageInfo, err
 }
 
 func (s *userService) FetchUserByID(id string) (domain.User, error) {
 	ctx := context.Background()
-	return s.userRepo.GetUserByID(ctx, id)
+	user, err := s.userRepo.GetUserByID(ctx, id)
+	if err != nil {
+		return domain.User{}, err
+	}
+	return s.decorateUser(user)
+}
+
+func (s *userService) FetchUsersByIDs(ids []string) ([]domain.User, error) {
+	ctx := context.Background()
+	users, err := s.userRepo.GetUsersByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	return s.decorateUsers(users)
 }
 
 func (s *userService) CreateUser(user domain.User) (domain.User, error) {
@@ -31,15 +50,61 @@ func (s *userService) CreateUser(user domain.User) (domain.User, error) {
 
 func (s *userService) UpdateUser(id string, user domain.User) (domain.User, error) {
 	ctx := context.Background()
-	return s.userRepo.UpdateUser(ctx, id, user)
+	updated, err := s.userRepo.UpdateUser(ctx, id, user)
+	if err != nil {
+		return domain.User{}, err
+	}
+	return s.decorateUser(updated)
 }
 
+// DeleteUser deletes the user's media and its files first; the rows would
+// otherwise go with the user and leave the files orphaned.
 func (s *userService) DeleteUser(id string) error {
 	ctx := context.Background()
+	if err := s.media.DeleteOwnerMedia(id); err != nil {
+		return err
+	}
 	return s.userRepo.DeleteUser(ctx, id)
 }
 
-func (s *userService) SearchUsers(query string) ([]domain.User, error) {
+func (s *userService) SearchUsers(query string, page domain.PageRequest) ([]domain.User, domain.PageInfo, error) {
     ctx := context.Background()
-    return s.userRepo.SearchUsers(ctx, query)
+    users, pageInfo, err := s.userRepo.SearchUsers(ctx, query, page)
+    if err != nil {
+        return nil, domain.PageInfo{}, err
+    }
+    users, err = s.decorateUsers(users)
+    return users, pageInfo, err
+}
+
+func (s *userService) decorateUser(user domain.User) (domain.User, error) {
+	users, err := s.decorateUsers([]domain.User{user})
+	if err != nil {
+		return domain.User{}, err
+	}
+	return users[0], nil
+}
+
+// decorateUsers attaches avatar renditions to a page of users in one query and
+// signs their image URLs.
+func (s *userService) decorateUsers(users []domain.User) ([]domain.User, error) {
+	if len(users) == 0 {
+		return users, nil
+	}
+
+	userIDs := make([]string, len(users))
+	for i, user := range users {
+		userIDs[i] = user.ID
+	}
+	avatars, err := s.media.FetchUserAvatars(userIDs)
+	if err != nil {
+		return nil, err
+	}
+	for i := range users {
+		users[i].ImageURL = s.media.SignURL(users[i].ImageURL)
+		if avatar, ok := avatars[users[i].ID]; ok {
+			users[i].Avatar = &avatar
+		}
+	}
+	return users, nil
 }
@@ -0,0 +1,407 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	entity "raion-assessment/domain/entity"
+	"raion-assessment/pkg/request"
+	"time"
+)
+
+// videoLimits is the allow-list of video containers accepted for upload, as
+// detected by sniffVideo, with the largest size accepted for each.
+var videoLimits = map[string]int64{
+	"video/mp4":       32 << 20,
+	"video/quicktime": 32 << 20,
+	"video/webm":      32 << 20,
+}
+
+// mp4Brands are the ISO base media file brands of playable video. HEIF and
+// AVIF images share the container and are deliberately absent.
+var mp4Brands = map[string]string{
+	"isom": "video/mp4",
+	"iso2": "video/mp4",
+	"iso4": "video/mp4",
+	"iso5": "video/mp4",
+	"iso6": "video/mp4",
+	"mp41": "video/mp4",
+	"mp42": "video/mp4",
+	"avc1": "video/mp4",
+	"M4V ": "video/mp4",
+	"qt  ": "video/quicktime",
+}
+
+var (
+	errInvalidVideo = errors.New("invalid video container")
+	errInvalidGIF   = errors.New("invalid gif image")
+
+	errMissingElement = errors.New("element not found")
+)
+
+// inspectPostMedia reads a post upload, which may be an image, an animated
+// GIF or a video, and checks it against the limits for its kind. Animated
+// GIFs are passed on as videos so they are transcoded like one.
+func inspectPostMedia(ctx context.Context, src io.Reader, field string) (checkedUpload, error) {
+	locale := request.LocaleFromContext(ctx)
+
+	data, err := io.ReadAll(io.LimitReader(src, entity.MaxVideoUploadSize+1))
+	if err != nil {
+		return checkedUpload{}, err
+	}
+
+	if contentType := sniffVideo(data); contentType != "" {
+		return checkVideo(locale, data, contentType, field)
+	}
+	if _, ok := imageLimits[http.DetectContentType(data)]; !ok {
+		return checkedUpload{}, request.Invalid(locale, field, "media_type")
+	}
+
+	upload, err := checkImage(locale, data, field)
+	if err != nil || upload.contentType != "image/gif" {
+		return upload, err
+	}
+	frames, duration, err := gifAnimation(data)
+	if err != nil {
+		return checkedUpload{}, request.Invalid(locale, field, "image")
+	}
+	if frames > 1 {
+		if duration > entity.MaxVideoDuration {
+			return checkedUpload{}, request.InvalidParam(locale, field, "duration", fmt.Sprint(entity.MaxVideoDuration.Seconds()))
+		}
+		upload.kind, upload.duration = entity.MediaKindVideo, duration
+	}
+	return upload, nil
+}
+
+func checkVideo(locale string, data []byte, contentType, field string) (checkedUpload, error) {
+	if limit := videoLimits[contentType]; int64(len(data)) > limit {
+		return checkedUpload{}, request.InvalidParam(locale, field, "file_size", fmt.Sprintf("%d MB", limit>>20))
+	}
+	duration, err := videoDuration(data, contentType)
+	if err != nil {
+		return checkedUpload{}, request.Invalid(locale, field, "video")
+	}
+	if duration > entity.MaxVideoDuration {
+		return checkedUpload{}, request.InvalidParam(locale, field, "duration", fmt.Sprint(entity.MaxVideoDuration.Seconds()))
+	}
+	return checkedUpload{data: data, contentType: contentType, kind: entity.MediaKindVideo, duration: duration}, nil
+}
+
+// sniffVideo names the video container data is in from its leading bytes, or
+// returns "" when it is not a supported video.
+func sniffVideo(data []byte) string {
+	if len(data) >= 12 && string(data[4:8]) == "ftyp" {
+		return mp4Brands[string(data[8:12])]
+	}
+	if bytes.HasPrefix(data, []byte{0x1a, 0x45, 0xdf, 0xa3}) {
+		return "video/webm"
+	}
+	return ""
+}
+
+// videoDuration validates the container structure of a sniffed video and
+// returns the duration it declares, which is zero when it declares none.
+func videoDuration(data []byte, contentType string) (time.Duration, error) {
+	if contentType == "video/webm" {
+		return webmDuration(data)
+	}
+	return mp4Duration(data)
+}
+
+// mp4Duration walks the top-level boxes of an MP4 or QuickTime file, which
+// must fit the file exactly and include the movie header, and reads the
+// duration from it.
+func mp4Duration(data []byte) (time.Duration, error) {
+	var duration time.Duration
+	var hasMovie, hasData bool
+	for offset := 0; offset < len(data); {
+		boxType, body, next, err := nextBox(data, offset)
+		if err != nil {
+			return 0, err
+		}
+		switch boxType {
+		case "moov":
+			hasMovie = true
+			if duration, err = movieDuration(body); err != nil {
+				return 0, err
+			}
+		case "mdat":
+			hasData = true
+		}
+		offset = next
+	}
+	if !hasMovie || !hasData {
+		return 0, errInvalidVideo
+	}
+	return duration, nil
+}
+
+// movieDuration reads the duration from the mvhd box inside a moov box.
+func movieDuration(moov []byte) (time.Duration, error) {
+	for offset := 0; offset < len(moov); {
+		boxType, body, next, err := nextBox(moov, offset)
+		if err != nil {
+			return 0, err
+		}
+		if boxType == "mvhd" {
+			var timescale, units uint64
+			switch {
+			case len(body) >= 20 && body[0] == 0:
+				timescale = uint64(binary.BigEndian.Uint32(body[12:16]))
+				units = uint64(binary.BigEndian.Uint32(body[16:20]))
+			case len(body) >= 32 && body[0] == 1:
+				timescale = uint64(binary.BigEndian.Uint32(body[20:24]))
+				units = binary.BigEndian.Uint64(body[24:32])
+			default:
+				return 0, errInvalidVideo
+			}
+			if timescale == 0 {
+				return 0, errInvalidVideo
+			}
+			return secondsDuration(float64(units) / float64(timescale)), nil
+		}
+		offset = next
+	}
+	return 0, errInvalidVideo
+}
+
+// nextBox parses the box header at offset and returns its type, its body and
+// the offset of the box after it.
+func nextBox(data []byte, offset int) (string, []byte, int, error) {
+	if len(data)-offset < 8 {
+		return "", nil, 0, errInvalidVideo
+	}
+	size := uint64(binary.BigEndian.Uint32(data[offset : offset+4]))
+	boxType := string(data[offset+4 : offset+8])
+	header := uint64(8)
+	switch size {
+	case 0:
+		size = uint64(len(data) - offset)
+	case 1:
+		if len(data)-offset < 16 {
+			return "", nil, 0, errInvalidVideo
+		}
+		size = binary.BigEndian.Uint64(data[offset+8 : offset+16])
+		header = 16
+	}
+	if size < header || size > uint64(len(data)-offset) {
+		return "", nil, 0, errInvalidVideo
+	}
+	end := offset + int(size)
+	return boxType, data[offset+int(header) : end], end, nil
+}
+
+// EBML element IDs read from WebM files.
+const (
+	ebmlHeaderID    = 0x1a45dfa3
+	ebmlDocTypeID   = 0x4282
+	webmSegmentID   = 0x18538067
+	webmInfoID      = 0x1549a966
+	webmTimescaleID = 0x2ad7b1
+	webmDurationID  = 0x4489
+)
+
+// webmDuration checks the EBML header names a WebM document and reads the
+// duration from the segment info. Recordings made in the browser with
+// MediaRecorder leave the Info or Duration element out, so a file without
+// one is accepted with a zero duration rather than rejected. The upload-time
+// duration check then cannot apply to it: the only bound on such a file is
+// the transcoder cutting its output at entity.MaxVideoDuration, and the
+// source is still held to its container's size limit.
+func webmDuration(data []byte) (time.Duration, error) {
+	id, header, next, err := nextElement(data, 0)
+	if err != nil || id != ebmlHeaderID {
+		return 0, errInvalidVideo
+	}
+	docType, err := findElement(header, ebmlDocTypeID)
+	if err != nil || string(docType) != "webm" {
+		return 0, errInvalidVideo
+	}
+
+	id, segment, _, err := nextElement(data, next)
+	if err != nil || id != webmSegmentID {
+		return 0, errInvalidVideo
+	}
+	info, err := findElement(segment, webmInfoID)
+	if errors.Is(err, errMissingElement) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	timescale := uint64(1_000_000)
+	raw, err := findElement(info, webmTimescaleID)
+	switch {
+	case err == nil:
+		if len(raw) == 0 || len(raw) > 8 {
+			return 0, errInvalidVideo
+		}
+		timescale = 0
+		for _, b := range raw {
+			timescale = timescale<<8 | uint64(b)
+		}
+		if timescale == 0 {
+			return 0, errInvalidVideo
+		}
+	case !errors.Is(err, errMissingElement):
+		return 0, err
+	}
+	raw, err = findElement(info, webmDurationID)
+	if errors.Is(err, errMissingElement) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	var ticks float64
+	switch len(raw) {
+	case 4:
+		ticks = float64(math.Float32frombits(binary.BigEndian.Uint32(raw)))
+	case 8:
+		ticks = math.Float64frombits(binary.BigEndian.Uint64(raw))
+	default:
+		return 0, errInvalidVideo
+	}
+	return secondsDuration(ticks * float64(timescale) / 1e9), nil
+}
+
+// findElement returns the body of the first child element with the given ID,
+// or errMissingElement when there is none. Children that fail to parse before
+// it is found make the whole file invalid.
+func findElement(data []byte, want uint64) ([]byte, error) {
+	for offset := 0; offset < len(data); {
+		id, body, next, err := nextElement(data, offset)
+		if err != nil {
+			return nil, err
+		}
+		if id == want {
+			return body, nil
+		}
+		offset = next
+	}
+	return nil, errMissingElement
+}
+
+// nextElement parses the EBML element at offset. An element of unknown size,
+// as streamed segments are written, runs to the end of data.
+func nextElement(data []byte, offset int) (uint64, []byte, int, error) {
+	id, idLen, ok := readVint(data[offset:], true)
+	if !ok {
+		return 0, nil, 0, errInvalidVideo
+	}
+	offset += idLen
+	size, sizeLen, ok := readVint(data[offset:], false)
+	if !ok {
+		return 0, nil, 0, errInvalidVideo
+	}
+	offset += sizeLen
+	remaining := uint64(len(data) - offset)
+	if unknown := size == 1<<(7*uint(sizeLen))-1; unknown {
+		size = remaining
+	} else if size > remaining {
+		return 0, nil, 0, errInvalidVideo
+	}
+	end := offset + int(size)
+	return id, data[offset:end], end, nil
+}
+
+// readVint reads an EBML variable-length integer. IDs keep their length
+// marker bit; sizes have it cleared.
+func readVint(data []byte, keepMarker bool) (uint64, int, bool) {
+	if len(data) == 0 || data[0] == 0 {
+		return 0, 0, false
+	}
+	length := 1
+	for mask := byte(0x80); data[0]&mask == 0; mask >>= 1 {
+		length++
+	}
+	if length > 8 || len(data) < length {
+		return 0, 0, false
+	}
+	value := uint64(data[0])
+	if !keepMarker {
+		value &= uint64(0xff >> length)
+	}
+	for _, b := range data[1:length] {
+		value = value<<8 | uint64(b)
+	}
+	return value, length, true
+}
+
+// gifAnimation walks the blocks of a GIF and returns how many frames it has
+// and how long one loop plays, without decoding any pixels.
+func gifAnimation(data []byte) (int, time.Duration, error) {
+	if len(data) < 13 {
+		return 0, 0, errInvalidGIF
+	}
+	offset := 13
+	if data[10]&0x80 != 0 {
+		offset += 3 << (data[10]&0x07 + 1)
+	}
+	if offset > len(data) {
+		return 0, 0, errInvalidGIF
+	}
+
+	var frames int
+	var delay time.Duration
+	for offset < len(data) {
+		switch data[offset] {
+		case 0x3b:
+			return frames, delay, nil
+		case 0x21:
+			if offset+2 > len(data) {
+				return 0, 0, errInvalidGIF
+			}
+			label := data[offset+1]
+			offset += 2
+			if label == 0xf9 && offset+5 <= len(data) && data[offset] == 4 {
+				delay += time.Duration(binary.LittleEndian.Uint16(data[offset+2:offset+4])) * 10 * time.Millisecond
+			}
+		case 0x2c:
+			if offset+10 > len(data) {
+				return 0, 0, errInvalidGIF
+			}
+			frames++
+			flags := data[offset+9]
+			offset += 10
+			if flags&0x80 != 0 {
+				offset += 3 << (flags&0x07 + 1)
+			}
+			offset++ // LZW minimum code size
+		default:
+			return 0, 0, errInvalidGIF
+		}
+		// Both extensions and image data end in a run of sub-blocks.
+		for {
+			if offset >= len(data) {
+				return 0, 0, errInvalidGIF
+			}
+			size := int(data[offset])
+			offset += 1 + size
+			if size == 0 {
+				break
+			}
+		}
+	}
+	// Some encoders leave out the trailer; what was read is still usable.
+	return frames, delay, nil
+}
+
+// secondsDuration converts a declared length in seconds, saturating instead
+// of overflowing so absurd values still fail the duration limit.
+func secondsDuration(seconds float64) time.Duration {
+	switch {
+	case seconds <= 0 || math.IsNaN(seconds):
+		return 0
+	case seconds >= float64(math.MaxInt64)/float64(time.Second):
+		return math.MaxInt64
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
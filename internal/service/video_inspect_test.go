@@ -0,0 +1,228 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"math"
+	entity "raion-assessment/domain/entity"
+	"testing"
+	"time"
+)
+
+// box builds an MP4 box whose size field covers its header and body.
+func box(boxType string, body ...[]byte) []byte {
+	joined := bytes.Join(body, nil)
+	return append(sizedBox(uint32(8+len(joined)), boxType), joined...)
+}
+
+func sizedBox(size uint32, boxType string) []byte {
+	return append(binary.BigEndian.AppendUint32(nil, size), boxType...)
+}
+
+// mvhd builds a version 0 movie header declaring units/timescale seconds.
+func mvhd(timescale, units uint32) []byte {
+	body := make([]byte, 20)
+	binary.BigEndian.PutUint32(body[12:16], timescale)
+	binary.BigEndian.PutUint32(body[16:20], units)
+	return box("mvhd", body)
+}
+
+// mvhd64 builds a version 1 movie header, which has 64-bit times.
+func mvhd64(timescale uint32, units uint64) []byte {
+	body := make([]byte, 32)
+	body[0] = 1
+	binary.BigEndian.PutUint32(body[20:24], timescale)
+	binary.BigEndian.PutUint64(body[24:32], units)
+	return box("mvhd", body)
+}
+
+func mp4File(boxes ...[]byte) []byte {
+	return bytes.Join(append([][]byte{box("ftyp", []byte("isom\x00\x00\x02\x00"))}, boxes...), nil)
+}
+
+func TestMP4Duration(t *testing.T) {
+	moov := box("moov", mvhd(1000, 2500))
+	mdat := box("mdat", []byte("frames"))
+
+	tests := []struct {
+		name    string
+		data    []byte
+		want    time.Duration
+		wantErr bool
+	}{
+		{"movie header and data", mp4File(moov, mdat), 2500 * time.Millisecond, false},
+		{"64-bit movie header", mp4File(box("moov", mvhd64(600, 600*90)), mdat), 90 * time.Second, false},
+		{"duration saturates", mp4File(box("moov", mvhd64(1, math.MaxUint64)), mdat), math.MaxInt64, false},
+		{"size 0 runs to the end", mp4File(moov, sizedBox(0, "mdat"), []byte("frames")), 2500 * time.Millisecond, false},
+		{"size 1 has a 64-bit size", mp4File(moov, sizedBox(1, "mdat"), binary.BigEndian.AppendUint64(nil, 16+6), []byte("frames")), 2500 * time.Millisecond, false},
+		{"size 1 with a truncated 64-bit size", mp4File(moov, sizedBox(1, "mdat"), []byte{0, 0, 0}), 0, true},
+		{"size 1 smaller than its header", mp4File(moov, sizedBox(1, "mdat"), binary.BigEndian.AppendUint64(nil, 8)), 0, true},
+		{"size smaller than the header", mp4File(moov, sizedBox(4, "mdat")), 0, true},
+		{"size larger than the data", mp4File(moov, sizedBox(1<<20, "mdat"), []byte("frames")), 0, true},
+		{"truncated box header", append(mp4File(moov, mdat), 0, 0, 0), 0, true},
+		{"no movie header box", mp4File(box("moov", box("trak")), mdat), 0, true},
+		{"no moov", mp4File(mdat), 0, true},
+		{"no mdat", mp4File(moov), 0, true},
+		{"zero timescale", mp4File(box("moov", mvhd(0, 2500)), mdat), 0, true},
+		{"truncated movie header", mp4File(box("moov", box("mvhd", make([]byte, 12))), mdat), 0, true},
+		{"box inside moov larger than moov", mp4File(box("moov", sizedBox(64, "mvhd")), mdat), 0, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := mp4Duration(test.data)
+			if (err != nil) != test.wantErr || got != test.want {
+				t.Errorf("mp4Duration() = %v, %v, want %v, error %v", got, err, test.want, test.wantErr)
+			}
+		})
+	}
+}
+
+// element builds an EBML element with a one-byte size, or an eight-byte one
+// for bodies that do not fit.
+func element(id uint32, body ...[]byte) []byte {
+	joined := bytes.Join(body, nil)
+	out := binary.BigEndian.AppendUint32(nil, id)
+	for len(out) > 1 && out[0] == 0 {
+		out = out[1:]
+	}
+	if len(joined) < 0x7f {
+		out = append(out, 0x80|byte(len(joined)))
+	} else {
+		out = append(out, 0x01)
+		out = append(out, binary.BigEndian.AppendUint64(nil, uint64(len(joined)))[1:]...)
+	}
+	return append(out, joined...)
+}
+
+func ebmlHeader(docType string) []byte {
+	return element(ebmlHeaderID, element(ebmlDocTypeID, []byte(docType)))
+}
+
+func float64Bytes(f float64) []byte {
+	return binary.BigEndian.AppendUint64(nil, math.Float64bits(f))
+}
+
+func TestWebMDuration(t *testing.T) {
+	info := element(webmInfoID, element(webmDurationID, float64Bytes(2500)))
+	cluster := element(0x1f43b675, []byte("frames"))
+	unknownSize := func(body ...[]byte) []byte {
+		return append([]byte{0x18, 0x53, 0x80, 0x67, 0x01, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, bytes.Join(body, nil)...)
+	}
+
+	tests := []struct {
+		name    string
+		data    []byte
+		want    time.Duration
+		wantErr bool
+	}{
+		{"duration in milliseconds", append(ebmlHeader("webm"), element(webmSegmentID, info, cluster)...), 2500 * time.Millisecond, false},
+		{"32-bit duration", append(ebmlHeader("webm"), element(webmSegmentID, element(webmInfoID, element(webmDurationID, binary.BigEndian.AppendUint32(nil, math.Float32bits(1500)))))...), 1500 * time.Millisecond, false},
+		{"custom timescale", append(ebmlHeader("webm"), element(webmSegmentID, element(webmInfoID, element(webmTimescaleID, []byte{0x03, 0xe8}), element(webmDurationID, float64Bytes(3000000))))...), 3 * time.Second, false},
+		{"unknown-size segment", append(ebmlHeader("webm"), unknownSize(info, cluster)...), 2500 * time.Millisecond, false},
+		{"one-byte unknown size", append(ebmlHeader("webm"), append([]byte{0x18, 0x53, 0x80, 0x67, 0xff}, info...)...), 2500 * time.Millisecond, false},
+		{"no info declares no duration", append(ebmlHeader("webm"), element(webmSegmentID, cluster)...), 0, false},
+		{"no duration element", append(ebmlHeader("webm"), element(webmSegmentID, element(webmInfoID, element(webmTimescaleID, []byte{1})))...), 0, false},
+		{"matroska is not webm", append(ebmlHeader("matroska"), element(webmSegmentID, info)...), 0, true},
+		{"header without doc type", append(element(ebmlHeaderID, element(0x4286, []byte{1})), element(webmSegmentID, info)...), 0, true},
+		{"first element is not the header", element(webmSegmentID, info), 0, true},
+		{"no segment", ebmlHeader("webm"), 0, true},
+		{"segment larger than the data", append(ebmlHeader("webm"), element(webmSegmentID, info)[:8]...), 0, true},
+		{"element larger than its parent", append(ebmlHeader("webm"), element(webmSegmentID, append([]byte{0x15, 0x49, 0xa9, 0x66, 0x90}, 0))...), 0, true},
+		{"zero timescale", append(ebmlHeader("webm"), element(webmSegmentID, element(webmInfoID, element(webmTimescaleID, []byte{0}), element(webmDurationID, float64Bytes(1e12))))...), 0, true},
+		{"duration of an odd length", append(ebmlHeader("webm"), element(webmSegmentID, element(webmInfoID, element(webmDurationID, []byte{1, 2})))...), 0, true},
+		{"truncated id", []byte{0x1a, 0x45}, 0, true},
+		{"zero vint marker", []byte{0x1a, 0x45, 0xdf, 0xa3, 0x00}, 0, true},
+		{"empty", nil, 0, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := webmDuration(test.data)
+			if (err != nil) != test.wantErr || got != test.want {
+				t.Errorf("webmDuration() = %v, %v, want %v, error %v", got, err, test.want, test.wantErr)
+			}
+		})
+	}
+}
+
+// gifFile builds a GIF with a two-colour global table and one 1x1 frame per
+// delay, each preceded by a graphic control extension giving that delay.
+func gifFile(delays ...uint16) []byte {
+	data := []byte("GIF89a\x01\x00\x01\x00\x80\x00\x00")
+	data = append(data, 0, 0, 0, 0xff, 0xff, 0xff)
+	for _, delay := range delays {
+		data = append(data, 0x21, 0xf9, 4, 0)
+		data = binary.LittleEndian.AppendUint16(data, delay)
+		data = append(data, 0, 0)
+		data = append(data, 0x2c, 0, 0, 0, 0, 1, 0, 1, 0, 0)
+		data = append(data, 2, 2, 0x44, 0x01, 0)
+	}
+	return data
+}
+
+func TestGIFAnimation(t *testing.T) {
+	animated := append(gifFile(50, 50), 0x3b)
+	tests := []struct {
+		name       string
+		data       []byte
+		wantFrames int
+		wantDelay  time.Duration
+		wantErr    bool
+	}{
+		{"still image", append(gifFile(0), 0x3b), 1, 0, false},
+		{"two frames", animated, 2, time.Second, false},
+		{"no trailer", gifFile(50, 50, 50), 3, 1500 * time.Millisecond, false},
+		{"data after the trailer is ignored", append(animated, "junk"...), 2, time.Second, false},
+		{"comment extension", append(append(gifFile(50), 0x21, 0xfe, 2, 'h', 'i', 0), 0x3b), 1, 500 * time.Millisecond, false},
+		{"truncated sub-block", animated[:len(animated)-4], 0, 0, true},
+		{"sub-block size past the end", append(gifFile(50), 0x21, 0xfe, 200, 'h'), 0, 0, true},
+		{"truncated image descriptor", append(gifFile(50), 0x2c, 0, 0), 0, 0, true},
+		{"truncated extension", append(gifFile(50), 0x21), 0, 0, true},
+		{"unknown block", append(gifFile(50), 0x99), 0, 0, true},
+		{"global table past the end", []byte("GIF89a\x01\x00\x01\x00\x87\x00\x00"), 0, 0, true},
+		{"shorter than the header", []byte("GIF89a"), 0, 0, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			frames, delay, err := gifAnimation(test.data)
+			if (err != nil) != test.wantErr || frames != test.wantFrames || delay != test.wantDelay {
+				t.Errorf("gifAnimation() = %d, %v, %v, want %d, %v, error %v", frames, delay, err, test.wantFrames, test.wantDelay, test.wantErr)
+			}
+		})
+	}
+}
+
+// FuzzInspectPostMedia feeds arbitrary bytes to post upload inspection and
+// to each container parser directly, since most inputs are not sniffed as
+// the container a parser handles. Slices are bounds-checked, so a parser
+// reading past the end of its input shows up here as a panic.
+func FuzzInspectPostMedia(f *testing.F) {
+	f.Add(mp4File(box("moov", mvhd(1000, 2500)), box("mdat", []byte("frames"))))
+	f.Add(mp4File(box("moov", mvhd64(1, 30)), sizedBox(1, "mdat"), binary.BigEndian.AppendUint64(nil, 16)))
+	f.Add(append(ebmlHeader("webm"), element(webmSegmentID, element(webmInfoID, element(webmDurationID, float64Bytes(2500))))...))
+	f.Add(append(ebmlHeader("webm"), 0x18, 0x53, 0x80, 0x67, 0xff))
+	f.Add(append(gifFile(50, 50), 0x3b))
+	f.Add(gifFile(0))
+	f.Add([]byte("RIFF\x00\x00\x00\x00WEBPVP8X\x0a\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00"))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		mp4Duration(data)
+		webmDuration(data)
+		gifAnimation(data)
+		webpDimensions(data)
+
+		upload, err := inspectPostMedia(context.Background(), bytes.NewReader(data), "media")
+		if err != nil {
+			return
+		}
+		if !bytes.Equal(upload.data, data) {
+			t.Fatalf("inspection passed on %d bytes of a %d-byte upload", len(upload.data), len(data))
+		}
+		if upload.duration < 0 || upload.duration > entity.MaxVideoDuration {
+			t.Fatalf("accepted a duration of %v", upload.duration)
+		}
+		if upload.kind != entity.MediaKindImage && upload.kind != entity.MediaKindVideo {
+			t.Fatalf("accepted an upload of kind %q", upload.kind)
+		}
+	})
+}
@@ -0,0 +1,197 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	contract "raion-assessment/domain/contract"
+	domain "raion-assessment/domain/entity"
+)
+
+// LocalStore keeps media on the local disk under root. The storage routes
+// serve a key at baseURL + "/" + key, but only through a signed URL that has
+// not expired, checked by OpenSigned. Presigned uploads are PUT to the same
+// address and accepted by ReceivePresignedPut. signingKey signs both kinds of
+// URL.
+type LocalStore struct {
+	root       string
+	baseURL    string
+	signingKey []byte
+	urlExpiry  time.Duration
+}
+
+func NewLocalStore(root, baseURL string, signingKey []byte, urlExpiry time.Duration) contract.IMediaStore {
+	return &LocalStore{root: root, baseURL: baseURL, signingKey: signingKey, urlExpiry: urlExpiry}
+}
+
+// Put writes to a temporary file first and renames it into place, so a failed
+// or concurrent upload never leaves a truncated file behind the key.
+func (s *LocalStore) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	target, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), target)
+}
+
+func (s *LocalStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	target, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(target)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, domain.NotFound("media not found")
+	}
+	return file, err
+}
+
+// Delete removes the file behind key. Deleting a missing key is not an error.
+func (s *LocalStore) Delete(ctx context.Context, key string) error {
+	target, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(target); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (s *LocalStore) URL(key string) string {
+	return joinURL(s.baseURL, key)
+}
+
+// SignedURL returns the key's URL with an expiry and an HMAC over the key and
+// expiry as query parameters.
+func (s *LocalStore) SignedURL(key string) string {
+	expires := signedURLStart(s.urlExpiry, time.Now()).Add(s.urlExpiry).Unix()
+	query := url.Values{
+		"expires":   {strconv.FormatInt(expires, 10)},
+		"signature": {s.getSignature(key, expires)},
+	}
+	return s.URL(key) + "?" + query.Encode()
+}
+
+func (s *LocalStore) KeyOf(rawURL string) (string, bool) {
+	return keyUnder(rawURL, s.baseURL)
+}
+
+// OpenSigned opens the file behind key if expires and signature come from a
+// URL made by SignedURL that has not expired.
+func (s *LocalStore) OpenSigned(ctx context.Context, key, expires, signature string) (io.ReadSeekCloser, fs.FileInfo, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || time.Now().Unix() > expiresAt {
+		return nil, nil, domain.Forbidden("media URL is invalid or has expired")
+	}
+	if !hmac.Equal([]byte(signature), []byte(s.getSignature(key, expiresAt))) {
+		return nil, nil, domain.Forbidden("media URL is invalid or has expired")
+	}
+
+	file, err := os.Open(filepath.Join(s.root, filepath.FromSlash(key)))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, domain.NotFound("media not found")
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+	if info.IsDir() {
+		file.Close()
+		return nil, nil, domain.NotFound("media not found")
+	}
+	return file, info, nil
+}
+
+// PresignPut returns the key's URL with its expiry and an HMAC over the key,
+// content type, size and expiry as query parameters.
+func (s *LocalStore) PresignPut(key, contentType string, size int64, expiry time.Duration) (string, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return "", err
+	}
+	expires := time.Now().Add(expiry).Unix()
+	query := url.Values{
+		"expires":   {strconv.FormatInt(expires, 10)},
+		"signature": {s.putSignature(key, contentType, size, expires)},
+	}
+	return s.URL(key) + "?" + query.Encode(), nil
+}
+
+// ReceivePresignedPut stores body under key if expires and signature come from
+// a URL made by PresignPut that has not expired, and the request's content
+// type and size are the ones it was signed for.
+func (s *LocalStore) ReceivePresignedPut(ctx context.Context, key, contentType string, size int64, expires, signature string, body io.Reader) error {
+	key, err := cleanKey(key)
+	if err != nil {
+		return err
+	}
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || time.Now().Unix() > expiresAt {
+		return domain.Forbidden("upload URL is invalid or has expired")
+	}
+	expected := s.putSignature(key, contentType, size, expiresAt)
+	if !hmac.Equal([]byte(signature), []byte(expected)) {
+		return domain.Forbidden("upload URL does not match this request")
+	}
+	return s.Put(ctx, key, io.LimitReader(body, size), size, contentType)
+}
+
+func (s *LocalStore) getSignature(key string, expires int64) string {
+	mac := hmac.New(sha256.New, s.signingKey)
+	fmt.Fprintf(mac, "GET\n%s\n%d", key, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *LocalStore) putSignature(key, contentType string, size, expires int64) string {
+	mac := hmac.New(sha256.New, s.signingKey)
+	fmt.Fprintf(mac, "PUT\n%s\n%s\n%d\n%d", key, contentType, size, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *LocalStore) path(key string) (string, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
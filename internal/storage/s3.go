@@ -0,0 +1,211 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"raion-assessment/config"
+	contract "raion-assessment/domain/contract"
+	domain "raion-assessment/domain/entity"
+)
+
+// S3Store keeps media in a bucket on Amazon S3 or any server speaking its
+// API, such as MinIO. Requests are signed with SigV4 by hand so the service
+// needs no AWS SDK.
+type S3Store struct {
+	endpoint        *url.URL
+	presignEndpoint *url.URL
+	bucket          string
+	publicURL       string
+	pathStyle       bool
+	urlExpiry       time.Duration
+	signer          signer
+	client          *http.Client
+}
+
+func NewS3Store(cfg config.StorageConfig) (contract.IMediaStore, error) {
+	if cfg.S3Bucket == "" {
+		return nil, errors.New("S3_BUCKET is required for the s3 storage driver")
+	}
+	endpoint, err := url.Parse(cfg.S3Endpoint)
+	if err != nil || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid S3 endpoint %q", cfg.S3Endpoint)
+	}
+	presignEndpoint, err := url.Parse(cfg.S3PresignEndpoint)
+	if err != nil || presignEndpoint.Host == "" {
+		return nil, fmt.Errorf("invalid S3 presign endpoint %q", cfg.S3PresignEndpoint)
+	}
+	if cfg.URLExpiry > maxPresignExpiry {
+		return nil, fmt.Errorf("STORAGE_URL_EXPIRY must be at most %s for the s3 storage driver", maxPresignExpiry)
+	}
+
+	return &S3Store{
+		endpoint:        endpoint,
+		presignEndpoint: presignEndpoint,
+		bucket:          cfg.S3Bucket,
+		publicURL:       cfg.S3PublicURL,
+		pathStyle:       cfg.S3ForcePathStyle,
+		urlExpiry:       cfg.URLExpiry,
+		signer: signer{
+			accessKey: cfg.S3AccessKey,
+			secretKey: cfg.S3SecretKey,
+			region:    cfg.S3Region,
+			service:   "s3",
+		},
+		client: &http.Client{Timeout: 60 * time.Second},
+	}, nil
+}
+
+// Put streams body to the bucket. When size is unknown (negative) the body is
+// buffered first, since S3 requires a Content-Length on PUT.
+func (s *S3Store) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	if size < 0 {
+		data, err := io.ReadAll(body)
+		if err != nil {
+			return err
+		}
+		body, size = bytes.NewReader(data), int64(len(data))
+	}
+
+	req, err := s.newRequest(ctx, http.MethodPut, key, body)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func (s *S3Store) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := s.newRequest(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// Delete removes the object behind key. S3 reports success for missing keys.
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	req, err := s.newRequest(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := s.do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// URL returns the object's address under S3PublicURL when one is configured,
+// such as a CDN in front of the bucket, or its address on the endpoint. The
+// bucket is private, so it only identifies the object; see SignedURL.
+func (s *S3Store) URL(key string) string {
+	if s.publicURL != "" {
+		return joinURL(s.publicURL, key)
+	}
+	return s.objectURL(key).String()
+}
+
+// SignedURL returns a SigV4 query-signed GET URL on the presign endpoint. It
+// asks S3 to send the object with an Expires header at the moment the URL
+// expires, so clients keep it for as long as the URL lasts and no longer. A
+// max-age would have to be fixed when signing and count from each fetch.
+func (s *S3Store) SignedURL(key string) string {
+	start := signedURLStart(s.urlExpiry, time.Now())
+	u := s.objectURLOn(s.presignEndpoint, key)
+	u.RawQuery = url.Values{
+		"response-cache-control": {signedCacheControl},
+		"response-expires":       {start.Add(s.urlExpiry).UTC().Format(http.TimeFormat)},
+	}.Encode()
+	req := &http.Request{Method: http.MethodGet, URL: u, Header: http.Header{}}
+	return s.signer.presign(req, s.urlExpiry, start)
+}
+
+// KeyOf recognises signed URLs as well as the public and endpoint addresses
+// media URLs were recorded under before they were signed.
+func (s *S3Store) KeyOf(rawURL string) (string, bool) {
+	return keyUnder(rawURL, s.publicURL, s.objectURLOn(s.endpoint, "").String(), s.objectURLOn(s.presignEndpoint, "").String())
+}
+
+// PresignPut returns a SigV4 query-signed URL on the presign endpoint. The
+// content type and length are signed, so the server rejects an upload of any
+// other type or size.
+func (s *S3Store) PresignPut(key, contentType string, size int64, expiry time.Duration) (string, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return "", err
+	}
+	req := &http.Request{Method: http.MethodPut, URL: s.objectURLOn(s.presignEndpoint, key), Header: http.Header{}}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Content-Length", strconv.FormatInt(size, 10))
+	return s.signer.presign(req, expiry, time.Now()), nil
+}
+
+func (s *S3Store) objectURL(key string) *url.URL {
+	return s.objectURLOn(s.endpoint, key)
+}
+
+func (s *S3Store) objectURLOn(endpoint *url.URL, key string) *url.URL {
+	u := *endpoint
+	basePath := strings.TrimRight(u.Path, "/")
+	if s.pathStyle {
+		u.Path = basePath + "/" + s.bucket + "/" + key
+		u.RawPath = basePath + "/" + uriEncode(s.bucket) + "/" + escapeKey(key)
+	} else {
+		u.Host = s.bucket + "." + u.Host
+		u.Path = basePath + "/" + key
+		u.RawPath = basePath + "/" + escapeKey(key)
+	}
+	return &u
+}
+
+func (s *S3Store) newRequest(ctx context.Context, method, key string, body io.Reader) (*http.Request, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return http.NewRequestWithContext(ctx, method, s.objectURL(key).String(), body)
+}
+
+// do signs and sends req, turning error responses into errors. A missing
+// object is reported as a domain not-found error.
+func (s *S3Store) do(req *http.Request) (*http.Response, error) {
+	s.signer.sign(req, unsignedPayload, time.Now())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("s3 %s %s: %w", req.Method, req.URL.Path, err)
+	}
+	if resp.StatusCode < 300 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, domain.NotFound("media not found")
+	}
+	detail, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return nil, fmt.Errorf("s3 %s %s: %s: %s", req.Method, req.URL.Path, resp.Status, strings.TrimSpace(string(detail)))
+}
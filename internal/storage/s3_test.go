@@ -0,0 +1,258 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"raion-assessment/config"
+	contract "raion-assessment/domain/contract"
+	domain "raion-assessment/domain/entity"
+)
+
+const testBucket = "media"
+
+// fakeS3 is a path-style S3 stand-in in the manner of MinIO. It checks the
+// SigV4 signature of every request, in its Authorization header or in the
+// query of a presigned URL, and keeps objects in memory.
+type fakeS3 struct {
+	signer signer
+
+	mu      sync.Mutex
+	objects map[string]fakeObject
+}
+
+type fakeObject struct {
+	data        []byte
+	contentType string
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !f.authorized(r) {
+		http.Error(w, "SignatureDoesNotMatch", http.StatusForbidden)
+		return
+	}
+	key, ok := strings.CutPrefix(r.URL.Path, "/"+testBucket+"/")
+	if !ok {
+		http.Error(w, "NoSuchBucket", http.StatusNotFound)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		data, err := io.ReadAll(r.Body)
+		if err != nil || int64(len(data)) != r.ContentLength {
+			http.Error(w, "IncompleteBody", http.StatusBadRequest)
+			return
+		}
+		f.objects[key] = fakeObject{data: data, contentType: r.Header.Get("Content-Type")}
+	case http.MethodGet:
+		object, ok := f.objects[key]
+		if !ok {
+			http.Error(w, "NoSuchKey", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", object.contentType)
+		w.Write(object.data)
+	case http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, "MethodNotAllowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// authorized signs the request again from what arrived over the wire, limited
+// to the headers the client says it signed, and compares the signatures.
+func (f *fakeS3) authorized(r *http.Request) bool {
+	signed := &http.Request{
+		Method: r.Method,
+		URL:    &url.URL{Scheme: "http", Host: r.Host, Path: r.URL.Path, RawPath: r.URL.RawPath},
+		Header: http.Header{},
+	}
+	query := r.URL.Query()
+
+	if signature := query.Get("X-Amz-Signature"); signature != "" {
+		date, err := time.Parse(sigV4TimeFormat, query.Get("X-Amz-Date"))
+		expires, _ := strconv.Atoi(query.Get("X-Amz-Expires"))
+		expiry := time.Duration(expires) * time.Second
+		if err != nil || time.Now().After(date.Add(expiry)) {
+			return false
+		}
+		copySignedHeaders(r, signed, query.Get("X-Amz-SignedHeaders"))
+		for _, name := range []string{"X-Amz-Algorithm", "X-Amz-Credential", "X-Amz-Date", "X-Amz-Expires", "X-Amz-SignedHeaders", "X-Amz-Signature"} {
+			query.Del(name)
+		}
+		signed.URL.RawQuery = query.Encode()
+		presigned, err := url.Parse(f.signer.presign(signed, expiry, date))
+		return err == nil && presigned.Query().Get("X-Amz-Signature") == signature
+	}
+
+	authorization := r.Header.Get("Authorization")
+	_, signedHeaders, _ := strings.Cut(authorization, "SignedHeaders=")
+	signedHeaders, _, _ = strings.Cut(signedHeaders, ",")
+	date, err := time.Parse(sigV4TimeFormat, r.Header.Get("X-Amz-Date"))
+	if err != nil {
+		return false
+	}
+	copySignedHeaders(r, signed, signedHeaders)
+	signed.URL.RawQuery = r.URL.RawQuery
+	f.signer.authorize(signed, r.Header.Get("X-Amz-Content-Sha256"), date)
+	return signed.Header.Get("Authorization") == authorization
+}
+
+func copySignedHeaders(from, to *http.Request, signedHeaders string) {
+	for _, name := range strings.Split(signedHeaders, ";") {
+		switch name {
+		case "host":
+		case "content-length":
+			to.Header.Set(name, strconv.FormatInt(from.ContentLength, 10))
+		default:
+			to.Header[http.CanonicalHeaderKey(name)] = from.Header.Values(name)
+		}
+	}
+}
+
+// newS3Test starts a fakeS3 holding requests to the given credentials and
+// returns it with a store pointed at it that signs with secretKey.
+func newS3Test(t *testing.T, secretKey string) (*fakeS3, contract.IMediaStore) {
+	t.Helper()
+	fake := &fakeS3{
+		signer:  signer{accessKey: "minio", secretKey: "minio-secret", region: "us-east-1", service: "s3"},
+		objects: map[string]fakeObject{},
+	}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	store, err := NewS3Store(config.StorageConfig{
+		URLExpiry:         time.Hour,
+		S3Endpoint:        server.URL,
+		S3PresignEndpoint: server.URL,
+		S3Region:          "us-east-1",
+		S3Bucket:          testBucket,
+		S3AccessKey:       "minio",
+		S3SecretKey:       secretKey,
+		S3ForcePathStyle:  true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fake, store
+}
+
+func TestS3StorePutGetDelete(t *testing.T) {
+	fake, store := newS3Test(t, "minio-secret")
+	ctx := context.Background()
+	key := "posts/summer trip/a+b.jpg"
+
+	if err := store.Put(ctx, key, strings.NewReader("jpeg bytes"), 10, "image/jpeg"); err != nil {
+		t.Fatal(err)
+	}
+	if object := fake.objects[key]; string(object.data) != "jpeg bytes" || object.contentType != "image/jpeg" {
+		t.Fatalf("stored %q as %q, want %q as image/jpeg", object.data, object.contentType, "jpeg bytes")
+	}
+	if err := store.Put(ctx, "posts/unsized.txt", strings.NewReader("no length"), -1, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	body, err := store.Get(ctx, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := io.ReadAll(body)
+	body.Close()
+	if string(data) != "jpeg bytes" {
+		t.Fatalf("Get returned %q, want %q", data, "jpeg bytes")
+	}
+
+	if err := store.Delete(ctx, key); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Get(ctx, key); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("Get after Delete: got %v, want a not-found error", err)
+	}
+	if err := store.Delete(ctx, key); err != nil {
+		t.Fatalf("deleting a missing object: %v", err)
+	}
+}
+
+func TestS3StoreRejectedSignature(t *testing.T) {
+	_, store := newS3Test(t, "wrong-secret")
+
+	err := store.Put(context.Background(), "posts/a.jpg", strings.NewReader("x"), 1, "image/jpeg")
+	if err == nil || !strings.Contains(err.Error(), "403") {
+		t.Fatalf("Put with the wrong secret: got %v, want a 403 error", err)
+	}
+}
+
+func TestS3StorePresignPut(t *testing.T) {
+	fake, store := newS3Test(t, "minio-secret")
+	presigned, err := store.PresignPut("incoming/u1/abc", "video/mp4", 4, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	send := func(contentType, body string) int {
+		req, err := http.NewRequest(http.MethodPut, presigned, bytes.NewReader([]byte(body)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Content-Type", contentType)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	if status := send("image/png", "abcd"); status != http.StatusForbidden {
+		t.Errorf("upload of another type: got %d, want 403", status)
+	}
+	if status := send("video/mp4", "abcdef"); status != http.StatusForbidden {
+		t.Errorf("upload of another size: got %d, want 403", status)
+	}
+	if status := send("video/mp4", "abcd"); status != http.StatusOK {
+		t.Fatalf("upload as signed: got %d, want 200", status)
+	}
+	if object := fake.objects["incoming/u1/abc"]; string(object.data) != "abcd" {
+		t.Fatalf("stored %q, want %q", object.data, "abcd")
+	}
+
+	body, err := store.Get(context.Background(), "incoming/u1/abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body.Close()
+}
+
+func TestS3StoreSignedURL(t *testing.T) {
+	_, store := newS3Test(t, "minio-secret")
+	if err := store.Put(context.Background(), "posts/a.jpg", strings.NewReader("jpeg"), 4, "image/jpeg"); err != nil {
+		t.Fatal(err)
+	}
+
+	signed := store.SignedURL("posts/a.jpg")
+	resp, err := http.Get(signed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("GET of a signed URL: got %d, want 200", resp.StatusCode)
+	}
+	if key, ok := store.KeyOf(signed); !ok || key != "posts/a.jpg" {
+		t.Fatalf("KeyOf(signed URL) = %q, %v, want posts/a.jpg", key, ok)
+	}
+}
@@ -0,0 +1,80 @@
+package storage
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"raion-assessment/config"
+	contract "raion-assessment/domain/contract"
+	domain "raion-assessment/domain/entity"
+)
+
+// NewMediaStore builds the media store selected by cfg.Driver.
+func NewMediaStore(cfg config.StorageConfig) (contract.IMediaStore, error) {
+	switch cfg.Driver {
+	case "", "local":
+		if cfg.SigningKey == "" {
+			return nil, fmt.Errorf("STORAGE_SIGNING_KEY must be set for the local storage driver")
+		}
+		return NewLocalStore(cfg.LocalDir, cfg.PublicBaseURL, []byte(cfg.SigningKey), cfg.URLExpiry), nil
+	case "s3":
+		return NewS3Store(cfg)
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q", cfg.Driver)
+	}
+}
+
+// cleanKey rejects keys that are empty, absolute or that would escape the
+// store's root once joined to it.
+func cleanKey(key string) (string, error) {
+	cleaned := path.Clean("/" + key)[1:]
+	if key == "" || strings.HasPrefix(key, "/") || cleaned != key {
+		return "", domain.Validation("invalid media key")
+	}
+	return cleaned, nil
+}
+
+func joinURL(base, key string) string {
+	return strings.TrimRight(base, "/") + "/" + escapeKey(key)
+}
+
+// keyUnder returns the key rawURL addresses when it lies under one of bases,
+// ignoring any query such as a signature.
+func keyUnder(rawURL string, bases ...string) (string, bool) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", false
+	}
+	u.RawQuery, u.Fragment = "", ""
+	address := u.String()
+	for _, base := range bases {
+		prefix := strings.TrimRight(base, "/") + "/"
+		if base == "" || !strings.HasPrefix(address, prefix) {
+			continue
+		}
+		key, err := url.PathUnescape(strings.TrimPrefix(address, prefix))
+		if err != nil {
+			return "", false
+		}
+		if key, err = cleanKey(key); err == nil {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+// signedURLStart is the time a signed URL made at now is signed as of. It is
+// rounded down to a quarter of expiry, so the same URL is handed out for a
+// while and clients can cache what it points at, and every URL handed out
+// stays valid for at least three quarters of expiry.
+func signedURLStart(expiry time.Duration, now time.Time) time.Time {
+	return now.Truncate(expiry / 4)
+}
+
+// signedCacheControl is sent with media fetched through a signed URL, next to
+// an Expires header at the URL's expiry. Keys are never reused for other
+// content, so it never needs revalidating while the URL lasts.
+const signedCacheControl = "private, immutable"
@@ -0,0 +1,76 @@
+// Package transcode runs the external ffmpeg binary that turns uploaded
+// videos and animated GIFs into files every browser can play.
+package transcode
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	contract "raion-assessment/domain/contract"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type ffmpeg struct {
+	path        string
+	maxSide     int
+	maxDuration time.Duration
+}
+
+// NewFFmpeg returns a transcoder that runs the ffmpeg binary at path. Output
+// is scaled down to fit maxSide and cut off after maxDuration.
+func NewFFmpeg(path string, maxSide int, maxDuration time.Duration) contract.IVideoTranscoder {
+	return &ffmpeg{path: path, maxSide: maxSide, maxDuration: maxDuration}
+}
+
+// Transcode writes srcPath to dstPath as H.264/AAC in an MP4 with the index
+// up front, so playback can start before the download ends. Only the first
+// video and audio streams are kept and every bit of metadata, such as the
+// location a phone recorded, is dropped.
+func (f *ffmpeg) Transcode(ctx context.Context, srcPath, dstPath string) error {
+	side := strconv.Itoa(f.maxSide)
+	return f.run(ctx, nil,
+		"-i", srcPath,
+		"-t", strconv.FormatFloat(f.maxDuration.Seconds(), 'f', -1, 64),
+		"-map", "0:v:0", "-map", "0:a:0?",
+		"-map_metadata", "-1", "-map_chapters", "-1",
+		"-vf", "scale=w='min("+side+",iw)':h='min("+side+",ih)':force_original_aspect_ratio=decrease:force_divisible_by=2",
+		"-c:v", "libx264", "-preset", "veryfast", "-crf", "23", "-pix_fmt", "yuv420p",
+		"-c:a", "aac", "-b:a", "128k",
+		"-movflags", "+faststart",
+		dstPath,
+	)
+}
+
+// Poster returns the first frame of videoPath as a PNG.
+func (f *ffmpeg) Poster(ctx context.Context, videoPath string) ([]byte, error) {
+	var out bytes.Buffer
+	err := f.run(ctx, &out,
+		"-i", videoPath,
+		"-frames:v", "1",
+		"-f", "image2pipe", "-c:v", "png",
+		"-",
+	)
+	if err != nil {
+		return nil, err
+	}
+	if out.Len() == 0 {
+		return nil, fmt.Errorf("ffmpeg produced no poster frame")
+	}
+	return out.Bytes(), nil
+}
+
+func (f *ffmpeg) run(ctx context.Context, stdout *bytes.Buffer, args ...string) error {
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, f.path, append([]string{"-nostdin", "-y", "-v", "error"}, args...)...)
+	if stdout != nil {
+		cmd.Stdout = stdout
+	}
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("ffmpeg: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
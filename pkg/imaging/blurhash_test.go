@@ -0,0 +1,81 @@
+package imaging
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+// The expected hashes were computed with a separate port of the reference
+// TypeScript encoder (https://github.com/woltapp/blurhash) for the same pixels.
+
+func solidImage(w, h int, c color.RGBA) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.SetRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+// grayRamp runs from black on the left towards white on the right.
+func grayRamp(w, h int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			v := uint8(x * 17)
+			img.SetRGBA(x, y, color.RGBA{v, v, v, 255})
+		}
+	}
+	return img
+}
+
+func TestBlurHash(t *testing.T) {
+	tests := []struct {
+		name                     string
+		img                      image.Image
+		xComponents, yComponents int
+		want                     string
+	}{
+		{"solid", solidImage(8, 8, color.RGBA{255, 0, 0, 255}), 4, 3, "LfTI:j|cfQ|c|csUfQsUfQfQfQfQ"},
+		{"DC only", solidImage(8, 4, color.RGBA{30, 120, 200, 255}), 1, 1, "003e?K"},
+		{"gray ramp", grayRamp(16, 8), 4, 3, "L$Hx$$00xuofxuRjj[j[fQfQfQfQ"},
+		{"colour blocks", gridImage(source, 4), 4, 3, "L]Lqe9^._l:@M{+Im^V|M{raV|WF"},
+		{"colour blocks, most components", gridImage(source, 4), 9, 9, "|]Lqe9^._l:@L9o%fQx?$0M{+Im^V|s%SxfQnirvM{raV|WFn~W.fQjZjI-;b:wbsmSzoMfQkBk8fQfQfQfQfQfQfQfQfQxus*rvnmbXbIfQj@n%M{raV|WFn~W.fQjZjI%Motrsn+X5j]fQkBoIfQfQfQfQfQfQfQfQfQ"},
+		{"components clamped", gridImage(source, 4), 0, 12, BlurHash(gridImage(source, 4), 1, 9)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := BlurHash(test.img, test.xComponents, test.yComponents)
+			if got != test.want {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestBlurHashLength(t *testing.T) {
+	img := grayRamp(16, 8)
+	for x := 1; x <= 9; x++ {
+		for y := 1; y <= 9; y++ {
+			if got, want := len(BlurHash(img, x, y)), 4+2*x*y; got != want {
+				t.Errorf("%dx%d components: got length %d, want %d", x, y, got, want)
+			}
+		}
+	}
+}
+
+func TestBlurHashIgnoresBoundsOrigin(t *testing.T) {
+	padded := image.NewRGBA(image.Rect(0, 0, 20, 12))
+	img := grayRamp(16, 8)
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 16; x++ {
+			padded.SetRGBA(x+2, y+3, img.RGBAAt(x, y))
+		}
+	}
+	sub := padded.SubImage(image.Rect(2, 3, 18, 11))
+	if got, want := BlurHash(sub, 4, 3), BlurHash(img, 4, 3); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
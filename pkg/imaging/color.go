@@ -0,0 +1,43 @@
+package imaging
+
+import (
+	"fmt"
+	"image"
+)
+
+// DominantColor returns the most common colour of img as a "#rrggbb" hex
+// string. Pixels are grouped into buckets of similar colour and the average of
+// the fullest bucket wins, so noise and gradients do not split the vote. Like
+// BlurHash it is meant for a small copy of the image.
+func DominantColor(img image.Image) string {
+	rgba := flatten(img)
+	bounds := rgba.Bounds()
+
+	type bucket struct {
+		count   int
+		r, g, b int
+	}
+	var buckets [1 << 12]bucket
+	best := 0
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			offset := rgba.PixOffset(x, y)
+			r, g, b := int(rgba.Pix[offset]), int(rgba.Pix[offset+1]), int(rgba.Pix[offset+2])
+			index := r>>4<<8 | g>>4<<4 | b>>4
+
+			buckets[index].count++
+			buckets[index].r += r
+			buckets[index].g += g
+			buckets[index].b += b
+			if buckets[index].count > buckets[best].count {
+				best = index
+			}
+		}
+	}
+
+	winner := buckets[best]
+	if winner.count == 0 {
+		return "#000000"
+	}
+	return fmt.Sprintf("#%02x%02x%02x", winner.r/winner.count, winner.g/winner.count, winner.b/winner.count)
+}
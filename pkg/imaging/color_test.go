@@ -0,0 +1,47 @@
+package imaging
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestDominantColor(t *testing.T) {
+	twoTone := solidImage(4, 4, color.RGBA{20, 40, 200, 255})
+	for y := 0; y < 4; y++ {
+		twoTone.SetRGBA(0, y, color.RGBA{250, 250, 250, 255})
+	}
+
+	// Slightly different shades of red share a bucket and outvote the
+	// single colour that appears most often on its own.
+	shades := image.NewRGBA(image.Rect(0, 0, 5, 1))
+	shades.SetRGBA(0, 0, color.RGBA{200, 10, 10, 255})
+	shades.SetRGBA(1, 0, color.RGBA{202, 12, 14, 255})
+	shades.SetRGBA(2, 0, color.RGBA{204, 14, 12, 255})
+	shades.SetRGBA(3, 0, color.RGBA{0, 128, 0, 255})
+	shades.SetRGBA(4, 0, color.RGBA{0, 128, 0, 255})
+
+	transparent := image.NewNRGBA(image.Rect(0, 0, 3, 3))
+	transparent.SetNRGBA(1, 1, color.NRGBA{R: 255, A: 255})
+
+	tests := []struct {
+		name string
+		img  image.Image
+		want string
+	}{
+		{"solid", solidImage(6, 4, color.RGBA{0x12, 0x34, 0x56, 255}), "#123456"},
+		{"solid white", solidImage(2, 2, color.RGBA{255, 255, 255, 255}), "#ffffff"},
+		{"two-tone", twoTone, "#1428c8"},
+		{"two-tone minority", twoTone.SubImage(image.Rect(0, 0, 1, 4)), "#fafafa"},
+		{"similar shades", shades, "#ca0c0c"},
+		{"transparent on white", transparent, "#ffffff"},
+		{"empty", image.NewRGBA(image.Rect(0, 0, 0, 0)), "#000000"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := DominantColor(test.img); got != test.want {
+				t.Errorf("got %s, want %s", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+// Package imaging is the pure-Go image pipeline used when ingesting uploads:
+// decoding, EXIF auto-orientation, resizing and re-encoding. Re-encoding drops
+// every metadata segment of the original, GPS coordinates included.
+// Renditions are always encoded as JPEG: WebP is accepted as input only, as
+// there is no WebP encoder in pure Go.
+package imaging
+
+import (
+	"bytes"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+
+	_ "golang.org/x/image/webp"
+)
+
+// Decode decodes a JPEG, PNG, GIF or WebP image. Only the first frame of an
+// animated GIF is returned.
+func Decode(data []byte) (image.Image, error) {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	return img, err
+}
@@ -0,0 +1,286 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"testing"
+)
+
+// palette holds the colours of the labelled test images. They are far enough
+// apart to be told apart after a lossy JPEG round trip.
+var palette = []color.RGBA{
+	{255, 0, 0, 255},
+	{0, 255, 0, 255},
+	{0, 0, 255, 255},
+	{255, 255, 0, 255},
+	{0, 255, 255, 255},
+	{255, 0, 255, 255},
+}
+
+// source is the 3x2 grid of palette indexes every orientation starts from.
+var source = [][]int{
+	{0, 1, 2},
+	{3, 4, 5},
+}
+
+// displayed is the grid each orientation must show once oriented upright.
+var displayed = map[int][][]int{
+	OrientationNormal:     {{0, 1, 2}, {3, 4, 5}},
+	OrientationFlipH:      {{2, 1, 0}, {5, 4, 3}},
+	OrientationRotate180:  {{5, 4, 3}, {2, 1, 0}},
+	OrientationFlipV:      {{3, 4, 5}, {0, 1, 2}},
+	OrientationTranspose:  {{0, 3}, {1, 4}, {2, 5}},
+	OrientationRotate90:   {{3, 0}, {4, 1}, {5, 2}},
+	OrientationTransverse: {{5, 2}, {4, 1}, {3, 0}},
+	OrientationRotate270:  {{2, 5}, {1, 4}, {0, 3}},
+}
+
+// gridImage paints grid as blocks of block×block pixels.
+func gridImage(grid [][]int, block int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, len(grid[0])*block, len(grid)*block))
+	for y := 0; y < img.Bounds().Dy(); y++ {
+		for x := 0; x < img.Bounds().Dx(); x++ {
+			img.SetRGBA(x, y, palette[grid[y/block][x/block]])
+		}
+	}
+	return img
+}
+
+// readGrid samples the centre of each block of an image divided into cols by
+// rows blocks and returns the nearest palette index of each.
+func readGrid(img image.Image, cols, rows int) [][]int {
+	bounds := img.Bounds()
+	grid := make([][]int, rows)
+	for row := range grid {
+		grid[row] = make([]int, cols)
+		for col := range grid[row] {
+			x := bounds.Min.X + (2*col+1)*bounds.Dx()/(2*cols)
+			y := bounds.Min.Y + (2*row+1)*bounds.Dy()/(2*rows)
+			grid[row][col] = nearest(img.At(x, y))
+		}
+	}
+	return grid
+}
+
+func nearest(c color.Color) int {
+	r, g, b, _ := c.RGBA()
+	best, bestDistance := 0, -1
+	for i, p := range palette {
+		dr, dg, db := int(r>>8)-int(p.R), int(g>>8)-int(p.G), int(b>>8)-int(p.B)
+		if distance := dr*dr + dg*dg + db*db; bestDistance < 0 || distance < bestDistance {
+			best, bestDistance = i, distance
+		}
+	}
+	return best
+}
+
+func sameGrid(a, b [][]int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for row := range a {
+		if len(a[row]) != len(b[row]) {
+			return false
+		}
+		for col := range a[row] {
+			if a[row][col] != b[row][col] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// exifSegment builds an APP1 Exif segment whose first IFD records orientation
+// and points at a GPS IFD holding a latitude reference.
+func exifSegment(order binary.ByteOrder, orientation int) []byte {
+	tiff := make([]byte, 56)
+	if order == binary.LittleEndian {
+		copy(tiff, "II")
+	} else {
+		copy(tiff, "MM")
+	}
+	order.PutUint16(tiff[2:], 42)
+	order.PutUint32(tiff[4:], 8)
+
+	// IFD0: orientation and the GPS IFD pointer, then no next IFD.
+	order.PutUint16(tiff[8:], 2)
+	order.PutUint16(tiff[10:], exifOrientationTag)
+	order.PutUint16(tiff[12:], 3)
+	order.PutUint32(tiff[14:], 1)
+	order.PutUint16(tiff[18:], uint16(orientation))
+	order.PutUint16(tiff[22:], 0x8825)
+	order.PutUint16(tiff[24:], 4)
+	order.PutUint32(tiff[26:], 1)
+	order.PutUint32(tiff[30:], 38)
+
+	// GPS IFD: GPSLatitudeRef "N".
+	order.PutUint16(tiff[38:], 1)
+	order.PutUint16(tiff[40:], 1)
+	order.PutUint16(tiff[42:], 2)
+	order.PutUint32(tiff[44:], 2)
+	copy(tiff[48:], "N\x00")
+
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	segment := []byte{0xFF, jpegAPP1, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+	return append(segment, payload...)
+}
+
+// withSegment inserts segment into a JPEG straight after its SOI marker.
+func withSegment(data, segment []byte) []byte {
+	return append(append(append([]byte{}, data[:2]...), segment...), data[2:]...)
+}
+
+func encodeTestJPEG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 95}); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// jpegMarkers lists the markers of the segments a JPEG has before its scan.
+func jpegMarkers(t *testing.T, data []byte) []byte {
+	t.Helper()
+	var markers []byte
+	for offset := 2; ; {
+		if offset+4 > len(data) || data[offset] != 0xFF {
+			t.Fatalf("malformed JPEG segment at offset %d", offset)
+		}
+		marker := data[offset+1]
+		if marker == jpegStartOfScan {
+			return markers
+		}
+		markers = append(markers, marker)
+		offset += 2 + int(binary.BigEndian.Uint16(data[offset+2:]))
+	}
+}
+
+func TestOrient(t *testing.T) {
+	src := gridImage(source, 1)
+	for orientation := OrientationNormal; orientation <= OrientationRotate270; orientation++ {
+		want := displayed[orientation]
+		oriented := Orient(src, orientation)
+
+		bounds := oriented.Bounds()
+		if bounds.Dx() != len(want[0]) || bounds.Dy() != len(want) {
+			t.Errorf("orientation %d: got %dx%d, want %dx%d", orientation, bounds.Dx(), bounds.Dy(), len(want[0]), len(want))
+			continue
+		}
+		if got := readGrid(oriented, len(want[0]), len(want)); !sameGrid(got, want) {
+			t.Errorf("orientation %d: got %v, want %v", orientation, got, want)
+		}
+		if SwapsAxes(orientation) != (bounds.Dx() != src.Bounds().Dx()) {
+			t.Errorf("orientation %d: SwapsAxes = %v for a %dx%d result", orientation, SwapsAxes(orientation), bounds.Dx(), bounds.Dy())
+		}
+	}
+}
+
+func TestOrientSubImage(t *testing.T) {
+	padded := gridImage([][]int{{5, 5, 5, 5}, {5, 0, 1, 5}, {5, 2, 3, 5}}, 1)
+	sub := padded.SubImage(image.Rect(1, 1, 3, 3))
+
+	want := [][]int{{2, 0}, {3, 1}}
+	if got := readGrid(Orient(sub, OrientationRotate90), 2, 2); !sameGrid(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestOrientIgnoresUnknownValues(t *testing.T) {
+	src := gridImage(source, 1)
+	for _, orientation := range []int{0, -1, 9} {
+		if got := Orient(src, orientation); got != image.Image(src) {
+			t.Errorf("orientation %d: image was transformed", orientation)
+		}
+	}
+}
+
+func TestExifOrientation(t *testing.T) {
+	plain := encodeTestJPEG(t, gridImage(source, 8))
+	jfif := []byte{0xFF, 0xE0, 0, 16, 'J', 'F', 'I', 'F', 0, 1, 1, 0, 0, 1, 0, 1, 0, 0}
+
+	for orientation := OrientationNormal; orientation <= OrientationRotate270; orientation++ {
+		for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+			data := withSegment(plain, exifSegment(order, orientation))
+			if got := ExifOrientation(data); got != orientation {
+				t.Errorf("%v orientation %d: got %d", order, orientation, got)
+			}
+		}
+	}
+
+	truncated := exifSegment(binary.LittleEndian, OrientationRotate90)
+	tests := []struct {
+		name string
+		data []byte
+		want int
+	}{
+		{"after JFIF segment", withSegment(withSegment(plain, exifSegment(binary.BigEndian, OrientationRotate270)), jfif), OrientationRotate270},
+		{"no EXIF", plain, OrientationNormal},
+		{"out of range", withSegment(plain, exifSegment(binary.LittleEndian, 9)), OrientationNormal},
+		{"zero", withSegment(plain, exifSegment(binary.LittleEndian, 0)), OrientationNormal},
+		{"truncated segment", append([]byte{0xFF, 0xD8}, truncated[:20]...), OrientationNormal},
+		{"not a JPEG", []byte("\x89PNG\r\n\x1a\n"), OrientationNormal},
+		{"empty", nil, OrientationNormal},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := ExifOrientation(test.data); got != test.want {
+				t.Errorf("got %d, want %d", got, test.want)
+			}
+		})
+	}
+}
+
+// TestOrientedRendition runs a camera JPEG through the rendition pipeline for
+// each orientation: the rendition must display upright, fit its bounds and
+// carry none of the original's EXIF, GPS included.
+func TestOrientedRendition(t *testing.T) {
+	const maxSide = 120
+	plain := encodeTestJPEG(t, gridImage(source, 80))
+
+	for orientation := OrientationNormal; orientation <= OrientationRotate270; orientation++ {
+		original := withSegment(plain, exifSegment(binary.BigEndian, orientation))
+		img, err := Decode(original)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := EncodeJPEG(Orient(Fit(img, maxSide), ExifOrientation(original)), 90)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, marker := range jpegMarkers(t, data) {
+			if marker >= 0xE1 && marker <= 0xEF || marker == 0xFE {
+				t.Errorf("orientation %d: rendition has segment %#x", orientation, marker)
+			}
+		}
+		if bytes.Contains(data, []byte("Exif\x00\x00")) {
+			t.Errorf("orientation %d: rendition contains an Exif header", orientation)
+		}
+		if got := ExifOrientation(data); got != OrientationNormal {
+			t.Errorf("orientation %d: rendition records orientation %d", orientation, got)
+		}
+
+		rendition, err := Decode(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := displayed[orientation]
+		wantW, wantH := maxSide, maxSide*2/3
+		if SwapsAxes(orientation) {
+			wantW, wantH = wantH, wantW
+		}
+		if bounds := rendition.Bounds(); bounds.Dx() != wantW || bounds.Dy() != wantH {
+			t.Errorf("orientation %d: got %dx%d, want %dx%d", orientation, bounds.Dx(), bounds.Dy(), wantW, wantH)
+			continue
+		}
+		if got := readGrid(rendition, len(want[0]), len(want)); !sameGrid(got, want) {
+			t.Errorf("orientation %d: got %v, want %v", orientation, got, want)
+		}
+	}
+}
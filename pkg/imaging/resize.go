@@ -0,0 +1,40 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"image/jpeg"
+
+	xdraw "golang.org/x/image/draw"
+)
+
+// Fit scales img down so that neither side exceeds maxSide, keeping its aspect
+// ratio. Images that already fit are never upscaled. The result is always an
+// opaque copy, ready to be encoded as JPEG.
+func Fit(img image.Image, maxSide int) *image.RGBA {
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	if w <= maxSide && h <= maxSide {
+		return flatten(img)
+	}
+
+	dstW, dstH := maxSide, maxSide
+	if w >= h {
+		dstH = max(1, h*maxSide/w)
+	} else {
+		dstW = max(1, w*maxSide/h)
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstW, dstH))
+	xdraw.CatmullRom.Scale(dst, dst.Bounds(), flatten(img), image.Rect(0, 0, w, h), xdraw.Src, nil)
+	return dst
+}
+
+// EncodeJPEG encodes img as a baseline JPEG without any metadata.
+func EncodeJPEG(img image.Image, quality int) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
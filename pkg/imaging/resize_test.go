@@ -0,0 +1,63 @@
+package imaging
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestFit(t *testing.T) {
+	tests := []struct {
+		name         string
+		w, h         int
+		maxSide      int
+		wantW, wantH int
+	}{
+		{"smaller than the bounds", 100, 50, 320, 100, 50},
+		{"exactly the bounds", 320, 320, 320, 320, 320},
+		{"one side exactly the bounds", 320, 10, 320, 320, 10},
+		{"landscape", 640, 480, 320, 320, 240},
+		{"portrait", 480, 640, 320, 240, 320},
+		{"square", 2048, 2048, 1080, 1080, 1080},
+		{"one pixel over", 321, 200, 320, 320, 199},
+		{"thin landscape", 5000, 1, 320, 320, 1},
+		{"thin portrait", 1, 5000, 96, 1, 96},
+		{"single pixel", 1, 1, 96, 1, 1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fitted := Fit(image.NewRGBA(image.Rect(0, 0, test.w, test.h)), test.maxSide)
+			bounds := fitted.Bounds()
+			if bounds.Dx() != test.wantW || bounds.Dy() != test.wantH {
+				t.Fatalf("got %dx%d, want %dx%d", bounds.Dx(), bounds.Dy(), test.wantW, test.wantH)
+			}
+			if bounds.Dx() > test.maxSide || bounds.Dy() > test.maxSide {
+				t.Errorf("%dx%d exceeds %d", bounds.Dx(), bounds.Dy(), test.maxSide)
+			}
+			if bounds.Dx() > test.w || bounds.Dy() > test.h {
+				t.Errorf("%dx%d was upscaled to %dx%d", test.w, test.h, bounds.Dx(), bounds.Dy())
+			}
+		})
+	}
+}
+
+func TestFitReturnsOpaqueCopy(t *testing.T) {
+	src := image.NewNRGBA(image.Rect(10, 10, 14, 12))
+	src.SetNRGBA(10, 10, color.NRGBA{R: 255, A: 255})
+
+	fitted := Fit(src, 100)
+	if fitted.Bounds() != image.Rect(0, 0, 4, 2) {
+		t.Fatalf("got bounds %v, want a copy at the origin", fitted.Bounds())
+	}
+	if got := fitted.RGBAAt(0, 0); got != (color.RGBA{255, 0, 0, 255}) {
+		t.Errorf("opaque pixel: got %v", got)
+	}
+	if got := fitted.RGBAAt(3, 1); got != (color.RGBA{255, 255, 255, 255}) {
+		t.Errorf("transparent pixel: got %v, want white", got)
+	}
+
+	fitted.SetRGBA(0, 0, color.RGBA{A: 255})
+	if src.NRGBAAt(10, 10).R != 255 {
+		t.Error("Fit returned the source image rather than a copy")
+	}
+}
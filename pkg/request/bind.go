@@ -0,0 +1,32 @@
+package request
+
+import (
+	domain "raion-assessment/domain/entity"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Locale returns the locale requested by the client.
+func Locale(c *fiber.Ctx) string {
+	return LocaleFromHeader(c.Get(fiber.HeaderAcceptLanguage))
+}
+
+// Bind fills req from the JSON, form or multipart body when the request has
+// one, then the query string and finally the route parameters, so a body field
+// can never override the resource named in the path. The result is validated
+// before it is returned. Uploaded files are not bound; handlers read them with
+// c.FormFile.
+func Bind(c *fiber.Ctx, req interface{}) error {
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(req); err != nil {
+			return domain.Validation("Invalid request format")
+		}
+	}
+	if err := c.QueryParser(req); err != nil {
+		return domain.Validation("Invalid query parameters")
+	}
+	if err := c.ParamsParser(req); err != nil {
+		return domain.Validation("Invalid path parameters")
+	}
+	return Validate(Locale(c), req)
+}
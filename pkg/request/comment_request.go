@@ -1,5 +1,24 @@
 package request
 
+// CommentParams identifies a comment addressed as /posts/:post_id/comments/:id.
+type CommentParams struct {
+	PostID string `params:"post_id" json:"-" validate:"required,uuid"`
+	ID     string `params:"id" json:"-" validate:"required,uuid"`
+}
+
+// CommentIDParams identifies the comment of a nested route such as
+// /posts/:post_id/comments/:comment_id/like.
+type CommentIDParams struct {
+	PostID    string `params:"post_id" json:"-" validate:"required,uuid"`
+	CommentID string `params:"comment_id" json:"-" validate:"required,uuid"`
+}
+
+type ListCommentsRequest struct {
+	PostID string `params:"post_id" json:"-" validate:"required,uuid"`
+	Sort   string `query:"sort" json:"-" validate:"omitempty,oneof=newest top"`
+}
+
 type CreateCommentRequest struct {
+	PostID  string `params:"post_id" json:"-" validate:"required,uuid"`
 	Content string `json:"content" validate:"required" example:"This is a great post!"`
 }
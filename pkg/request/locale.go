@@ -0,0 +1,37 @@
+package request
+
+import (
+	"context"
+	"strings"
+)
+
+// DefaultLocale is used when a client asks for no supported language.
+const DefaultLocale = "en"
+
+type localeKey struct{}
+
+// LocaleFromHeader picks the first supported language from an
+// Accept-Language header, ignoring quality values and region subtags.
+func LocaleFromHeader(acceptLanguage string) string {
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag := strings.TrimSpace(strings.SplitN(part, ";", 2)[0])
+		tag = strings.ToLower(strings.SplitN(tag, "-", 2)[0])
+		if _, ok := messages[tag]; ok {
+			return tag
+		}
+	}
+	return DefaultLocale
+}
+
+// WithLocale stores the caller's locale for code that validates input outside
+// of a Fiber handler, such as the GraphQL resolvers.
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+func LocaleFromContext(ctx context.Context) string {
+	if locale, ok := ctx.Value(localeKey{}).(string); ok {
+		return locale
+	}
+	return DefaultLocale
+}
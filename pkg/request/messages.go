@@ -0,0 +1,86 @@
+package request
+
+import "strings"
+
+// messages holds the per-field validation messages for each supported
+// locale, keyed by validation rule. {field} and {param} are replaced with the
+// field name and the rule's parameter.
+var messages = map[string]map[string]string{
+	"en": {
+		"required": "{field} is required",
+		"email":    "{field} must be a valid email address",
+		"uuid":     "{field} must be a valid UUID",
+		"min":      "{field} must be at least {param} characters long",
+		"max":      "{field} must be at most {param} characters long",
+		"oneof":    "{field} must be one of: {param}",
+		"number":   "{field} must be a positive integer",
+		"cursor":   "{field} is not a valid cursor",
+		"invalid":  "{field} is invalid",
+
+		"min_items":  "{field} must contain at least {param} items",
+		"max_items":  "{field} must contain at most {param} items",
+		"post_media": "{field} must be one of the post's current images",
+		"duplicate":  "{field} must not list the same image twice",
+
+		"file_type":  "{field} must be a JPEG, PNG, GIF or WebP image",
+		"file_size":  "{field} must be at most {param}",
+		"image":      "{field} is not a readable image",
+		"dimensions": "{field} must be at most {param} pixels",
+		"media_type": "{field} must be a JPEG, PNG, GIF or WebP image or an MP4, MOV or WebM video",
+		"video":      "{field} is not a readable video",
+		"duration":   "{field} must be at most {param} seconds long",
+
+		"upload":            "{field} must be an upload of yours that has not expired",
+		"upload_incomplete": "{field} has not been uploaded completely",
+		"content_type":      "{field} is not the type of file that was declared",
+		"upload_size":       "{field} is not the size that was declared",
+		"media":             "{field} must be media of yours uploaded for this purpose",
+	},
+	"id": {
+		"required": "{field} wajib diisi",
+		"email":    "{field} harus berupa alamat email yang valid",
+		"uuid":     "{field} harus berupa UUID yang valid",
+		"min":      "{field} minimal {param} karakter",
+		"max":      "{field} maksimal {param} karakter",
+		"oneof":    "{field} harus salah satu dari: {param}",
+		"number":   "{field} harus berupa bilangan bulat positif",
+		"cursor":   "{field} bukan cursor yang valid",
+		"invalid":  "{field} tidak valid",
+
+		"min_items":  "{field} minimal berisi {param} item",
+		"max_items":  "{field} maksimal berisi {param} item",
+		"post_media": "{field} harus salah satu gambar post ini",
+		"duplicate":  "{field} tidak boleh memuat gambar yang sama dua kali",
+
+		"file_type":  "{field} harus berupa gambar JPEG, PNG, GIF atau WebP",
+		"file_size":  "{field} maksimal {param}",
+		"image":      "{field} bukan gambar yang dapat dibaca",
+		"dimensions": "{field} maksimal {param} piksel",
+		"media_type": "{field} harus berupa gambar JPEG, PNG, GIF atau WebP atau video MP4, MOV atau WebM",
+		"video":      "{field} bukan video yang dapat dibaca",
+		"duration":   "{field} maksimal berdurasi {param} detik",
+
+		"upload":            "{field} harus berupa unggahan milik Anda yang belum kedaluwarsa",
+		"upload_incomplete": "{field} belum selesai diunggah",
+		"content_type":      "{field} bukan jenis file yang dinyatakan",
+		"upload_size":       "{field} tidak berukuran sesuai yang dinyatakan",
+		"media":             "{field} harus berupa media milik Anda yang diunggah untuk keperluan ini",
+	},
+}
+
+// Message renders the message for a failed rule in locale, falling back to
+// English and then to the generic "invalid" message.
+func Message(locale, field, code, param string) string {
+	catalog, ok := messages[locale]
+	if !ok {
+		catalog = messages[DefaultLocale]
+	}
+	template, ok := catalog[code]
+	if !ok {
+		template = catalog["invalid"]
+	}
+	if code == "oneof" {
+		param = strings.ReplaceAll(param, " ", ", ")
+	}
+	return strings.NewReplacer("{field}", field, "{param}", param).Replace(template)
+}
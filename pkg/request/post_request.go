@@ -1,5 +1,40 @@
 package request
 
+// PostParams identifies a post addressed as /posts/:id.
+type PostParams struct {
+	ID string `params:"id" json:"-" validate:"required,uuid"`
+}
+
+// PostIDParams identifies the parent post of a nested route such as
+// /posts/:post_id/like.
+type PostIDParams struct {
+	PostID string `params:"post_id" json:"-" validate:"required,uuid"`
+}
+
+// CreatePostRequest holds the text fields of a new post. UploadIDs name
+// finished resumable uploads attached after any multipart files, MediaIDs
+// media from completed direct uploads attached after those, and AltText
+// describes the files in that order.
+type CreatePostRequest struct {
+	Caption   string   `form:"caption" json:"caption" validate:"required" example:"Had an amazing trip to the mountains!"`
+	AltText   []string `form:"alt_text" json:"-" validate:"max=10,dive,max=1000"`
+	UploadIDs []string `form:"upload_id" json:"-" validate:"max=10,dive,uuid"`
+	MediaIDs  []string `form:"media_id" json:"-" validate:"max=10,dive,uuid"`
+}
+
+// UpdatePostRequest changes the caption, the images or both. A nil Caption
+// keeps the current one and an empty one clears it. Media, when present,
+// lists the images to keep in their new order; images of the post left out
+// of it are removed.
 type UpdatePostRequest struct {
-	Caption string `json:"caption" validate:"required,min=1" example:"Had an amazing trip to the mountains!"`
+	ID      string          `params:"id" json:"-" validate:"required,uuid"`
+	Caption *string         `json:"caption,omitempty" example:"Had an amazing trip to the mountains!"`
+	Media   []PostMediaItem `json:"media,omitempty" validate:"omitnil,min=1,max=10,dive"`
+}
+
+// PostMediaItem places one of a post's current images when they are
+// rearranged.
+type PostMediaItem struct {
+	MediaID string `json:"media_id" validate:"required,uuid" example:"6b1f2c9e-8a47-4d3b-9e21-5c0a7f3d2b18"`
+	AltText string `json:"alt_text" validate:"max=1000" example:"Sunset over the bay"`
 }
@@ -0,0 +1,5 @@
+package request
+
+type SearchRequest struct {
+	Query string `query:"query" json:"-" validate:"required"`
+}
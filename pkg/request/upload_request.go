@@ -0,0 +1,14 @@
+package request
+
+// UploadParams identifies a resumable upload addressed as /uploads/:id.
+type UploadParams struct {
+	ID string `params:"id" json:"-" validate:"required,uuid"`
+}
+
+// CreateDirectUploadRequest declares a file a client is about to send straight
+// to the media store: what it is for, its type and its exact size in bytes.
+type CreateDirectUploadRequest struct {
+	Purpose     string `json:"purpose" validate:"required,oneof=posts profile" example:"posts"`
+	ContentType string `json:"content_type" validate:"required,max=100" example:"image/jpeg"`
+	Size        int64  `json:"size" validate:"required,gt=0" example:"482113"`
+}
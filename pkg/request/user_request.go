@@ -1,5 +1,22 @@
 package request
 
+// UserParams identifies a user addressed as /users/:id.
+type UserParams struct {
+	ID string `params:"id" json:"-" validate:"required,uuid"`
+}
+
+// UserIDParams identifies the user of a nested route such as
+// /users/:user_id/likes.
+type UserIDParams struct {
+	UserID string `params:"user_id" json:"-" validate:"required,uuid"`
+}
+
+// UpdateUserRequest holds the profile fields to change. UploadID names a
+// finished resumable upload and MediaID the media of a completed direct
+// upload to use as the profile image.
 type UpdateUserRequest struct {
-	Username string `json:"username" validate:"required,min=3,max=50" example:"john_doe"`
+	Username string `form:"username" json:"username" validate:"omitempty,min=3,max=50" example:"john_doe"`
+	Bio      string `form:"bio" json:"bio" example:"Photographer and coffee lover"`
+	UploadID string `form:"upload_id" json:"upload_id" validate:"omitempty,uuid" example:"0f8c4a52-5d2e-4b7a-9a3e-1c6d2b9e7f10"`
+	MediaID  string `form:"media_id" json:"media_id" validate:"omitempty,uuid" example:"6b1f3c2e-8d4a-4f7b-9c1e-2a5d7e9f0b13"`
 }
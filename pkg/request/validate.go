@@ -0,0 +1,75 @@
+package request
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	domain "raion-assessment/domain/entity"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// validate is shared by the REST handlers and the GraphQL resolvers so that
+// both APIs enforce the same rules. Fields are reported under the name the
+// client sent them as, taken from the params, query, form or json tag.
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New()
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		for _, tag := range []string{"params", "query", "form", "json"} {
+			name := strings.SplitN(field.Tag.Get(tag), ",", 2)[0]
+			if name != "" && name != "-" {
+				return name
+			}
+		}
+		return field.Name
+	})
+	return v
+}
+
+// Validate checks req against its validate tags, returning a domain
+// validation error that lists every failing field with a message in locale.
+func Validate(locale string, req interface{}) error {
+	err := validate.Struct(req)
+	if err == nil {
+		return nil
+	}
+
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return err
+	}
+
+	fields := make([]domain.FieldError, 0, len(validationErrs))
+	for _, fieldErr := range validationErrs {
+		code := fieldErr.Tag()
+		// Length rules on lists count items rather than characters.
+		if kind := fieldErr.Kind(); (kind == reflect.Slice || kind == reflect.Array) && (code == "min" || code == "max") {
+			code += "_items"
+		}
+		fields = append(fields, domain.FieldError{
+			Field:   fieldErr.Field(),
+			Code:    code,
+			Message: Message(locale, fieldErr.Field(), code, fieldErr.Param()),
+		})
+	}
+	return domain.Validation("validation failed", fields...)
+}
+
+// Invalid reports a single field that failed a check made outside of the
+// struct tags, such as decoding a cursor.
+func Invalid(locale, field, code string) error {
+	return InvalidParam(locale, field, code, "")
+}
+
+// InvalidParam is Invalid for rules that take a parameter, such as a size
+// limit, which is filled into the message.
+func InvalidParam(locale, field, code, param string) error {
+	return domain.Validation("validation failed", domain.FieldError{
+		Field:   field,
+		Code:    code,
+		Message: Message(locale, field, code, param),
+	})
+}
@@ -3,9 +3,11 @@ package response
 import "time"
 
 type GetCommentsResponse struct {
-	Status string    `json:"status" example:"success"`
-	Data   []Comment `json:"data"`
-	Code   int       `json:"code" example:200`
+	Status     string    `json:"status" example:"success"`
+	Data       []Comment `json:"data"`
+	NextCursor string    `json:"next_cursor" example:"eyJ0IjoiMjAyNS0wMS0wMVQwMDowMDowMFoiLCJpZCI6IjEifQ"`
+	HasMore    bool      `json:"has_more" example:"true"`
+	Code       int       `json:"code" example:200`
 }
 
 type CreateCommentResponse struct {
@@ -25,6 +27,8 @@ type Comment struct {
 	UserID    string    `json:"user_id" example:"b3d1a42b-6871-4a47-bec3-6df0980a9c75"`
 	PostID    string    `json:"post_id" example:"c6f7c988-233f-4f3c-a74d-17f72e4a1b56"`
 	Content   string    `json:"content" example:"This is a comment!"`
+	LikeCount int       `json:"like_count" example:"3"`
+	LikedByMe bool      `json:"liked_by_me" example:"false"`
 	CreatedAt time.Time `json:"created_at" example:"2025-01-31T12:00:00Z"`
 	UpdatedAt time.Time `json:"updated_at" example:"2025-01-31T12:30:00Z"`
-}
\ No newline at end of file
+}
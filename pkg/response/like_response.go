@@ -9,9 +9,11 @@ type LikeResponse struct {
 }
 
 type GetAllLikesResponse struct {
-	Status string `json:"status" example:"success"`
-	Data   []Like `json:"data"`
-	Code   int    `json:"code" example:200`
+	Status     string `json:"status" example:"success"`
+	Data       []Like `json:"data"`
+	NextCursor string `json:"next_cursor" example:"eyJ0IjoiMjAyNS0wMS0wMVQwMDowMDowMFoiLCJpZCI6IjEifQ"`
+	HasMore    bool   `json:"has_more" example:"true"`
+	Code       int    `json:"code" example:200`
 }
 
 type Like struct {
@@ -20,3 +22,18 @@ type Like struct {
 	PostID    string    `json:"post_id" example:"c6f7c988-233f-4f3c-a74d-17f72e4a1b56"`
 	CreatedAt time.Time `json:"created_at" example:"2025-01-31T12:00:00Z"`
 }
+
+type GetAllCommentLikesResponse struct {
+	Status     string        `json:"status" example:"success"`
+	Data       []CommentLike `json:"data"`
+	NextCursor string        `json:"next_cursor" example:"eyJ0IjoiMjAyNS0wMS0wMVQwMDowMDowMFoiLCJpZCI6IjEifQ"`
+	HasMore    bool          `json:"has_more" example:"true"`
+	Code       int           `json:"code" example:"200"`
+}
+
+type CommentLike struct {
+	ID        string    `json:"id" example:"a1f5e4b3-8d2a-4c39-91a2-47b36295d8a3"`
+	UserID    string    `json:"user_id" example:"b3d1a42b-6871-4a47-bec3-6df0980a9c75"`
+	CommentID string    `json:"comment_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	CreatedAt time.Time `json:"created_at" example:"2025-01-31T12:00:00Z"`
+}
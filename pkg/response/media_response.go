@@ -0,0 +1,62 @@
+package response
+
+import "time"
+
+type Media struct {
+	ID            string      `json:"id" example:"6b1f2c9e-8a47-4d3b-9e21-5c0a7f3d2b18"`
+	Purpose       string      `json:"purpose" example:"posts" enums:"posts,profile"`
+	Kind          string      `json:"kind" example:"image" enums:"image,video"`
+	Status        string      `json:"status" example:"ready" enums:"pending,ready,failed"`
+	Width         int         `json:"width" example:"3024"`
+	Height        int         `json:"height" example:"4032"`
+	DurationMS    int         `json:"duration_ms" example:"0"`
+	BlurHash      string      `json:"blurhash" example:"LEHV6nWB2yk8pyo0adR*.7kCMdnj"`
+	DominantColor string      `json:"dominant_color" example:"#6f8fa8"`
+	Renditions    []Rendition `json:"renditions"`
+}
+
+type Rendition struct {
+	Name        string `json:"name" example:"feed" enums:"video,thumbnail,feed,full"`
+	URL         string `json:"url" example:"https://example.com/uploads/posts/2e0850c7/6b1f2c9e/feed.jpg?expires=1738328400&signature=9f2c..."`
+	ContentType string `json:"content_type" example:"image/jpeg"`
+	Width       int    `json:"width" example:"810"`
+	Height      int    `json:"height" example:"1080"`
+}
+
+// DirectUpload tells a client where to send a file straight to storage: a
+// request with Method to URL carrying every header in Headers.
+type DirectUpload struct {
+	ID           string            `json:"id" example:"0f8c4a52-5d2e-4b7a-9a3e-1c6d2b9e7f10"`
+	Method       string            `json:"method" example:"PUT"`
+	URL          string            `json:"url" example:"https://storage.example.com/media/incoming/2e0850c7/0f8c4a52?X-Amz-Signature=..."`
+	Headers      map[string]string `json:"headers"`
+	URLExpiresAt time.Time         `json:"url_expires_at" example:"2025-01-31T12:15:00Z"`
+	ExpiresAt    time.Time         `json:"expires_at" example:"2025-02-01T12:00:00Z"`
+}
+
+type CreateDirectUploadResponse struct {
+	Status string       `json:"status" example:"success"`
+	Data   DirectUpload `json:"data"`
+	Code   int          `json:"code" example:"201"`
+}
+
+// StorageUsage is how much of their storage quota a user has taken up, in
+// bytes. Reserved counts uploads started but not yet turned into media.
+type StorageUsage struct {
+	Used      int64 `json:"used" example:"52428800"`
+	Reserved  int64 `json:"reserved" example:"10485760"`
+	Quota     int64 `json:"quota" example:"1073741824"`
+	Remaining int64 `json:"remaining" example:"1010827264"`
+}
+
+type GetStorageUsageResponse struct {
+	Status string       `json:"status" example:"success"`
+	Data   StorageUsage `json:"data"`
+	Code   int          `json:"code" example:"200"`
+}
+
+type CompleteDirectUploadResponse struct {
+	Status string `json:"status" example:"success"`
+	Data   Media  `json:"data"`
+	Code   int    `json:"code" example:"201"`
+}
@@ -3,15 +3,19 @@ package response
 import "time"
 
 type GetAllPostsResponse struct {
-	Status string `json:"status" example:"success"`
-	Data   []Post `json:"data"`
-	Code   int    `json:"code" example:200`
+	Status     string `json:"status" example:"success"`
+	Data       []Post `json:"data"`
+	NextCursor string `json:"next_cursor" example:"eyJ0IjoiMjAyNS0wMS0wMVQwMDowMDowMFoiLCJpZCI6IjEifQ"`
+	HasMore    bool   `json:"has_more" example:"true"`
+	Code       int    `json:"code" example:200`
 }
 
 type SearchPostsResponse struct {
-	Status string `json:"status" example:"success"`
-	Data   []Post `json:"data"`
-	Code   int    `json:"code" example:200`
+	Status     string `json:"status" example:"success"`
+	Data       []Post `json:"data"`
+	NextCursor string `json:"next_cursor" example:"eyJ0IjoiMjAyNS0wMS0wMVQwMDowMDowMFoiLCJpZCI6IjEifQ"`
+	HasMore    bool   `json:"has_more" example:"true"`
+	Code       int    `json:"code" example:200`
 }
 
 type GetPostByIDResponse struct {
@@ -43,10 +47,29 @@ type DeletePostData struct {
 }
 
 type Post struct {
-	ID        string    `json:"id" example:"f9d6b52a-76a1-4b2b-9229-4c8db23a5ef2"`
-	UserID    string    `json:"user_id" example:"2e0850c7-d213-4a91-9b78-bb86e3a6f0d3"`
-	Caption   string    `json:"caption" example:"Had an amazing day at the beach!"`
-	ImageURL  string    `json:"image_url" example:"https://example.com/images/beach.jpg"`
-	CreatedAt time.Time `json:"created_at" example:"2025-01-31T12:00:00Z"`
-	UpdatedAt time.Time `json:"updated_at" example:"2025-01-31T12:30:00Z"`
+	ID             string      `json:"id" example:"f9d6b52a-76a1-4b2b-9229-4c8db23a5ef2"`
+	UserID         string      `json:"user_id" example:"2e0850c7-d213-4a91-9b78-bb86e3a6f0d3"`
+	Caption        string      `json:"caption" example:"Had an amazing day at the beach!"`
+	ImageURL       string      `json:"image_url" example:"https://example.com/images/beach.jpg"`
+	Media          []PostMedia `json:"media"`
+	Status         string      `json:"status" example:"ready" enums:"pending,ready,failed"`
+	LikeCount      int         `json:"like_count" example:"12"`
+	CommentCount   int         `json:"comment_count" example:"4"`
+	LikedByMe      bool        `json:"liked_by_me" example:"true"`
+	BookmarkedByMe bool        `json:"bookmarked_by_me" example:"false"` // Reserved for bookmarks, which are not supported yet: always false.
+	Author         *PostAuthor `json:"author,omitempty"`
+	CreatedAt      time.Time   `json:"created_at" example:"2025-01-31T12:00:00Z"`
+	UpdatedAt      time.Time   `json:"updated_at" example:"2025-01-31T12:30:00Z"`
+}
+
+type PostMedia struct {
+	Position int    `json:"position" example:"0"`
+	AltText  string `json:"alt_text" example:"Sunset over the bay"`
+	Media    Media  `json:"media"`
+}
+
+type PostAuthor struct {
+	ID       string `json:"id" example:"2e0850c7-d213-4a91-9b78-bb86e3a6f0d3"`
+	Username string `json:"username" example:"john_doe"`
+	ImageURL string `json:"image_url" example:"https://example.com/profile.jpg"`
 }
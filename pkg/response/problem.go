@@ -0,0 +1,90 @@
+package response
+
+import (
+	"errors"
+	"log"
+	"math"
+	domain "raion-assessment/domain/entity"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+)
+
+const ProblemContentType = "application/problem+json"
+
+// kindStatuses maps each domain error kind to the HTTP status it is served
+// with. Errors of no known kind are internal failures.
+var kindStatuses = []struct {
+	kind   error
+	status int
+}{
+	{domain.ErrNotFound, fiber.StatusNotFound},
+	{domain.ErrConflict, fiber.StatusConflict},
+	{domain.ErrForbidden, fiber.StatusForbidden},
+	{domain.ErrUnauthenticated, fiber.StatusUnauthorized},
+	{domain.ErrValidation, fiber.StatusBadRequest},
+	{domain.ErrRateLimited, fiber.StatusTooManyRequests},
+	{domain.ErrQuotaExceeded, fiber.StatusInsufficientStorage},
+}
+
+// statusCodes holds the machine-readable code reported for each status, the
+// same one the GraphQL API uses for the matching condition.
+var statusCodes = map[int]string{
+	fiber.StatusBadRequest:          "VALIDATION",
+	fiber.StatusUnauthorized:        "UNAUTHENTICATED",
+	fiber.StatusForbidden:           "FORBIDDEN",
+	fiber.StatusNotFound:            "NOT_FOUND",
+	fiber.StatusConflict:            "CONFLICT",
+	fiber.StatusTooManyRequests:     "RATE_LIMITED",
+	fiber.StatusInsufficientStorage: "QUOTA_EXCEEDED",
+}
+
+// Problem writes an RFC 7807 problem details response.
+func Problem(c *fiber.Ctx, status int, detail string, errors []domain.FieldError) error {
+	code := statusCodes[status]
+	if code == "" && status >= fiber.StatusInternalServerError {
+		code = "INTERNAL"
+	}
+	return c.Status(status).JSON(ErrorResponse{
+		Type:     "about:blank",
+		Title:    utils.StatusMessage(status),
+		Status:   status,
+		Detail:   detail,
+		Instance: c.Path(),
+		Code:     code,
+		Errors:   errors,
+	}, ProblemContentType)
+}
+
+// ErrorHandler is the application's central Fiber error handler. Domain
+// errors are written with the status for their kind, *fiber.Error keeps its
+// own status and anything else is logged and reported as a bare 500.
+func ErrorHandler(c *fiber.Ctx, err error) error {
+	if domainErr, ok := domain.AsError(err); ok {
+		if domainErr.Err != nil {
+			log.Printf("%s %s: %v", c.Method(), c.Path(), err)
+		}
+		if domainErr.RetryAfter > 0 {
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(domainErr.RetryAfter.Seconds()))))
+		}
+		return Problem(c, statusForKind(domainErr.Kind), domainErr.Message, domainErr.Fields)
+	}
+
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return Problem(c, fiberErr.Code, fiberErr.Message, nil)
+	}
+
+	log.Printf("%s %s: %v", c.Method(), c.Path(), err)
+	return Problem(c, fiber.StatusInternalServerError, "Internal Server Error. Please try again later.", nil)
+}
+
+func statusForKind(kind error) int {
+	for _, mapping := range kindStatuses {
+		if errors.Is(kind, mapping.kind) {
+			return mapping.status
+		}
+	}
+	return fiber.StatusInternalServerError
+}
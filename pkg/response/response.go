@@ -1,16 +1,23 @@
 package response
 
 import (
-	"log"
+	domain "raion-assessment/domain/entity"
 
-	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 )
 
+// ErrorResponse is the RFC 7807 problem details document written for every
+// failed request. Code is the same machine-readable code the GraphQL API
+// reports in extensions.code; Errors lists each invalid field of a rejected
+// request.
 type ErrorResponse struct {
-	Status  string `json:"status"`
-	Message string `json:"message"`
-	Code    int    `json:"code"`
+	Type     string              `json:"type" example:"about:blank"`
+	Title    string              `json:"title" example:"Not Found"`
+	Status   int                 `json:"status" example:"404"`
+	Detail   string              `json:"detail,omitempty" example:"post not found"`
+	Instance string              `json:"instance,omitempty" example:"/api/v1/posts/1"`
+	Code     string              `json:"code,omitempty" example:"NOT_FOUND"`
+	Errors   []domain.FieldError `json:"errors,omitempty"`
 }
 
 func Success(c *fiber.Ctx, data interface{}, statusCode ...int) error {
@@ -25,56 +32,23 @@ func Success(c *fiber.Ctx, data interface{}, statusCode ...int) error {
 	})
 }
 
-func Error(c *fiber.Ctx, message string, statusCode ...int) error {
-	code := fiber.StatusInternalServerError
-	if len(statusCode) > 0 {
-		code = statusCode[0]
-	}
-	return c.Status(code).JSON(fiber.Map{
-		"status":  "error",
-		"message": message,
-		"code":    code,
-	})
-}
-
-func ValidationError(c *fiber.Ctx, errors interface{}) error {
-	code := fiber.StatusBadRequest
+// Paginated writes the success envelope for one page of a list, adding the
+// opaque cursor for the next page and whether one exists.
+func Paginated(c *fiber.Ctx, data interface{}, nextCursor string, hasMore bool) error {
+	code := fiber.StatusOK
 	return c.Status(code).JSON(fiber.Map{
-		"status":  "error",
-		"errors":  errors,
-		"code":    code, 
+		"status":      "success",
+		"data":        data,
+		"next_cursor": nextCursor,
+		"has_more":    hasMore,
+		"code":        code,
 	})
 }
 
-var validate = validator.New()
-
-func ValidateStruct(s interface{}) map[string]string {
-	err := validate.Struct(s)
-
-	if err == nil {
-		return nil
-	}
-
-	errs := make(map[string]string)
-	for _, err := range err.(validator.ValidationErrors) {
-		errs[err.Field()] = err.Tag()
+func Error(c *fiber.Ctx, message string, statusCode ...int) error {
+	code := fiber.StatusInternalServerError
+	if len(statusCode) > 0 {
+		code = statusCode[0]
 	}
-
-	return errs
-}
-
-func HandleValidationError(c *fiber.Ctx, err string) error {
-	return ValidationError(c, err)
+	return Problem(c, code, message, nil)
 }
-
-func ParseAndValidateRequest(c *fiber.Ctx, req interface{}) error {
-	if err := c.BodyParser(req); err != nil {
-		log.Printf("Error parsing request body: %v", err)
-		return ValidationError(c, "Invalid request format")
-	}
-	if err := validate.Struct(req); err != nil {
-		log.Printf("Validation failed: %v", err)
-		return ValidationError(c, err.Error())
-	}
-	return nil
-}
\ No newline at end of file
@@ -3,15 +3,19 @@ package response
 import "time"
 
 type GetAllUsersResponse struct {
-	Status string `json:"status" example:"success"`
-	Data   []User `json:"data"`
-	Code   int    `json:"code" example:200`
+	Status     string `json:"status" example:"success"`
+	Data       []User `json:"data"`
+	NextCursor string `json:"next_cursor" example:"eyJ0IjoiMjAyNS0wMS0wMVQwMDowMDowMFoiLCJpZCI6IjEifQ"`
+	HasMore    bool   `json:"has_more" example:"true"`
+	Code       int    `json:"code" example:200`
 }
 
 type SearchUsersResponse struct {
-	Status string `json:"status" example:"success"`
-	Data   []User `json:"data"`
-	Code   int    `json:"code" example:200`
+	Status     string `json:"status" example:"success"`
+	Data       []User `json:"data"`
+	NextCursor string `json:"next_cursor" example:"eyJ0IjoiMjAyNS0wMS0wMVQwMDowMDowMFoiLCJpZCI6IjEifQ"`
+	HasMore    bool   `json:"has_more" example:"true"`
+	Code       int    `json:"code" example:200`
 }
 
 type GetUserByIDResponse struct {
@@ -32,12 +36,14 @@ type DeleteUserResponse struct {
 	Code   int    `json:"code" example:200`
 }
 
-type User struct { 
-	ID        string     `json:"id" example:"3d5a8b92-f1c5-4dbe-a2a7-1d9a8c743e9b"`
-	Username  string     `json:"username" example:"john_doe"`
-	Email     string     `json:"email" example:"john.doe@example.com"`
-	ImageURL  string     `json:"image_url" example:"https://example.com/profile.jpg"`
-	Bio       string     `json:"bio" example:"Hi there!"`
-	CreatedAt time.Time  `json:"created_at" example:"2025-01-31T12:00:00Z"`
-	UpdatedAt time.Time  `json:"updated_at" example:"2025-01-31T12:30:00Z"`
-}
\ No newline at end of file
+type User struct {
+	ID        string    `json:"id" example:"3d5a8b92-f1c5-4dbe-a2a7-1d9a8c743e9b"`
+	Username  string    `json:"username" example:"john_doe"`
+	Email     string    `json:"email" example:"john.doe@example.com"`
+	ImageURL  string    `json:"image_url" example:"https://example.com/profile.jpg"`
+	Avatar    *Media    `json:"avatar,omitempty"`
+	Bio       string    `json:"bio" example:"Hi there!"`
+	PostCount int       `json:"post_count" example:"8"`
+	CreatedAt time.Time `json:"created_at" example:"2025-01-31T12:00:00Z"`
+	UpdatedAt time.Time `json:"updated_at" example:"2025-01-31T12:30:00Z"`
+}
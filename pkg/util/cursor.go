@@ -0,0 +1,64 @@
+package util
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	entity "raion-assessment/domain/entity"
+	"raion-assessment/pkg/request"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// EncodeCursor turns a page cursor into the opaque string handed to clients.
+func EncodeCursor(cursor *entity.Cursor) string {
+	if cursor == nil {
+		return ""
+	}
+	raw, err := json.Marshal(cursor)
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func DecodeCursor(value string) (*entity.Cursor, error) {
+	if value == "" {
+		return nil, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var cursor entity.Cursor
+	if err := json.Unmarshal(raw, &cursor); err != nil || cursor.ID == "" {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
+
+// ParsePageRequest reads the limit and cursor query parameters. Limits above
+// entity.MaxPageSize are clamped rather than rejected; anything else invalid is
+// reported as a field error on the offending parameter.
+func ParsePageRequest(c *fiber.Ctx) (entity.PageRequest, error) {
+	page := entity.PageRequest{Limit: entity.DefaultPageSize}
+
+	if rawLimit := c.Query("limit"); rawLimit != "" {
+		limit, err := strconv.Atoi(rawLimit)
+		if err != nil || limit <= 0 {
+			return page, request.Invalid(request.Locale(c), "limit", "number")
+		}
+		page.Limit = limit
+	}
+
+	after, err := DecodeCursor(c.Query("cursor"))
+	if err != nil {
+		return page, request.Invalid(request.Locale(c), "cursor", "cursor")
+	}
+	page.After = after
+
+	return page.Normalized(), nil
+}
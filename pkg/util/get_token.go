@@ -1,7 +1,7 @@
 package util
 
 import (
-	"raion-assessment/pkg/response"
+	entity "raion-assessment/domain/entity"
 
 	fiber "github.com/gofiber/fiber/v2"
 )
@@ -9,7 +9,7 @@ import (
 func GetToken(c *fiber.Ctx) (string, error) {
 	authHeader := c.Get("Authorization")
 	if authHeader == "" || len(authHeader) <= len("Bearer ") {
-		return "", response.Error(c, "Missing or invalid token", fiber.StatusUnauthorized)
+		return "", entity.Unauthenticated("Missing or invalid token")
 	}
 	return authHeader[len("Bearer "):], nil
 }
@@ -3,7 +3,6 @@ package util
 import (
 	contract "raion-assessment/domain/contract"
 	entity "raion-assessment/domain/entity"
-	"raion-assessment/pkg/response"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -11,9 +10,21 @@ import (
 func GetUserFromToken(c *fiber.Ctx, authService contract.IAuthService) (*entity.User, error) {
 	authHeader := c.Get("Authorization")
 	if authHeader == "" || len(authHeader) <= len("Bearer ") {
-		return nil, response.Error(c, "Missing or invalid token", fiber.StatusUnauthorized)
+		return nil, entity.Unauthenticated("Missing or invalid token")
 	}
 	token := authHeader[len("Bearer "):]
 	ctx := c.Context()
 	return authService.GetCurrentUser(ctx, token)
 }
+
+func GetOptionalUserFromToken(c *fiber.Ctx, authService contract.IAuthService) *entity.User {
+	authHeader := c.Get("Authorization")
+	if len(authHeader) <= len("Bearer ") {
+		return nil
+	}
+	user, err := authService.GetCurrentUser(c.Context(), authHeader[len("Bearer "):])
+	if err != nil {
+		return nil
+	}
+	return user
+}
@@ -0,0 +1,27 @@
+package util
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+)
+
+var ErrInvalidGlobalID = errors.New("invalid global id")
+
+// ToGlobalID builds the opaque Relay object identifier for a row of the given
+// GraphQL type, e.g. base64("Post:<uuid>").
+func ToGlobalID(typeName, id string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(typeName + ":" + id))
+}
+
+func FromGlobalID(globalID string) (typeName string, id string, err error) {
+	raw, err := base64.RawURLEncoding.DecodeString(globalID)
+	if err != nil {
+		return "", "", ErrInvalidGlobalID
+	}
+	typeName, id, found := strings.Cut(string(raw), ":")
+	if !found || typeName == "" || id == "" {
+		return "", "", ErrInvalidGlobalID
+	}
+	return typeName, id, nil
+}
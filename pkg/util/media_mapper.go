@@ -0,0 +1,54 @@
+package util
+
+import (
+	entity "raion-assessment/domain/entity"
+	"raion-assessment/pkg/response"
+)
+
+func MapToMediaResponse(media *entity.Media) *response.Media {
+	if media == nil {
+		return nil
+	}
+	renditions := make([]response.Rendition, 0, len(media.Renditions))
+	for _, rendition := range media.Renditions {
+		renditions = append(renditions, response.Rendition{
+			Name:        rendition.Name,
+			URL:         rendition.URL,
+			ContentType: rendition.ContentType,
+			Width:       rendition.Width,
+			Height:      rendition.Height,
+		})
+	}
+	return &response.Media{
+		ID:            media.ID,
+		Purpose:       media.Purpose,
+		Kind:          media.Kind,
+		Status:        media.Status,
+		Width:         media.Width,
+		Height:        media.Height,
+		DurationMS:    media.DurationMS,
+		BlurHash:      media.BlurHash,
+		DominantColor: media.DominantColor,
+		Renditions:    renditions,
+	}
+}
+
+func MapToDirectUploadResponse(upload entity.DirectUpload) response.DirectUpload {
+	return response.DirectUpload{
+		ID:           upload.ID,
+		Method:       upload.Method,
+		URL:          upload.URL,
+		Headers:      upload.Headers,
+		URLExpiresAt: upload.URLExpiresAt,
+		ExpiresAt:    upload.ExpiresAt,
+	}
+}
+
+func MapToStorageUsageResponse(usage entity.StorageUsage) response.StorageUsage {
+	return response.StorageUsage{
+		Used:      usage.Used,
+		Reserved:  usage.Reserved,
+		Quota:     usage.Quota,
+		Remaining: usage.Remaining(),
+	}
+}
@@ -6,26 +6,49 @@ import (
 )
 
 func MapToPostResponse(posts []entity.Post) []response.Post {
-	var postResponse []response.Post
+	postResponse := make([]response.Post, 0, len(posts))
 	for _, post := range posts {
-		postResponse = append(postResponse, response.Post{
-			ID:        post.ID,
-			UserID:    post.UserID,
-			Caption:   post.Caption,
-			ImageURL:  post.ImageURL,
-			CreatedAt: post.CreatedAt,
-			UpdatedAt: post.UpdatedAt,
-		})
+		postResponse = append(postResponse, MapToSinglePostResponse(post))
 	}
 	return postResponse
 }
 
 func MapToSinglePostResponse(post entity.Post) response.Post {
 	return response.Post{
-		ID:        post.ID,
-		UserID:    post.UserID,
-		Caption:   post.Caption,
-		ImageURL:  post.ImageURL,
-		CreatedAt: post.CreatedAt,
+		ID:           post.ID,
+		UserID:       post.UserID,
+		Caption:      post.Caption,
+		ImageURL:     post.ImageURL,
+		Media:        mapToPostMediaResponse(post.Media),
+		Status:       post.Status,
+		LikeCount:    post.LikeCount,
+		CommentCount: post.CommentCount,
+		LikedByMe:    post.LikedByMe,
+		Author:       mapToPostAuthorResponse(post.Author),
+		CreatedAt:    post.CreatedAt,
+		UpdatedAt:    post.UpdatedAt,
+	}
+}
+
+func mapToPostAuthorResponse(author *entity.PostAuthor) *response.PostAuthor {
+	if author == nil {
+		return nil
+	}
+	return &response.PostAuthor{
+		ID:       author.ID,
+		Username: author.Name,
+		ImageURL: author.ImageURL,
 	}
-}
\ No newline at end of file
+}
+
+func mapToPostMediaResponse(media []entity.PostMedia) []response.PostMedia {
+	items := make([]response.PostMedia, 0, len(media))
+	for _, item := range media {
+		items = append(items, response.PostMedia{
+			Position: item.Position,
+			AltText:  item.AltText,
+			Media:    *MapToMediaResponse(&item.Media),
+		})
+	}
+	return items
+}
@@ -0,0 +1,31 @@
+package util
+
+import (
+	domain "raion-assessment/domain/entity"
+	"raion-assessment/pkg/request"
+)
+
+// ArrangePostMedia rebuilds a post's images from an edit that reorders,
+// relabels or drops them. Every item must name one of the post's current
+// images, at most once; new images cannot be added this way.
+func ArrangePostMedia(locale string, current []domain.PostMedia, items []request.PostMediaItem) ([]domain.PostMedia, error) {
+	byID := make(map[string]domain.Media, len(current))
+	for _, item := range current {
+		byID[item.Media.ID] = item.Media
+	}
+
+	arranged := make([]domain.PostMedia, 0, len(items))
+	seen := make(map[string]bool, len(items))
+	for i, item := range items {
+		media, ok := byID[item.MediaID]
+		if !ok {
+			return nil, request.Invalid(locale, "media_id", "post_media")
+		}
+		if seen[item.MediaID] {
+			return nil, request.Invalid(locale, "media_id", "duplicate")
+		}
+		seen[item.MediaID] = true
+		arranged = append(arranged, domain.PostMedia{Position: i, AltText: item.AltText, Media: media})
+	}
+	return arranged, nil
+}
@@ -1,11 +0,0 @@
-package util
-
-import (
-	"regexp"
-	"strings"
-)
-
-func sanitizeFileName(fileName string) string {
-	sanitized := strings.ReplaceAll(fileName, " ", "_")
-	return regexp.MustCompile(`[^a-zA-Z0-9\._-]`).ReplaceAllString(sanitized, "")
-}
\ No newline at end of file
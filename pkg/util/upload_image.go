@@ -0,0 +1,37 @@
+package util
+
+import (
+	"errors"
+	"mime/multipart"
+	domain "raion-assessment/domain/entity"
+	"raion-assessment/pkg/request"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+)
+
+// ImagePolicy says whether an endpoint requires an image with the request.
+type ImagePolicy int
+
+const (
+	ImageOptional ImagePolicy = iota
+	ImageRequired
+)
+
+// OpenFormImage opens the "image" file of a multipart request for the media
+// service to check and ingest. A missing file is a validation error when
+// policy is ImageRequired and otherwise yields a nil file. The caller closes
+// the file.
+func OpenFormImage(c *fiber.Ctx, policy ImagePolicy) (multipart.File, error) {
+	file, err := c.FormFile("image")
+	if errors.Is(err, fasthttp.ErrMissingFile) || errors.Is(err, fasthttp.ErrNoMultipartForm) {
+		if policy == ImageRequired {
+			return nil, request.Invalid(request.Locale(c), "image", "required")
+		}
+		return nil, nil
+	}
+	if err != nil {
+		return nil, domain.Validation("Invalid request format")
+	}
+	return file.Open()
+}
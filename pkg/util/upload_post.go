@@ -1,28 +1,53 @@
 package util
 
 import (
-	"os"
+	"errors"
+	"io"
+	"mime/multipart"
+	domain "raion-assessment/domain/entity"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
 )
 
-func UploadPostImage(c *fiber.Ctx, userID string, uploadDir string) (string, error) {
-	file, err := c.FormFile("image")
+// OpenPostMediaFiles opens the files of a multipart post request, sent as
+// repeated "media" files or, from older clients, repeated "images" files or
+// a single "image" file, in that order. A request without a multipart form
+// has no files. The caller closes the files with CloseFiles.
+func OpenPostMediaFiles(c *fiber.Ctx) ([]multipart.File, error) {
+	form, err := c.MultipartForm()
+	if errors.Is(err, fasthttp.ErrNoMultipartForm) {
+		return nil, nil
+	}
 	if err != nil {
-		return "", nil
+		return nil, domain.Validation("Invalid request format")
 	}
 
-	if _, err := os.Stat(uploadDir); os.IsNotExist(err) {
-		if err := os.MkdirAll(uploadDir, os.ModePerm); err != nil {
-			return "", err
+	headers := append(append(form.File["media"], form.File["images"]...), form.File["image"]...)
+	files := make([]multipart.File, 0, len(headers))
+	for _, header := range headers {
+		file, err := header.Open()
+		if err != nil {
+			CloseFiles(files)
+			return nil, err
 		}
+		files = append(files, file)
 	}
+	return files, nil
+}
 
-	sanitizedFileName := sanitizeFileName(file.Filename)
-	savePath := uploadDir + sanitizedFileName
-	if err := c.SaveFile(file, savePath); err != nil {
-		return "", err
+// Readers returns files as plain readers for the media service.
+func Readers(files []multipart.File) []io.ReadSeeker {
+	srcs := make([]io.ReadSeeker, 0, len(files))
+	for _, file := range files {
+		srcs = append(srcs, file)
 	}
+	return srcs
+}
 
-	return "https://raion-assessment.elginbrian.com" + savePath, nil
-}
\ No newline at end of file
+// CloseFiles closes files opened from a multipart form.
+func CloseFiles(files []multipart.File) {
+	for _, file := range files {
+		file.Close()
+	}
+}
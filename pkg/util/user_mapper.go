@@ -11,7 +11,9 @@ func MapToUserResponse(user entity.User) response.User {
 		Username:  user.Name,
 		Email:     user.Email,
 		Bio:       user.Bio,
+		PostCount: user.PostCount,
 		ImageURL:  user.ImageURL,
+		Avatar:    MapToMediaResponse(user.Avatar),
 		CreatedAt: user.CreatedAt,
 		UpdatedAt: user.UpdatedAt,
 	}